@@ -1,24 +1,123 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"hufschlaeger.net/gitlab-tasks-exporter/internal/cli"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/logging"
 	"hufschlaeger.net/gitlab-tasks-exporter/internal/service"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/webhook"
 )
 
 func main() {
 	cfg, err := cli.ParseFlags()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "❌ Fehler beim Parsen der Flags: %v\n", err)
+		// Ohne Konfiguration gilt --lang aus den Argumenten bzw. LANG
+		fmt.Fprintln(os.Stderr, i18n.New(cli.Lang(os.Args[1:])).T("cli.parse_failed", err))
 		os.Exit(1)
 	}
 
+	// Logs gehen nach stderr, Fortschrittsmeldungen bleiben auf stdout
+	if err := logging.Setup(os.Stderr, cfg.LogFormat, cfg.EffectiveLogLevel()); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.New(cfg.Lang).T("cli.logging_failed", err))
+		os.Exit(1)
+	}
+	slog.Debug("configuration loaded", "config", cfg)
+
+	// Export nach stdout: Fortschrittsmeldungen dürfen die Ausgabe nicht stören
+	if cfg.OutputFile == config.StdoutOutput || cfg.Command == "diff" || cfg.Command == "analytics" || cfg.Command == "timesheet" || (cfg.Command == "release-notes" && cfg.ReleaseChangelog == "") {
+		logging.SetProgressOutput(os.Stderr)
+	}
+
 	exporter := service.NewExporter(cfg)
 
-	if err := exporter.Export(); err != nil {
-		fmt.Fprintf(os.Stderr, "❌ Export fehlgeschlagen: %v\n", err)
+	switch cfg.Command {
+	case "", "export":
+		if err := exporter.Export(); err != nil {
+			fatal("export failed", err)
+		}
+
+	case "watch":
+		if err := runWatch(cfg, exporter); err != nil {
+			fatal("watch mode failed", err)
+		}
+
+	case "serve":
+		if err := runServe(cfg, exporter); err != nil {
+			fatal("webhook server failed", err)
+		}
+
+	case "diff":
+		if err := exporter.Diff(); err != nil {
+			fatal("diff failed", err)
+		}
+
+	case "release-notes":
+		if err := exporter.ReleaseNotes(); err != nil {
+			fatal("release notes failed", err)
+		}
+
+	case "analytics":
+		if err := exporter.Analytics(); err != nil {
+			fatal("analytics failed", err)
+		}
+
+	case "report":
+		if err := exporter.Report(); err != nil {
+			fatal("report failed", err)
+		}
+
+	case "timesheet":
+		if err := exporter.Timesheet(); err != nil {
+			fatal("timesheet failed", err)
+		}
+
+	case "todos":
+		if err := exporter.Todos(); err != nil {
+			fatal("todos sync failed", err)
+		}
+
+	default:
+		slog.Error("unknown command", "command", cfg.Command)
 		os.Exit(1)
 	}
 }
+
+// fatal protokolliert einen Fehler und beendet das Programm mit Exit-Code 1
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// runWatch startet den Watch-Modus bis SIGINT/SIGTERM
+func runWatch(cfg *config.Config, exporter *service.Exporter) error {
+	scheduler, err := service.NewScheduler(cfg, exporter)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return scheduler.Run(ctx)
+}
+
+// runServe startet den Webhook-Server bis SIGINT/SIGTERM
+func runServe(cfg *config.Config, exporter *service.Exporter) error {
+	server, err := webhook.NewServer(cfg, exporter)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return server.ListenAndServe(ctx)
+}
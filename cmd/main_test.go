@@ -45,6 +45,8 @@ func runMain(t *testing.T, args []string, extraEnv map[string]string) (output st
 		"GO_HELPER_ARGS="+strings.Join(args, " "),
 		// Disable godotenv so tests don't pick up a local .env file
 		"GODOTENV_DISABLE=1",
+		// Keep the output language deterministic (German default)
+		"LANG=",
 	)
 	for k, v := range extraEnv {
 		env = append(env, k+"="+v)
@@ -123,7 +125,7 @@ func TestMain_ExportError_ExitsOneAndPrintsMessage(t *testing.T) {
 		t.Fatalf("expected exit code 1 for export error, got %d. Output: %s", code, out)
 	}
 
-	if !strings.Contains(out, "Export fehlgeschlagen") {
+	if !strings.Contains(out, "level=ERROR msg=\"export failed\"") {
 		t.Fatalf("expected export error message, got: %s", out)
 	}
 }
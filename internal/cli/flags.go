@@ -4,8 +4,10 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
 )
 
 // ParseFlags parst Command-Line Arguments und ENV-Konfiguration
@@ -15,29 +17,111 @@ func ParseFlags() (*config.Config, error) {
 		return nil, err
 	}
 
+	// Texte der Flags zunächst in der Sprache aus LANG; --lang wird nach dem Parsen berücksichtigt
+	tr := i18n.New(cfg.Lang)
+
 	// 2. CLI-Flags definieren (überschreiben ENV-Werte)
 	var (
-		gitlabToken    = flag.String("gitlab-token", cfg.GitLabToken, "GitLab API Token (oder GITLAB_TOKEN)")
-		gitlabURL      = flag.String("gitlab-url", cfg.GitLabURL, "GitLab URL (oder GITLAB_URL)")
-		projectPath    = flag.String("project-path", cfg.ProjectPath, "GitLab Projekt-Pfad (oder PROJECT_PATH)")
-		milestoneTitle = flag.String("milestone", "", "Milestone-Filter (oder MILESTONE_TITLE)")
-		todoistToken   = flag.String("todoist-token", cfg.TodoistToken, "Todoist API Token (oder TODOIST_TOKEN)")
-		todoistProject = flag.String("todoist-project", cfg.TodoistProject, "Todoist Projekt-Name (oder TODOIST_PROJECT)")
-		todoistAPI     = flag.Bool("todoist", cfg.TodoistAPI, "Export zu Todoist API (oder TODOIST_API=true)")
-		outputFile     = flag.String("output", cfg.OutputFile, "Output-Datei für Markdown-Export (oder OUTPUT_FILE)")
-		verbose        = flag.Bool("verbose", cfg.Verbose, "Verbose-Modus (oder VERBOSE=true)")
-		help           = flag.Bool("help", false, "Hilfe anzeigen")
+		gitlabToken    = flag.String("gitlab-token", cfg.GitLabToken, tr.T("flag.gitlab-token"))
+		gitlabURL      = flag.String("gitlab-url", cfg.GitLabURL, tr.T("flag.gitlab-url"))
+		projectPath    = flag.String("project-path", cfg.ProjectPath, tr.T("flag.project-path"))
+		milestoneTitle = flag.String("milestone", "", tr.T("flag.milestone"))
+		todoistToken   = flag.String("todoist-token", cfg.TodoistToken, tr.T("flag.todoist-token"))
+		todoistProject = flag.String("todoist-project", cfg.TodoistProject, tr.T("flag.todoist-project"))
+		todoistAPI     = flag.Bool("todoist", cfg.TodoistAPI, tr.T("flag.todoist"))
+		outputFile     = flag.String("output", cfg.OutputFile, tr.T("flag.output"))
+		templateFile   = flag.String("template", cfg.MarkdownTemplate, tr.T("flag.template"))
+		format         = flag.String("format", cfg.Format, tr.T("flag.format"))
+		includeTodoist = flag.Bool("include-todoist", cfg.IncludeTodoistMapping, tr.T("flag.include-todoist"))
+		csvColumns     = flag.String("csv-columns", cfg.CSVColumns, tr.T("flag.csv-columns"))
+		csvSeparator   = flag.String("csv-separator", cfg.CSVSeparator, tr.T("flag.csv-separator"))
+		csvListDelim   = flag.String("csv-list-delimiter", cfg.CSVListDelimiter, tr.T("flag.csv-list-delimiter"))
+		csvBOM         = flag.Bool("csv-bom", cfg.CSVBOM, tr.T("flag.csv-bom"))
+		icsComponent   = flag.String("ics-component", cfg.ICSComponent, tr.T("flag.ics-component"))
+		verbose        = flag.Bool("verbose", cfg.Verbose, tr.T("flag.verbose"))
+		help           = flag.Bool("help", false, tr.T("flag.help"))
+		lang           = flag.String("lang", "", tr.T("flag.lang"))
+		logFormat      = flag.String("log-format", cfg.LogFormat, tr.T("flag.log-format"))
+		logLevel       = flag.String("log-level", cfg.LogLevel, tr.T("flag.log-level"))
+
+		watchInterval   = flag.Duration("interval", cfg.WatchInterval, tr.T("flag.interval"))
+		watchCron       = flag.String("cron", cfg.WatchCron, tr.T("flag.cron"))
+		watchMaxBackoff = flag.Duration("max-backoff", cfg.WatchMaxBackoff, tr.T("flag.max-backoff"))
+
+		listenAddr          = flag.String("listen", cfg.ListenAddr, tr.T("flag.listen"))
+		gitlabWebhookSecret = flag.String("gitlab-webhook-secret", cfg.GitLabWebhookSecret, tr.T("flag.gitlab-webhook-secret"))
+		gitlabWebhookNotes  = flag.Bool("webhook-notes", cfg.GitLabWebhookNotes, tr.T("flag.webhook-notes"))
+		todoistClientSecret = flag.String("todoist-client-secret", cfg.TodoistClientSecret, tr.T("flag.todoist-client-secret"))
+		todoistActions      = flag.String("todoist-webhook-actions", cfg.TodoistWebhookActions, tr.T("flag.todoist-webhook-actions"))
+		webhookDryRun       = flag.Bool("webhook-dry-run", cfg.WebhookDryRun, tr.T("flag.webhook-dry-run"))
+		stateFile           = flag.String("state-file", cfg.StateFile, tr.T("flag.state-file"))
+
+		milestoneProgress = flag.Bool("milestone-progress", cfg.MilestoneProgress, tr.T("flag.milestone-progress"))
+		issueLinks        = flag.Bool("issue-links", cfg.IssueLinks, tr.T("flag.issue-links"))
+		onlyUnblocked     = flag.Bool("only-unblocked", cfg.OnlyUnblocked, tr.T("flag.only-unblocked"))
+		hierarchy         = flag.Bool("hierarchy", cfg.Hierarchy, tr.T("flag.hierarchy"))
+
+		historyDir = flag.String("history-dir", cfg.HistoryDir, tr.T("flag.history-dir"))
+		diffFrom   = flag.String("from", cfg.DiffFrom, tr.T("flag.from"))
+		diffTo     = flag.String("to", cfg.DiffTo, tr.T("flag.to"))
+
+		releaseVersion    = flag.String("release-version", cfg.ReleaseVersion, tr.T("flag.release-version"))
+		since             = flag.String("since", "", tr.T("flag.since"))
+		until             = flag.String("until", "", tr.T("flag.until"))
+		releaseCategories = flag.String("release-categories", cfg.ReleaseCategories, tr.T("flag.release-categories"))
+		releaseChangelog  = flag.String("changelog", cfg.ReleaseChangelog, tr.T("flag.changelog"))
+		releaseMRs        = flag.Bool("merge-requests", cfg.ReleaseMergeRequests, tr.T("flag.merge-requests"))
+
+		analyticsLabels      = flag.String("labels", cfg.AnalyticsLabels, tr.T("flag.labels"))
+		analyticsDoingLabels = flag.String("doing-labels", cfg.AnalyticsDoingLabels, tr.T("flag.doing-labels"))
+
+		reportDueSoonDays = flag.Int("due-soon-days", cfg.ReportDueSoonDays, tr.T("flag.due-soon-days"))
+		reportStaleDays   = flag.Int("stale-days", cfg.ReportStaleDays, tr.T("flag.stale-days"))
+		reportTodoist     = flag.Bool("push-todoist", cfg.ReportTodoist, tr.T("flag.push-todoist"))
+
+		timesheetGroup    = flag.String("group", cfg.TimesheetGroup, tr.T("flag.group"))
+		timesheetUsers    = flag.String("users", cfg.TimesheetUsers, tr.T("flag.users"))
+		timesheetRounding = flag.String("rounding", cfg.TimesheetRounding, tr.T("flag.rounding"))
+
+		todosProject = flag.String("todos-project", cfg.TodosProject, tr.T("flag.todos-project"))
 	)
 
 	flag.Parse()
 
+	// Unterbefehl (z.B. "watch") inkl. nachgestellter Flags
+	if flag.NArg() > 0 {
+		cfg.Command = flag.Arg(0)
+		if err = flag.CommandLine.Parse(flag.Args()[1:]); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sprache früh übernehmen, damit Hilfe und Validierung sie bereits verwenden
+	if *lang != "" {
+		code, ok := i18n.Lookup(*lang)
+		if !ok {
+			return nil, tr.Errorf("cli.unsupported_lang", *lang, strings.Join(i18n.Supported(), ", "))
+		}
+		cfg.Lang = code
+		tr = i18n.New(code)
+		localizeFlagUsage(tr)
+	}
+
+	// Erst nach --lang melden, ein nachgestelltes --lang gilt sonst nicht
+	if flag.NArg() > 0 {
+		return nil, tr.Errorf("cli.unexpected_args", flag.Args())
+	}
+
 	if *help {
-		printUsage()
+		printUsage(tr)
 		os.Exit(0)
 	}
 
-	if err = cfg.Validate(); err != nil {
-		return nil, err
+	// todos braucht kein Projekt, timesheet mit --group ebenfalls; beide prüfen ihre Konfiguration selbst
+	if cfg.Command != "todos" && cfg.Command != "timesheet" {
+		if err = cfg.Validate(); err != nil {
+			return nil, err
+		}
 	}
 
 	// 3. CLI-Flags anwenden (überschreiben .env-Werte)
@@ -63,50 +147,114 @@ func ParseFlags() (*config.Config, error) {
 	if *outputFile != "" {
 		cfg.OutputFile = *outputFile
 	}
+	cfg.MarkdownTemplate = *templateFile
+	if *format != "" {
+		cfg.Format = *format
+	}
+	cfg.IncludeTodoistMapping = *includeTodoist
+	cfg.CSVColumns = *csvColumns
+	cfg.CSVSeparator = *csvSeparator
+	cfg.CSVListDelimiter = *csvListDelim
+	cfg.CSVBOM = *csvBOM
+	if *icsComponent != "" {
+		cfg.ICSComponent = *icsComponent
+	}
 	cfg.Verbose = *verbose
+	if *logFormat != "" {
+		cfg.LogFormat = *logFormat
+	}
+	cfg.LogLevel = *logLevel
+	cfg.WatchInterval = *watchInterval
+	cfg.WatchCron = *watchCron
+	cfg.WatchMaxBackoff = *watchMaxBackoff
+	if *listenAddr != "" {
+		cfg.ListenAddr = *listenAddr
+	}
+	if *gitlabWebhookSecret != "" {
+		cfg.GitLabWebhookSecret = *gitlabWebhookSecret
+	}
+	cfg.GitLabWebhookNotes = *gitlabWebhookNotes
+	if *todoistClientSecret != "" {
+		cfg.TodoistClientSecret = *todoistClientSecret
+	}
+	if *todoistActions != "" {
+		cfg.TodoistWebhookActions = *todoistActions
+	}
+	cfg.WebhookDryRun = *webhookDryRun
+	if *stateFile != "" {
+		cfg.StateFile = *stateFile
+	}
+	cfg.MilestoneProgress = *milestoneProgress
+	cfg.IssueLinks = *issueLinks
+	cfg.OnlyUnblocked = *onlyUnblocked
+	cfg.Hierarchy = *hierarchy
+	cfg.HistoryDir = *historyDir
+	cfg.DiffFrom = *diffFrom
+	cfg.DiffTo = *diffTo
+	cfg.ReleaseVersion = *releaseVersion
+	// --since/--until gelten für Release Notes und Timesheet, die ENV-Variablen getrennt
+	if *since != "" {
+		cfg.ReleaseSince = *since
+		cfg.TimesheetSince = *since
+	}
+	if *until != "" {
+		cfg.ReleaseUntil = *until
+		cfg.TimesheetUntil = *until
+	}
+	if *releaseCategories != "" {
+		cfg.ReleaseCategories = *releaseCategories
+	}
+	cfg.ReleaseChangelog = *releaseChangelog
+	cfg.ReleaseMergeRequests = *releaseMRs
+	cfg.AnalyticsLabels = *analyticsLabels
+	if *analyticsDoingLabels != "" {
+		cfg.AnalyticsDoingLabels = *analyticsDoingLabels
+	}
+	cfg.ReportDueSoonDays = *reportDueSoonDays
+	cfg.ReportStaleDays = *reportStaleDays
+	cfg.ReportTodoist = *reportTodoist
+	cfg.TimesheetGroup = *timesheetGroup
+	cfg.TimesheetUsers = *timesheetUsers
+	cfg.TimesheetRounding = *timesheetRounding
+	if *todosProject != "" {
+		cfg.TodosProject = *todosProject
+	}
 
 	return cfg, nil
 }
 
-func printUsage() {
-	fmt.Println(`GitLab zu Todoist Exporter
-
-VERWENDUNG:
-  gitlab-exporter [OPTIONS]
-
-KONFIGURATION:
-  Die Konfiguration kann über CLI-Flags, Umgebungsvariablen oder .env-Datei erfolgen.
-  Priorität: CLI-Flags > ENV-Variablen > .env-Datei
+// Lang liefert die Sprache für Meldungen, bevor ParseFlags eine Konfiguration liefert
+// (z.B. bei einem unbekannten Flag): --lang aus args, sonst LANG
+func Lang(args []string) string {
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name != "lang" {
+			continue
+		}
+		if !hasValue && i+1 < len(args) {
+			value = args[i+1]
+		}
+		if code, ok := i18n.Lookup(value); ok {
+			return code
+		}
+	}
+	return i18n.Normalize(os.Getenv("LANG"))
+}
 
-ENV-DATEI BEISPIEL (.env):
-  GITLAB_TOKEN=glpat-your-token
-  PROJECT_PATH=user/project
-  TODOIST_TOKEN=your-todoist-token
-  TODOIST_API=true
+// localizeFlagUsage setzt die Hilfetexte aller Flags in der Sprache des Translators
+func localizeFlagUsage(tr *i18n.Translator) {
+	flag.VisitAll(func(f *flag.Flag) {
+		f.Usage = tr.T("flag." + f.Name)
+	})
+}
 
-CLI-OPTIONEN:`)
+func printUsage(tr *i18n.Translator) {
+	fmt.Println(tr.T("usage.header"))
 
 	flag.PrintDefaults()
 
-	fmt.Println(`
-BEISPIELE:
-  # Export zu Markdown-Datei (mit .env-Konfiguration)
-  gitlab-exporter
-
-  # Export zu Todoist mit CLI-Parametern
-  gitlab-exporter --gitlab-token glpat-123 --project-path user/repo --todoist --todoist-token abc123
-
-  # Nur bestimmtes Milestone
-  gitlab-exporter --milestone "v1.0.0" --output milestone-v1.md
-
-ENV-VARIABLEN:
-  GITLAB_TOKEN     GitLab API Token
-  GITLAB_URL       GitLab URL (default: https://gitlab.com)
-  PROJECT_PATH     GitLab Projekt-Pfad (user/repository)
-  MILESTONE_TITLE  Milestone-Filter
-  TODOIST_TOKEN    Todoist API Token
-  TODOIST_PROJECT  Todoist Projekt-Name
-  TODOIST_API      Export zu Todoist (true/false)
-  OUTPUT_FILE      Output-Datei für Markdown-Export
-  VERBOSE          Verbose-Modus (true/false)`)
+	fmt.Println(tr.T("usage.footer"))
 }
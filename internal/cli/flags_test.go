@@ -48,6 +48,15 @@ func TestHelperProcess_ParseFlags(t *testing.T) {
 		TodoistAPI     bool   `json:"todoist_api"`
 		OutputFile     string `json:"output_file"`
 		Verbose        bool   `json:"verbose"`
+		Command        string `json:"command"`
+		WatchInterval  string `json:"watch_interval"`
+		WatchCron      string `json:"watch_cron"`
+		LogFormat      string `json:"log_format"`
+		LogLevel       string `json:"log_level"`
+		ReleaseSince   string `json:"release_since"`
+		TimesheetSince string `json:"timesheet_since"`
+		TimesheetUntil string `json:"timesheet_until"`
+		TimesheetGroup string `json:"timesheet_group"`
 	}{
 		GitLabURL:      cfg.GitLabURL,
 		ProjectPath:    cfg.ProjectPath,
@@ -56,6 +65,15 @@ func TestHelperProcess_ParseFlags(t *testing.T) {
 		TodoistAPI:     cfg.TodoistAPI,
 		OutputFile:     cfg.OutputFile,
 		Verbose:        cfg.Verbose,
+		Command:        cfg.Command,
+		WatchInterval:  cfg.WatchInterval.String(),
+		WatchCron:      cfg.WatchCron,
+		LogFormat:      cfg.LogFormat,
+		LogLevel:       cfg.LogLevel,
+		ReleaseSince:   cfg.ReleaseSince,
+		TimesheetSince: cfg.TimesheetSince,
+		TimesheetUntil: cfg.TimesheetUntil,
+		TimesheetGroup: cfg.TimesheetGroup,
 	}
 	if cfg.MilestoneTitle != nil {
 		out.MilestoneTitle = *cfg.MilestoneTitle
@@ -90,6 +108,14 @@ func runParseFlags(t *testing.T, args []string, env map[string]string) (output s
 	keys := []string{
 		"GITLAB_TOKEN", "GITLAB_URL", "PROJECT_PATH", "MILESTONE_TITLE",
 		"TODOIST_TOKEN", "TODOIST_PROJECT", "TODOIST_API", "OUTPUT_FILE", "VERBOSE",
+		"WATCH_INTERVAL", "WATCH_CRON", "WATCH_MAX_BACKOFF",
+		"LISTEN_ADDR", "GITLAB_WEBHOOK_SECRET", "GITLAB_WEBHOOK_NOTES",
+		"TODOIST_CLIENT_SECRET", "TODOIST_WEBHOOK_ACTIONS", "WEBHOOK_DRY_RUN", "STATE_FILE", "LOG_FORMAT", "LOG_LEVEL", "LANG", "MARKDOWN_TEMPLATE", "OUTPUT_FORMAT", "EXPORT_TODOIST_MAPPING",
+		"CSV_COLUMNS", "CSV_SEPARATOR", "CSV_LIST_DELIMITER", "CSV_BOM", "ICS_COMPONENT",
+		"MILESTONE_PROGRESS", "HISTORY_DIR", "DIFF_FROM", "DIFF_TO", "RELEASE_VERSION", "RELEASE_SINCE", "RELEASE_UNTIL", "RELEASE_CATEGORIES", "RELEASE_CHANGELOG", "RELEASE_MERGE_REQUESTS",
+		"ANALYTICS_LABELS", "ANALYTICS_DOING_LABELS", "REPORT_DUE_SOON_DAYS", "REPORT_STALE_DAYS", "REPORT_TODOIST",
+		"TIMESHEET_SINCE", "TIMESHEET_UNTIL", "TIMESHEET_GROUP", "TIMESHEET_USERS", "TIMESHEET_ROUNDING",
+		"ISSUE_LINKS", "ONLY_UNBLOCKED", "ISSUE_HIERARCHY", "TODOS_PROJECT",
 	}
 	for _, k := range keys {
 		e = append(e, k+"=")
@@ -213,3 +239,154 @@ func TestParseFlags_AppliesCLIOverridesAfterValidation(t *testing.T) {
 		t.Errorf("expected Verbose true after --verbose")
 	}
 }
+
+func TestParseFlags_CommandWithTrailingFlags(t *testing.T) {
+	env := map[string]string{
+		"GITLAB_TOKEN": "env-token",
+		"PROJECT_PATH": "env/project",
+	}
+
+	out, code := runParseFlags(t, []string{"--verbose", "watch", "--interval", "5m", "--cron", "@hourly", "--log-format", "json"}, env)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d. Output: %s", code, out)
+	}
+
+	idx := strings.Index(out, "CFG:")
+	if idx == -1 {
+		t.Fatalf("expected CFG: JSON in output, got: %s", out)
+	}
+
+	var got struct {
+		Verbose       bool   `json:"verbose"`
+		Command       string `json:"command"`
+		WatchInterval string `json:"watch_interval"`
+		WatchCron     string `json:"watch_cron"`
+		LogFormat     string `json:"log_format"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out[idx+4:])), &got); err != nil {
+		t.Fatalf("failed to decode config JSON: %v", err)
+	}
+
+	if got.Command != "watch" {
+		t.Errorf("expected command watch, got %q", got.Command)
+	}
+	if !got.Verbose || got.WatchInterval != "5m0s" || got.WatchCron != "@hourly" || got.LogFormat != "json" {
+		t.Errorf("flags around command not applied: %+v", got)
+	}
+}
+
+func TestParseFlags_SinceAppliesToReleaseNotesAndTimesheet(t *testing.T) {
+	env := map[string]string{
+		"GITLAB_TOKEN":    "env-token",
+		"PROJECT_PATH":    "env/project",
+		"TIMESHEET_UNTIL": "2024-03-31",
+	}
+
+	out, code := runParseFlags(t, []string{"timesheet", "--since", "2024-03-01"}, env)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d. Output: %s", code, out)
+	}
+
+	idx := strings.Index(out, "CFG:")
+	if idx == -1 {
+		t.Fatalf("expected CFG: JSON in output, got: %s", out)
+	}
+
+	var got struct {
+		ReleaseSince   string `json:"release_since"`
+		TimesheetSince string `json:"timesheet_since"`
+		TimesheetUntil string `json:"timesheet_until"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out[idx+4:])), &got); err != nil {
+		t.Fatalf("failed to decode config JSON: %v", err)
+	}
+
+	if got.ReleaseSince != "2024-03-01" || got.TimesheetSince != "2024-03-01" {
+		t.Errorf("--since should apply to both commands: %+v", got)
+	}
+	if got.TimesheetUntil != "2024-03-31" {
+		t.Errorf("unset --until should keep TIMESHEET_UNTIL: %+v", got)
+	}
+}
+
+func TestParseFlags_TodosNeedsNoProject(t *testing.T) {
+	env := map[string]string{"GITLAB_TOKEN": "env-token"}
+
+	out, code := runParseFlags(t, []string{"todos", "--todoist-token", "abc"}, env)
+	if code != 0 || !strings.Contains(out, `"command":"todos"`) || !strings.Contains(out, `"todoist_token":"abc"`) {
+		t.Fatalf("todos should not require PROJECT_PATH, got %d: %s", code, out)
+	}
+
+	if out, code := runParseFlags(t, []string{"report"}, env); code != 2 || !strings.Contains(out, "PARSE_ERROR:") {
+		t.Fatalf("other commands still require PROJECT_PATH, got %d: %s", code, out)
+	}
+}
+
+func TestParseFlags_TimesheetForGroupNeedsNoProject(t *testing.T) {
+	env := map[string]string{"GITLAB_TOKEN": "env-token"}
+
+	out, code := runParseFlags(t, []string{"timesheet", "--group", "mygroup"}, env)
+	if code != 0 || !strings.Contains(out, `"command":"timesheet"`) || !strings.Contains(out, `"timesheet_group":"mygroup"`) {
+		t.Fatalf("timesheet --group should not require PROJECT_PATH, got %d: %s", code, out)
+	}
+}
+
+func TestParseFlags_UnexpectedArguments(t *testing.T) {
+	env := map[string]string{
+		"GITLAB_TOKEN": "env-token",
+		"PROJECT_PATH": "env/project",
+	}
+
+	out, code := runParseFlags(t, []string{"watch", "extra"}, env)
+	if code != 2 || !strings.Contains(out, "unerwartete Argumente") {
+		t.Fatalf("expected unexpected argument error, got %d: %s", code, out)
+	}
+
+	// --lang gilt auch für diesen Fehler
+	out, code = runParseFlags(t, []string{"--lang", "en", "watch", "extra"}, env)
+	if code != 2 || !strings.Contains(out, "unexpected arguments") {
+		t.Fatalf("expected English unexpected argument error, got %d: %s", code, out)
+	}
+}
+
+func TestParseFlags_LangSelectsHelpLanguage(t *testing.T) {
+	out, code := runParseFlags(t, []string{"--lang", "en", "--help"}, nil)
+	if code != 0 {
+		t.Fatalf("expected exit code 0 for --help, got %d. Output: %s", code, out)
+	}
+	if !strings.Contains(out, "USAGE:") || !strings.Contains(out, "Show help") || strings.Contains(out, "VERWENDUNG:") {
+		t.Fatalf("expected English usage text, got: %s", out)
+	}
+
+	// LANG aus der Umgebung wirkt auch ohne --lang
+	out, _ = runParseFlags(t, []string{"--help"}, map[string]string{"LANG": "en_US.UTF-8"})
+	if !strings.Contains(out, "USAGE:") {
+		t.Fatalf("expected English usage text from LANG, got: %s", out)
+	}
+}
+
+func TestParseFlags_UnsupportedLang(t *testing.T) {
+	out, code := runParseFlags(t, []string{"--lang", "fr"}, nil)
+	if code != 2 || !strings.Contains(out, "nicht unterstützte Sprache") {
+		t.Fatalf("expected unsupported language error, got %d: %s", code, out)
+	}
+}
+
+func TestLang_BeforeConfigIsParsed(t *testing.T) {
+	t.Setenv("LANG", "de_DE.UTF-8")
+
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--lang", "en", "--unknown"}, "en"},
+		{[]string{"-lang=en", "watch"}, "en"},
+		{[]string{"--lang", "fr"}, "de"},
+		{[]string{"--unknown"}, "de"},
+	}
+	for _, c := range cases {
+		if got := Lang(c.args); got != c.want {
+			t.Errorf("Lang(%v) = %q, want %q", c.args, got, c.want)
+		}
+	}
+}
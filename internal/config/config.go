@@ -1,12 +1,15 @@
 package config
 
 import (
-	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
 )
 
 type Config struct {
@@ -19,12 +22,122 @@ type Config struct {
 	TodoistAPI     bool
 	OutputFile     string
 	Verbose        bool
+
+	// Format ist das Dateiformat des Exports (markdown, html, json, ndjson, csv, ics, obsidian, taskwarrior, org, todotxt, todoist-csv)
+	Format string
+	// IncludeTodoistMapping ergänzt maschinenlesbare Exporte um die berechnete Todoist-Abbildung
+	IncludeTodoistMapping bool
+
+	// MarkdownTemplate ist ein optionales eigenes text/template für den Markdown-Export
+	MarkdownTemplate string
+
+	// CSV-Export: Spalten, Trennzeichen (leer = passend zur Sprache),
+	// Trenner für mehrwertige Felder und optionales UTF-8 BOM für Excel
+	CSVColumns       string
+	CSVSeparator     string
+	CSVListDelimiter string
+	CSVBOM           bool
+
+	// ICSComponent legt fest, ob Fälligkeiten als VTODO oder VEVENT exportiert werden
+	ICSComponent string
+
+	// Logging (text oder json, Level debug/info/warn/error)
+	LogFormat string
+	LogLevel  string
+
+	// Lang ist die Sprache der Ausgaben (de, en)
+	Lang string
+
+	// Command ist der optionale Unterbefehl (z.B. "watch"), leer bedeutet einmaliger Export
+	Command string
+
+	// MilestoneProgress ergänzt Markdown und HTML um Fortschritt und Burndown je Milestone
+	MilestoneProgress bool
+
+	// IssueLinks lädt die Verknüpfungen der Issues ("blocks", "is blocked by", "relates to");
+	// OnlyUnblocked exportiert nur Issues, die von keinem offenen Issue blockiert werden
+	IssueLinks    bool
+	OnlyUnblocked bool
+
+	// Hierarchy lädt Epics und untergeordnete Work Items und bildet sie in Todoist und Markdown ab
+	Hierarchy bool
+
+	// HistoryDir speichert pro Lauf einen Snapshot der Issues (leer = deaktiviert)
+	HistoryDir string
+
+	// Diff: zu vergleichende Snapshots (Pfad oder Datum YYYY-MM-DD, leer = die beiden neuesten)
+	DiffFrom string
+	DiffTo   string
+
+	// Release Notes: Version, Zeitraum (YYYY-MM-DD), Label-Kategorien und optionales CHANGELOG.md
+	ReleaseVersion       string
+	ReleaseSince         string
+	ReleaseUntil         string
+	ReleaseCategories    string
+	ReleaseChangelog     string
+	ReleaseMergeRequests bool
+
+	// Analytics: Labels, die ein Issue alle tragen muss, und Labels für "in Arbeit" (Beginn der Cycle Time)
+	AnalyticsLabels      string
+	AnalyticsDoingLabels string
+
+	// Report: Vorlauf für "bald fällig", Tage ohne Änderung für "inaktiv" (0 = aus) und Push zu Todoist
+	ReportDueSoonDays int
+	ReportStaleDays   int
+	ReportTodoist     bool
+
+	// Timesheet: Zeitraum (YYYY-MM-DD, Standard: aktueller Monat), Gruppe statt Projekt,
+	// Personen (Username oder Name) und Rundung wie "15m" oder "nearest:6m"
+	TimesheetSince    string
+	TimesheetUntil    string
+	TimesheetGroup    string
+	TimesheetUsers    string
+	TimesheetRounding string
+
+	// TodosProject ist das Todoist-Projekt, in das der todos-Befehl die GitLab-To-Do-Liste spiegelt
+	TodosProject string
+
+	// Watch-Modus
+	WatchInterval   time.Duration
+	WatchCron       string
+	WatchMaxBackoff time.Duration
+
+	// Webhook-Server
+	ListenAddr          string
+	GitLabWebhookSecret string
+	GitLabWebhookNotes  bool
+
+	// Todoist-Webhooks (Todoist → GitLab)
+	TodoistClientSecret   string
+	TodoistWebhookActions string
+	WebhookDryRun         bool
+
+	// StateFile speichert die Verknüpfung von Todoist Tasks mit GitLab Issues
+	StateFile string
 }
 
+// DefaultOutputFile ist der Standard-Dateiname; seine Endung richtet sich nach dem Format
+const DefaultOutputFile = "gitlab_issues.md"
+
+// StdoutOutput als Output-Datei schreibt den Export nach stdout
+const StdoutOutput = "-"
+
+// DefaultReleaseCategories ordnet Labels den Kategorien von Keep a Changelog zu; "*" fängt alle übrigen
+const DefaultReleaseCategories = "Added=feature,enhancement;Changed=*;Deprecated=deprecation;Removed=removal;Fixed=bug,fix;Security=security"
+
+// DefaultAnalyticsDoingLabels markiert ein Issue als in Arbeit; Scoped Labels passen auch über ihren Wert
+const DefaultAnalyticsDoingLabels = "doing,in progress"
+
+// DefaultTodosProject ist das Todoist-Projekt für die GitLab-To-Do-Liste
+const DefaultTodosProject = "GitLab Inbox"
+
+// DefaultTodoistWebhookActions ordnet Todoist-Events den GitLab-Aktionen zu
+const DefaultTodoistWebhookActions = "item:completed=close,item:uncompleted=reopen,item:updated=update,note:added=comment"
+
 func NewConfig() (*Config, error) {
 	// .env laden (ignoriere Fehler wenn Datei nicht existiert)
 	if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
-		fmt.Printf("⚠️  Warnung beim Laden der .env: %v\n", err)
+		slog.Warn("loading .env failed", "error", err)
 	}
 
 	cfg := &Config{
@@ -34,8 +147,69 @@ func NewConfig() (*Config, error) {
 		TodoistToken:   getEnv("TODOIST_TOKEN", ""),
 		TodoistProject: getEnv("TODOIST_PROJECT", "GitLab Issues"),
 		TodoistAPI:     getBoolEnv("TODOIST_API", false),
-		OutputFile:     getEnv("OUTPUT_FILE", "gitlab_issues.md"),
+		OutputFile:     getEnv("OUTPUT_FILE", DefaultOutputFile),
 		Verbose:        getBoolEnv("VERBOSE", false),
+
+		Format:                getEnv("OUTPUT_FORMAT", "markdown"),
+		IncludeTodoistMapping: getBoolEnv("EXPORT_TODOIST_MAPPING", false),
+		MarkdownTemplate:      getEnv("MARKDOWN_TEMPLATE", ""),
+
+		CSVColumns:       getEnv("CSV_COLUMNS", ""),
+		CSVSeparator:     getEnv("CSV_SEPARATOR", ""),
+		CSVListDelimiter: getEnv("CSV_LIST_DELIMITER", ", "),
+		CSVBOM:           getBoolEnv("CSV_BOM", false),
+		ICSComponent:     getEnv("ICS_COMPONENT", "vtodo"),
+
+		LogFormat: getEnv("LOG_FORMAT", "text"),
+		LogLevel:  getEnv("LOG_LEVEL", ""),
+
+		Lang: i18n.Normalize(os.Getenv("LANG")),
+
+		MilestoneProgress: getBoolEnv("MILESTONE_PROGRESS", true),
+
+		IssueLinks:    getBoolEnv("ISSUE_LINKS", false),
+		OnlyUnblocked: getBoolEnv("ONLY_UNBLOCKED", false),
+		Hierarchy:     getBoolEnv("ISSUE_HIERARCHY", false),
+
+		HistoryDir: getEnv("HISTORY_DIR", ".gitlab-exporter/history"),
+		DiffFrom:   getEnv("DIFF_FROM", ""),
+		DiffTo:     getEnv("DIFF_TO", ""),
+
+		ReleaseVersion:       getEnv("RELEASE_VERSION", ""),
+		ReleaseSince:         getEnv("RELEASE_SINCE", ""),
+		ReleaseUntil:         getEnv("RELEASE_UNTIL", ""),
+		ReleaseCategories:    getEnv("RELEASE_CATEGORIES", DefaultReleaseCategories),
+		ReleaseChangelog:     getEnv("RELEASE_CHANGELOG", ""),
+		ReleaseMergeRequests: getBoolEnv("RELEASE_MERGE_REQUESTS", true),
+
+		AnalyticsLabels:      getEnv("ANALYTICS_LABELS", ""),
+		AnalyticsDoingLabels: getEnv("ANALYTICS_DOING_LABELS", DefaultAnalyticsDoingLabels),
+
+		ReportDueSoonDays: getIntEnv("REPORT_DUE_SOON_DAYS", 7),
+		ReportStaleDays:   getIntEnv("REPORT_STALE_DAYS", 14),
+		ReportTodoist:     getBoolEnv("REPORT_TODOIST", false),
+
+		TimesheetSince:    getEnv("TIMESHEET_SINCE", ""),
+		TimesheetUntil:    getEnv("TIMESHEET_UNTIL", ""),
+		TimesheetGroup:    getEnv("TIMESHEET_GROUP", ""),
+		TimesheetUsers:    getEnv("TIMESHEET_USERS", ""),
+		TimesheetRounding: getEnv("TIMESHEET_ROUNDING", ""),
+
+		TodosProject: getEnv("TODOS_PROJECT", DefaultTodosProject),
+
+		WatchInterval:   getDurationEnv("WATCH_INTERVAL", 15*time.Minute),
+		WatchCron:       getEnv("WATCH_CRON", ""),
+		WatchMaxBackoff: getDurationEnv("WATCH_MAX_BACKOFF", time.Hour),
+
+		ListenAddr:          getEnv("LISTEN_ADDR", ":8080"),
+		GitLabWebhookSecret: getEnv("GITLAB_WEBHOOK_SECRET", ""),
+		GitLabWebhookNotes:  getBoolEnv("GITLAB_WEBHOOK_NOTES", false),
+
+		TodoistClientSecret:   getEnv("TODOIST_CLIENT_SECRET", ""),
+		TodoistWebhookActions: getEnv("TODOIST_WEBHOOK_ACTIONS", DefaultTodoistWebhookActions),
+		WebhookDryRun:         getBoolEnv("WEBHOOK_DRY_RUN", false),
+
+		StateFile: getEnv("STATE_FILE", ".gitlab-exporter/state.json"),
 	}
 
 	// Optional: MILESTONE_TITLE
@@ -43,24 +217,39 @@ func NewConfig() (*Config, error) {
 		cfg.MilestoneTitle = &milestone
 	}
 
-	if cfg.Verbose {
-		cfg.printDebugInfo()
-	}
-
 	return cfg, nil
 }
 
-func (c *Config) printDebugInfo() {
-	fmt.Printf("🔧 Configuration loaded:\n")
-	fmt.Printf("   GitLab URL: %s\n", c.GitLabURL)
-	fmt.Printf("   Project Path: %s\n", c.ProjectPath)
-	fmt.Printf("   Output File: %s\n", c.OutputFile)
-	fmt.Printf("   Has GitLab Token: %t (length: %d)\n",
-		c.GitLabToken != "", len(c.GitLabToken))
-	fmt.Printf("   Has Todoist Token: %t\n", c.TodoistToken != "")
+// EffectiveLogLevel liefert das Log-Level; ohne LOG_LEVEL schaltet der Verbose-Modus auf debug
+func (c *Config) EffectiveLogLevel() string {
+	if c.LogLevel != "" {
+		return c.LogLevel
+	}
+	if c.Verbose {
+		return "debug"
+	}
+	return "info"
+}
+
+// LogValue implementiert slog.LogValuer. Tokens und Secrets werden nie ausgegeben,
+// nur ob sie gesetzt sind.
+func (c *Config) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("gitlab_url", c.GitLabURL),
+		slog.String("project_path", c.ProjectPath),
+		slog.String("output_file", c.OutputFile),
+		slog.Bool("has_gitlab_token", c.GitLabToken != ""),
+		slog.Bool("has_todoist_token", c.TodoistToken != ""),
+		slog.Bool("has_gitlab_webhook_secret", c.GitLabWebhookSecret != ""),
+		slog.Bool("has_todoist_client_secret", c.TodoistClientSecret != ""),
+		slog.String("state_file", c.StateFile),
+		slog.String("history_dir", c.HistoryDir),
+		slog.String("lang", c.Lang),
+	}
 	if c.MilestoneTitle != nil {
-		fmt.Printf("   Milestone Filter: %s\n", *c.MilestoneTitle)
+		attrs = append(attrs, slog.String("milestone", *c.MilestoneTitle))
 	}
+	return slog.GroupValue(attrs...)
 }
 
 func getEnv(key, defaultValue string) string {
@@ -79,15 +268,34 @@ func getBoolEnv(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
 func (c *Config) Validate() error {
+	tr := i18n.New(c.Lang)
 	if c.GitLabToken == "" {
-		return fmt.Errorf("GitLab Token fehlt (GITLAB_TOKEN)")
+		return tr.Errorf("config.missing_gitlab_token")
 	}
 	if c.ProjectPath == "" {
-		return fmt.Errorf("GitLab Projekt-Pfad fehlt (PROJECT_PATH)")
+		return tr.Errorf("config.missing_project_path")
 	}
 	if c.TodoistAPI && c.TodoistToken == "" {
-		return fmt.Errorf("todoist Token fehlt für API-Export (TODOIST_TOKEN)")
+		return tr.Errorf("config.missing_todoist_token")
 	}
 	return nil
 }
@@ -99,3 +307,8 @@ func (c *Config) GetGitLabBaseURL() string {
 func (c *Config) GetTodoistBaseURL() string {
 	return "https://api.todoist.com/rest/v2"
 }
+
+// GetTodoistSyncURL liefert die URL der Sync API (für Operationen, die REST nicht kennt)
+func (c *Config) GetTodoistSyncURL() string {
+	return "https://api.todoist.com/sync/v9"
+}
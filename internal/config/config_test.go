@@ -1,7 +1,11 @@
 package config
 
 import (
+	"bytes"
+	"log/slog"
+	"strings"
 	"testing"
+	"time"
 )
 
 // helper to construct a config with a clean environment.
@@ -15,6 +19,14 @@ func newConfigWithEnv(t *testing.T, env map[string]string) *Config {
 	keys := []string{
 		"GITLAB_TOKEN", "GITLAB_URL", "PROJECT_PATH", "MILESTONE_TITLE",
 		"TODOIST_TOKEN", "TODOIST_PROJECT", "TODOIST_API", "OUTPUT_FILE", "VERBOSE",
+		"WATCH_INTERVAL", "WATCH_CRON", "WATCH_MAX_BACKOFF",
+		"LISTEN_ADDR", "GITLAB_WEBHOOK_SECRET", "GITLAB_WEBHOOK_NOTES",
+		"TODOIST_CLIENT_SECRET", "TODOIST_WEBHOOK_ACTIONS", "WEBHOOK_DRY_RUN", "STATE_FILE", "LOG_FORMAT", "LOG_LEVEL", "LANG", "MARKDOWN_TEMPLATE", "OUTPUT_FORMAT", "EXPORT_TODOIST_MAPPING",
+		"CSV_COLUMNS", "CSV_SEPARATOR", "CSV_LIST_DELIMITER", "CSV_BOM", "ICS_COMPONENT",
+		"MILESTONE_PROGRESS", "HISTORY_DIR", "DIFF_FROM", "DIFF_TO", "RELEASE_VERSION", "RELEASE_SINCE", "RELEASE_UNTIL", "RELEASE_CATEGORIES", "RELEASE_CHANGELOG", "RELEASE_MERGE_REQUESTS",
+		"ANALYTICS_LABELS", "ANALYTICS_DOING_LABELS", "REPORT_DUE_SOON_DAYS", "REPORT_STALE_DAYS", "REPORT_TODOIST",
+		"TIMESHEET_SINCE", "TIMESHEET_UNTIL", "TIMESHEET_GROUP", "TIMESHEET_USERS", "TIMESHEET_ROUNDING",
+		"ISSUE_LINKS", "ONLY_UNBLOCKED", "ISSUE_HIERARCHY", "TODOS_PROJECT",
 	}
 	for _, k := range keys {
 		t.Setenv(k, "")
@@ -62,6 +74,9 @@ func TestNewConfig_Defaults_NoEnv(t *testing.T) {
 	if cfg.Verbose {
 		t.Errorf("expected Verbose false by default")
 	}
+	if cfg.TodosProject != "GitLab Inbox" {
+		t.Errorf("expected default TodosProject, got %q", cfg.TodosProject)
+	}
 }
 
 func TestNewConfig_WithEnvValues(t *testing.T) {
@@ -106,6 +121,115 @@ func TestNewConfig_WithEnvValues(t *testing.T) {
 	}
 }
 
+func TestNewConfig_WatchSettings(t *testing.T) {
+	cfg := newConfigWithEnv(t, map[string]string{})
+	if cfg.WatchInterval != 15*time.Minute || cfg.WatchMaxBackoff != time.Hour || cfg.WatchCron != "" {
+		t.Errorf("unexpected watch defaults: %s / %s / %q", cfg.WatchInterval, cfg.WatchMaxBackoff, cfg.WatchCron)
+	}
+
+	cfg = newConfigWithEnv(t, map[string]string{
+		"WATCH_INTERVAL":    "5m",
+		"WATCH_CRON":        "*/10 * * * *",
+		"WATCH_MAX_BACKOFF": "invalid", // ungültig → Default
+	})
+	if cfg.WatchInterval != 5*time.Minute {
+		t.Errorf("WatchInterval mismatch: %s", cfg.WatchInterval)
+	}
+	if cfg.WatchCron != "*/10 * * * *" {
+		t.Errorf("WatchCron mismatch: %q", cfg.WatchCron)
+	}
+	if cfg.WatchMaxBackoff != time.Hour {
+		t.Errorf("expected default WatchMaxBackoff for invalid value, got %s", cfg.WatchMaxBackoff)
+	}
+}
+
+func TestNewConfig_ReportSettings(t *testing.T) {
+	cfg := newConfigWithEnv(t, map[string]string{})
+	if cfg.ReportDueSoonDays != 7 || cfg.ReportStaleDays != 14 || cfg.ReportTodoist {
+		t.Errorf("unexpected report defaults: %d / %d / %v", cfg.ReportDueSoonDays, cfg.ReportStaleDays, cfg.ReportTodoist)
+	}
+
+	cfg = newConfigWithEnv(t, map[string]string{
+		"REPORT_DUE_SOON_DAYS": "3",
+		"REPORT_STALE_DAYS":    "zwei", // ungültig → Default
+	})
+	if cfg.ReportDueSoonDays != 3 || cfg.ReportStaleDays != 14 {
+		t.Errorf("unexpected report settings: %d / %d", cfg.ReportDueSoonDays, cfg.ReportStaleDays)
+	}
+}
+
+func TestNewConfig_WebhookDefaults(t *testing.T) {
+	cfg := newConfigWithEnv(t, map[string]string{})
+	if cfg.ListenAddr != ":8080" {
+		t.Errorf("unexpected ListenAddr default: %q", cfg.ListenAddr)
+	}
+	if cfg.TodoistWebhookActions != DefaultTodoistWebhookActions {
+		t.Errorf("unexpected action mapping default: %q", cfg.TodoistWebhookActions)
+	}
+	if cfg.StateFile != ".gitlab-exporter/state.json" {
+		t.Errorf("unexpected StateFile default: %q", cfg.StateFile)
+	}
+	if cfg.WebhookDryRun || cfg.GitLabWebhookNotes {
+		t.Errorf("dry-run and note events should be disabled by default")
+	}
+}
+
+func TestEffectiveLogLevel(t *testing.T) {
+	cfg := newConfigWithEnv(t, map[string]string{})
+	if cfg.LogFormat != "text" || cfg.EffectiveLogLevel() != "info" {
+		t.Errorf("unexpected log defaults: %q / %q", cfg.LogFormat, cfg.EffectiveLogLevel())
+	}
+
+	cfg.Verbose = true
+	if cfg.EffectiveLogLevel() != "debug" {
+		t.Errorf("verbose should enable debug, got %q", cfg.EffectiveLogLevel())
+	}
+
+	cfg.LogLevel = "warn"
+	if cfg.EffectiveLogLevel() != "warn" {
+		t.Errorf("LOG_LEVEL should win over verbose, got %q", cfg.EffectiveLogLevel())
+	}
+}
+
+func TestLogValue_NeverContainsSecrets(t *testing.T) {
+	cfg := &Config{
+		GitLabToken:         "glpat-secret-token",
+		TodoistToken:        "todoist-secret",
+		GitLabWebhookSecret: "hook-secret",
+		TodoistClientSecret: "client-secret",
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("config", "config", cfg)
+
+	out := buf.String()
+	for _, secret := range []string{"glpat-secret-token", "todoist-secret", "hook-secret", "client-secret", "length"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log output contains %q: %s", secret, out)
+		}
+	}
+	if !strings.Contains(out, `"has_gitlab_token":true`) {
+		t.Errorf("expected token presence flag: %s", out)
+	}
+}
+
+func TestNewConfig_LangFromLocale(t *testing.T) {
+	cfg := newConfigWithEnv(t, map[string]string{})
+	if cfg.Lang != "de" {
+		t.Errorf("expected German default, got %q", cfg.Lang)
+	}
+
+	cfg = newConfigWithEnv(t, map[string]string{"LANG": "en_US.UTF-8"})
+	if cfg.Lang != "en" {
+		t.Fatalf("expected en from LANG, got %q", cfg.Lang)
+	}
+
+	cfg.GitLabToken = ""
+	if err := cfg.Validate(); err == nil || err.Error() != "GitLab token missing (GITLAB_TOKEN)" {
+		t.Errorf("expected English validation error, got %v", err)
+	}
+}
+
 func TestValidate_MissingGitLabToken(t *testing.T) {
 	cfg := newConfigWithEnv(t, map[string]string{
 		// Only project path set, token missing
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// FlowReport enthält die Flow-Metriken des analytics-Befehls
+type FlowReport struct {
+	SchemaVersion int                `json:"schema_version"`
+	Metadata      ExportMetadata     `json:"metadata"`
+	LeadTime      DurationStats      `json:"lead_time"`
+	CycleTime     DurationStats      `json:"cycle_time"`
+	Throughput    []WeeklyThroughput `json:"throughput"`
+	WIP           []AssigneeWIP      `json:"wip"`
+	Issues        []FlowIssue        `json:"issues"`
+}
+
+// DurationStats fasst Durchlaufzeiten in Tagen zusammen (Perzentile nach Nearest-Rank)
+type DurationStats struct {
+	Count    int     `json:"count"`
+	MeanDays float64 `json:"mean_days"`
+	P50Days  float64 `json:"p50_days"`
+	P85Days  float64 `json:"p85_days"`
+	P95Days  float64 `json:"p95_days"`
+}
+
+// WeeklyThroughput ist die Anzahl geschlossener Issues einer ISO-Woche (z.B. "2024-W09")
+type WeeklyThroughput struct {
+	Week   string `json:"week"`
+	Closed int    `json:"closed"`
+}
+
+// AssigneeWIP ist die Arbeit in Bearbeitung einer Person
+type AssigneeWIP struct {
+	Assignee   string `json:"assignee"`
+	InProgress int    `json:"in_progress"`
+	Open       int    `json:"open"`
+}
+
+// FlowIssue enthält die Zeitpunkte und Durchlaufzeiten eines Issues
+type FlowIssue struct {
+	IID           string     `json:"iid"`
+	Title         string     `json:"title"`
+	State         string     `json:"state"`
+	WebURL        string     `json:"web_url"`
+	CreatedAt     time.Time  `json:"created_at"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	ClosedAt      *time.Time `json:"closed_at,omitempty"`
+	LeadTimeDays  *float64   `json:"lead_time_days,omitempty"`
+	CycleTimeDays *float64   `json:"cycle_time_days,omitempty"`
+}
@@ -0,0 +1,105 @@
+package models
+
+import "time"
+
+// ExportSchemaVersion ist die Version des maschinenlesbaren Exportformats.
+// Sie wird erhöht, wenn sich Felder inkompatibel ändern.
+const ExportSchemaVersion = 1
+
+// ExportDocument ist der Inhalt eines JSON-Exports
+type ExportDocument struct {
+	SchemaVersion int               `json:"schema_version"`
+	Metadata      ExportMetadata    `json:"metadata"`
+	Issues        []NormalizedIssue `json:"issues"`
+}
+
+// ExportMetadata beschreibt, wann und womit ein Export erstellt wurde
+type ExportMetadata struct {
+	ExportedAt time.Time     `json:"exported_at"`
+	GitLabURL  string        `json:"gitlab_url"`
+	Project    string        `json:"project"`
+	Filters    ExportFilters `json:"filters"`
+	IssueCount int           `json:"issue_count"`
+}
+
+// ExportFilters enthält die beim Export aktiven Filter
+type ExportFilters struct {
+	Milestone string   `json:"milestone,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	// OnlyUnblocked ist gesetzt, wenn blockierte Issues ausgelassen wurden (--only-unblocked)
+	OnlyUnblocked bool `json:"only_unblocked,omitempty"`
+}
+
+// NormalizedIssue ist ein GitLab Issue in flacher, von der API unabhängiger Form
+type NormalizedIssue struct {
+	IID         string    `json:"iid"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	State       string    `json:"state"`
+	WebURL      string    `json:"web_url"`
+	DueDate     string    `json:"due_date,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Labels      []string  `json:"labels"`
+	Assignees   []string  `json:"assignees"`
+	Milestone   string    `json:"milestone,omitempty"`
+	Weight      *int      `json:"weight,omitempty"`
+	// Zeiterfassung in Sekunden
+	TimeEstimate   int             `json:"time_estimate,omitempty"`
+	TotalTimeSpent int             `json:"total_time_spent,omitempty"`
+	Todoist        *TodoistMapping `json:"todoist,omitempty"`
+}
+
+// TodoistMapping ist die berechnete Abbildung eines Issues auf einen Todoist Task
+type TodoistMapping struct {
+	Content  string   `json:"content"`
+	Priority int      `json:"priority"`
+	Labels   []string `json:"labels"`
+	Section  string   `json:"section"`
+	DueDate  string   `json:"due_date,omitempty"`
+	// Duration ist die Schätzung in DurationUnit (Minuten), 0 ohne Schätzung
+	Duration     int    `json:"duration,omitempty"`
+	DurationUnit string `json:"duration_unit,omitempty"`
+}
+
+// SnapshotDiff beschreibt die Änderungen zwischen zwei Snapshots
+type SnapshotDiff struct {
+	SchemaVersion int            `json:"schema_version"`
+	From          ExportMetadata `json:"from"`
+	To            ExportMetadata `json:"to"`
+	New           []IssueRef     `json:"new"`
+	Closed        []IssueRef     `json:"closed"`
+	Reopened      []IssueRef     `json:"reopened"`
+	Retitled      []IssueChange  `json:"retitled"`
+	Relabelled    []ListChange   `json:"relabelled"`
+	Reassigned    []ListChange   `json:"reassigned"`
+	DueChanged    []IssueChange  `json:"due_changed"`
+	Removed       []IssueRef     `json:"removed"`
+}
+
+// IssueRef verweist auf ein Issue im neueren Snapshot (bei Removed im älteren)
+type IssueRef struct {
+	IID    string `json:"iid"`
+	Title  string `json:"title"`
+	WebURL string `json:"web_url"`
+}
+
+// IssueChange ist ein geänderter Einzelwert (z.B. Titel oder Fälligkeit)
+type IssueChange struct {
+	IssueRef
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// ListChange ist eine geänderte Liste (z.B. Labels oder Assignees)
+type ListChange struct {
+	IssueRef
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// Empty meldet, ob keine Änderungen gefunden wurden
+func (d SnapshotDiff) Empty() bool {
+	return len(d.New)+len(d.Closed)+len(d.Reopened)+len(d.Retitled)+len(d.Relabelled)+
+		len(d.Reassigned)+len(d.DueChanged)+len(d.Removed) == 0
+}
@@ -3,16 +3,97 @@ package models
 import "time"
 
 type Issue struct {
-	IID         string    `json:"iid"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	State       string    `json:"state"`
-	WebURL      string    `json:"web_url"`
-	DueDate     *string   `json:"due_date"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	Labels      Labels    `json:"labels"`
-	Assignees   Assignees `json:"assignees"`
+	IID         string     `json:"iid"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	WebURL      string     `json:"web_url"`
+	DueDate     *string    `json:"due_date"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	Labels      Labels     `json:"labels"`
+	Assignees   Assignees  `json:"assignees"`
+	Milestone   *Milestone `json:"milestone,omitempty"`
+	// Weight ist nur mit GitLab Premium verfügbar und wird separat geladen (nil, wenn unbekannt)
+	Weight *int `json:"weight,omitempty"`
+	// Zeiterfassung in Sekunden (/estimate und Summe aller /spend)
+	TimeEstimate   int `json:"time_estimate"`
+	TotalTimeSpent int `json:"total_time_spent"`
+	// Links sind die verknüpften Issues; sie werden separat via REST geladen (nur mit --issue-links)
+	Links []IssueLink `json:"links,omitempty"`
+	// Epic und Children bilden die Hierarchie; sie werden separat geladen (nur mit --hierarchy)
+	Epic     *Epic      `json:"epic,omitempty"`
+	Children []WorkItem `json:"children,omitempty"`
+}
+
+// Epic ist das übergeordnete Epic eines Issues (GitLab Premium)
+type Epic struct {
+	IID    string `json:"iid"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	WebURL string `json:"web_url"`
+	// Reference ist die Referenz innerhalb der Gruppe wie "&5"
+	Reference string `json:"reference"`
+}
+
+// WorkItem ist ein untergeordnetes Work Item eines Issues, z.B. ein Task
+type WorkItem struct {
+	IID   string `json:"iid"`
+	Title string `json:"title"`
+	// State ist wie bei Issues "opened" oder "closed"
+	State  string `json:"state"`
+	WebURL string `json:"web_url"`
+	// Type ist der Name des Work-Item-Typs, z.B. "Task"
+	Type string `json:"type"`
+}
+
+// WorkItemGraphQLResponse ist die Antwort auf eine Abfrage der Hierarchie von Work Items
+type WorkItemGraphQLResponse struct {
+	Data struct {
+		Project *struct {
+			WorkItems struct {
+				Nodes []struct {
+					IID     string `json:"iid"`
+					Widgets []struct {
+						// Children ist nur beim Hierarchy-Widget gesetzt
+						Children *struct {
+							Nodes []struct {
+								IID          string `json:"iid"`
+								Title        string `json:"title"`
+								State        string `json:"state"`
+								WebURL       string `json:"web_url"`
+								WorkItemType struct {
+									Name string `json:"name"`
+								} `json:"work_item_type"`
+							} `json:"nodes"`
+						} `json:"children"`
+					} `json:"widgets"`
+				} `json:"nodes"`
+			} `json:"work_items"`
+		} `json:"project"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Arten von Issue-Verknüpfungen aus Sicht des Issues, dessen Links abgefragt werden
+const (
+	LinkRelatesTo   = "relates_to"
+	LinkBlocks      = "blocks"
+	LinkIsBlockedBy = "is_blocked_by"
+)
+
+// IssueLink ist ein verknüpftes Issue, ggf. aus einem anderen Projekt
+type IssueLink struct {
+	IID    string `json:"iid"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	WebURL string `json:"web_url"`
+	// Reference ist die vollständige Referenz wie "gruppe/projekt#12"
+	Reference string `json:"reference"`
+	LinkType  string `json:"link_type"`
 }
 
 type Labels struct {
@@ -31,6 +112,10 @@ type Assignee struct {
 	Name string `json:"name"`
 }
 
+type Milestone struct {
+	Title string `json:"title"`
+}
+
 type GraphQLResponse struct {
 	Data struct {
 		Project struct {
@@ -43,3 +128,191 @@ type GraphQLResponse struct {
 		Message string `json:"message"`
 	} `json:"errors"`
 }
+
+// IssuePageGraphQLResponse ist eine Seite einer paginierten Abfrage von Issues
+type IssuePageGraphQLResponse struct {
+	Data struct {
+		Project *struct {
+			Issues struct {
+				Nodes    []Issue  `json:"nodes"`
+				PageInfo PageInfo `json:"page_info"`
+			} `json:"issues"`
+		} `json:"project"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// MergeRequest ist ein (gemergter) GitLab Merge Request
+type MergeRequest struct {
+	IID      string     `json:"iid"`
+	Title    string     `json:"title"`
+	WebURL   string     `json:"web_url"`
+	MergedAt *time.Time `json:"merged_at"`
+	Labels   Labels     `json:"labels"`
+}
+
+// MergeRequestGraphQLResponse ist die Antwort auf eine Abfrage von Merge Requests
+type MergeRequestGraphQLResponse struct {
+	Data struct {
+		Project *struct {
+			MergeRequests struct {
+				Nodes    []MergeRequest `json:"nodes"`
+				PageInfo PageInfo       `json:"page_info"`
+			} `json:"mergeRequests"`
+		} `json:"project"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// LabelEvent ist ein Resource Label Event (Label hinzugefügt oder entfernt)
+type LabelEvent struct {
+	Action    string    `json:"action"`
+	CreatedAt time.Time `json:"created_at"`
+	// Label ist nil, wenn das Label inzwischen gelöscht wurde
+	Label *EventLabel `json:"label"`
+}
+
+// EventLabel ist das Label eines Resource Label Events
+type EventLabel struct {
+	Name string `json:"name"`
+}
+
+// BurnupPoint ist ein Tageswert des Burnup-Charts eines Milestones
+type BurnupPoint struct {
+	Date            string `json:"date"`
+	ScopeCount      int    `json:"scope_count"`
+	ScopeWeight     int    `json:"scope_weight"`
+	CompletedCount  int    `json:"completed_count"`
+	CompletedWeight int    `json:"completed_weight"`
+}
+
+// MilestoneReportGraphQLResponse ist die Antwort auf eine Abfrage des Timebox-Reports eines Milestones
+type MilestoneReportGraphQLResponse struct {
+	Data struct {
+		Project *struct {
+			Milestones struct {
+				Nodes []struct {
+					Report *struct {
+						BurnupTimeSeries []BurnupPoint `json:"burnup_time_series"`
+					} `json:"report"`
+				} `json:"nodes"`
+			} `json:"milestones"`
+		} `json:"project"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// IssueGraphQLResponse ist die Antwort auf eine Abfrage eines einzelnen Issues
+type IssueGraphQLResponse struct {
+	Data struct {
+		Project *struct {
+			Issue *Issue `json:"issue"`
+		} `json:"project"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Timelog ist eine Zeitbuchung der GitLab-Zeiterfassung
+type Timelog struct {
+	SpentAt time.Time `json:"spent_at"`
+	// TimeSpent ist die gebuchte Zeit in Sekunden (negativ bei Korrekturen wie "/spend -1h")
+	TimeSpent int         `json:"time_spent"`
+	Summary   string      `json:"summary"`
+	User      TimelogUser `json:"user"`
+	// Issue oder MergeRequest ist gesetzt, je nachdem worauf gebucht wurde
+	Issue        *TimelogTarget `json:"issue"`
+	MergeRequest *TimelogTarget `json:"merge_request"`
+}
+
+// TimelogUser ist die Person, die Zeit gebucht hat (bei To-Dos die auslösende Person)
+type TimelogUser struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+// TimelogTarget ist das Issue oder der Merge Request einer Zeitbuchung bzw. eines To-Dos
+type TimelogTarget struct {
+	IID    string `json:"iid"`
+	Title  string `json:"title"`
+	WebURL string `json:"web_url"`
+	// Reference ist die vollständige Referenz, z.B. "group/project#12"
+	Reference string `json:"reference"`
+}
+
+// PageInfo ist die Cursor-Information einer paginierten GraphQL-Verbindung
+type PageInfo struct {
+	HasNextPage bool   `json:"has_next_page"`
+	EndCursor   string `json:"end_cursor"`
+}
+
+// TimelogGraphQLResponse ist die Antwort auf eine Abfrage der Zeitbuchungen eines Projekts oder einer Gruppe
+type TimelogGraphQLResponse struct {
+	Data struct {
+		Namespace *struct {
+			Timelogs struct {
+				Nodes    []Timelog `json:"nodes"`
+				PageInfo PageInfo  `json:"page_info"`
+			} `json:"timelogs"`
+		} `json:"namespace"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Todo ist ein Eintrag der persönlichen To-Do-Liste in GitLab (Erwähnung, Review-Anfrage, Zuweisung usw.)
+type Todo struct {
+	// ID ist die globale ID, z.B. "gid://gitlab/Todo/42"
+	ID string `json:"id"`
+	// Action ist der Anlass in GraphQL-Schreibweise, z.B. "mentioned" oder "review_requested"
+	Action string `json:"action"`
+	Body   string `json:"body"`
+	// TargetType ist z.B. "ISSUE" oder "MERGEREQUEST"
+	TargetType string       `json:"target_type"`
+	CreatedAt  time.Time    `json:"created_at"`
+	Author     *TimelogUser `json:"author"`
+	Project    *TodoProject `json:"project"`
+	// Target ist nur für Issues und Merge Requests gesetzt
+	Target *TimelogTarget `json:"target"`
+}
+
+// TodoProject ist das Projekt, zu dem ein To-Do gehört
+type TodoProject struct {
+	FullPath string `json:"full_path"`
+	WebURL   string `json:"web_url"`
+}
+
+// TodoGraphQLResponse ist die Antwort auf eine Abfrage der offenen To-Dos des angemeldeten Benutzers
+type TodoGraphQLResponse struct {
+	Data struct {
+		CurrentUser *struct {
+			Todos struct {
+				Nodes    []Todo   `json:"nodes"`
+				PageInfo PageInfo `json:"page_info"`
+			} `json:"todos"`
+		} `json:"current_user"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// TodoMarkDoneGraphQLResponse ist die Antwort auf die Mutation todoMarkDone
+type TodoMarkDoneGraphQLResponse struct {
+	Data struct {
+		TodoMarkDone *struct {
+			Errors []string `json:"errors"`
+		} `json:"todo_mark_done"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// AttentionReport ist das Ergebnis des report-Befehls: offene Issues, die Aufmerksamkeit brauchen
+type AttentionReport struct {
+	SchemaVersion int                 `json:"schema_version"`
+	Metadata      ExportMetadata      `json:"metadata"`
+	DueSoonDays   int                 `json:"due_soon_days"`
+	StaleDays     int                 `json:"stale_days"`
+	Overdue       []AttentionIssue    `json:"overdue"`
+	DueSoon       []AttentionIssue    `json:"due_soon"`
+	Stale         []AttentionIssue    `json:"stale"`
+	Unassigned    []AttentionIssue    `json:"unassigned"`
+	Assignees     []AssigneeAttention `json:"assignees"`
+}
+
+// AttentionIssue ist ein Issue einer Kategorie des Reports
+type AttentionIssue struct {
+	IssueRef
+	Assignees []string  `json:"assignees"`
+	DueDate   string    `json:"due_date,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Days ist je nach Kategorie: Tage überfällig, Tage bis zur Fälligkeit,
+	// Tage ohne Änderung oder Tage seit Erstellung (nicht zugewiesen)
+	Days int `json:"days"`
+}
+
+// AssigneeAttention zählt die Issues einer Person je Kategorie
+type AssigneeAttention struct {
+	Assignee string `json:"assignee"`
+	Overdue  int    `json:"overdue"`
+	DueSoon  int    `json:"due_soon"`
+	Stale    int    `json:"stale"`
+}
+
+// Empty meldet, ob kein Issue Aufmerksamkeit braucht
+func (r AttentionReport) Empty() bool {
+	return len(r.Overdue)+len(r.DueSoon)+len(r.Stale)+len(r.Unassigned) == 0
+}
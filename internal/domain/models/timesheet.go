@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Timesheet ist das Ergebnis des timesheet-Befehls: gebuchte Zeit je Tag, Person und Issue
+type Timesheet struct {
+	SchemaVersion int               `json:"schema_version"`
+	Metadata      TimesheetMetadata `json:"metadata"`
+	Entries       []TimesheetEntry  `json:"entries"`
+	ByUser        []TimesheetTotal  `json:"by_user"`
+	ByIssue       []TimesheetTotal  `json:"by_issue"`
+	ByDay         []TimesheetTotal  `json:"by_day"`
+	TotalHours    float64           `json:"total_hours"`
+}
+
+// TimesheetMetadata beschreibt Quelle, Zeitraum und Filter eines Timesheets
+type TimesheetMetadata struct {
+	ExportedAt time.Time `json:"exported_at"`
+	GitLabURL  string    `json:"gitlab_url"`
+	// Namespace ist der Pfad des Projekts bzw. der Gruppe (Group = true)
+	Namespace string   `json:"namespace"`
+	Group     bool     `json:"group,omitempty"`
+	Since     string   `json:"since"`
+	Until     string   `json:"until"`
+	Users     []string `json:"users,omitempty"`
+	Rounding  string   `json:"rounding,omitempty"`
+}
+
+// TimesheetEntry ist die Summe der Buchungen einer Person auf ein Issue an einem Tag
+type TimesheetEntry struct {
+	Date     string `json:"date"`
+	User     string `json:"user"`
+	UserName string `json:"user_name"`
+	// Reference ist "#12" bzw. "!34" (bei Gruppen mit Projektpfad), leer ohne Issue/Merge Request
+	Reference string `json:"reference"`
+	Title     string `json:"title"`
+	WebURL    string `json:"web_url,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+	// SpentSeconds ist die tatsächlich gebuchte Zeit, Hours der Wert nach Rundung
+	SpentSeconds int     `json:"spent_seconds"`
+	Hours        float64 `json:"hours"`
+}
+
+// TimesheetTotal ist die Summe einer Person, eines Issues (Key = Referenz) oder eines Tages
+type TimesheetTotal struct {
+	Key   string  `json:"key"`
+	Title string  `json:"title,omitempty"`
+	Hours float64 `json:"hours"`
+}
@@ -11,6 +11,17 @@ type Task struct {
 	Priority    int      `json:"priority"`
 	DueDate     string   `json:"due_date,omitempty"`
 	URL         string   `json:"url,omitempty"`
+	AssigneeID  string   `json:"assignee_id,omitempty"`
+	// Duration ist die geplante Dauer (nil ohne Dauer)
+	Duration *TaskDuration `json:"duration,omitempty"`
+	// ParentID ist der übergeordnete Task (leer auf oberster Ebene)
+	ParentID string `json:"parent_id,omitempty"`
+}
+
+// TaskDuration ist die Dauer eines Tasks, z.B. 90 "minute"
+type TaskDuration struct {
+	Amount int    `json:"amount"`
+	Unit   string `json:"unit"`
 }
 
 type CreateTaskRequest struct {
@@ -22,6 +33,13 @@ type CreateTaskRequest struct {
 	Priority    int      `json:"priority,omitempty"`
 	DueDate     string   `json:"due_date,omitempty"`
 	DueString   string   `json:"due_string,omitempty"`
+	// AssigneeID ist die ID eines Mitglieds des (geteilten) Projekts
+	AssigneeID string `json:"assignee_id,omitempty"`
+	// Duration und DurationUnit ("minute" oder "day") werden nur gemeinsam gesetzt
+	Duration     int    `json:"duration,omitempty"`
+	DurationUnit string `json:"duration_unit,omitempty"`
+	// ParentID legt den Task als Subtask an; er liegt dann in der Section des Parents
+	ParentID string `json:"parent_id,omitempty"`
 }
 
 type Project struct {
@@ -36,3 +54,10 @@ type Section struct {
 	Name      string `json:"name"`
 	Order     int    `json:"order"`
 }
+
+// Collaborator ist ein Mitglied eines geteilten Projekts
+type Collaborator struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
@@ -0,0 +1,115 @@
+package i18n
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+// Unterstützte Sprachen
+const (
+	DE = "de"
+	EN = "en"
+
+	// Default ist die Sprache, wenn weder --lang noch LANG eine unterstützte Sprache liefern
+	Default = DE
+)
+
+// Translator liefert die Texte einer Sprache aus dem Katalog
+type Translator struct {
+	lang     string
+	messages map[string]string
+}
+
+// New erstellt einen Translator; unbekannte Sprachen fallen auf Default zurück
+func New(lang string) *Translator {
+	code := Normalize(lang)
+	return &Translator{lang: code, messages: catalog[code]}
+}
+
+// Lookup prüft, ob eine Sprache unterstützt wird, und liefert deren Code.
+// Akzeptiert werden auch Locale-Angaben wie "en_US.UTF-8".
+func Lookup(lang string) (string, bool) {
+	code := strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(code, "_-.@"); i >= 0 {
+		code = code[:i]
+	}
+	if _, ok := catalog[code]; !ok {
+		return Default, false
+	}
+	return code, true
+}
+
+// Normalize wandelt eine Sprach- oder Locale-Angabe (z.B. aus LANG) in einen unterstützten Code um
+func Normalize(lang string) string {
+	code, _ := Lookup(lang)
+	return code
+}
+
+// Supported liefert alle unterstützten Sprachcodes
+func Supported() []string {
+	langs := make([]string, 0, len(catalog))
+	for lang := range catalog {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Lang liefert den Sprachcode des Translators
+func (t *Translator) Lang() string {
+	return t.lang
+}
+
+// T liefert den übersetzten Text zu key, formatiert mit args.
+// Fehlt ein Eintrag, wird der deutsche Text bzw. der Key selbst verwendet.
+func (t *Translator) T(key string, args ...interface{}) string {
+	msg := t.message(key)
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Errorf erstellt einen Fehler mit übersetztem Text; %w wird wie bei fmt.Errorf unterstützt
+func (t *Translator) Errorf(key string, args ...interface{}) error {
+	return fmt.Errorf(t.message(key), args...)
+}
+
+// FormatDate formatiert ein Datum (YYYY-MM-DD) im Format der Sprache
+func (t *Translator) FormatDate(dateStr string) string {
+	return utils.FormatDate(dateStr, t.message("date.layout"), t.message("date.none"))
+}
+
+// Variants liefert den Text zu key in allen Sprachen, beginnend mit der eigenen.
+// Damit werden Namen wiedergefunden, die unter einer anderen Sprache angelegt wurden.
+func (t *Translator) Variants(key string) []string {
+	variants := []string{t.message(key)}
+	for _, lang := range Supported() {
+		msg, ok := catalog[lang][key]
+		if !ok || slices.Contains(variants, msg) {
+			continue
+		}
+		variants = append(variants, msg)
+	}
+	return variants
+}
+
+func (t *Translator) message(key string) string {
+	if msg, ok := t.messages[key]; ok {
+		return msg
+	}
+	if msg, ok := catalog[Default][key]; ok {
+		return msg
+	}
+	return key
+}
+
+// catalog enthält alle Übersetzungen je Sprachcode
+var catalog = map[string]map[string]string{
+	DE: messagesDE,
+	EN: messagesEN,
+}
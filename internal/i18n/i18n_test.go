@@ -0,0 +1,110 @@
+package i18n
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"sort"
+	"testing"
+)
+
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalog_AllLanguagesHaveSameKeysAndVerbs(t *testing.T) {
+	for lang, messages := range catalog {
+		for key, msg := range messagesDE {
+			translated, ok := messages[key]
+			if !ok {
+				t.Errorf("%s: missing key %q", lang, key)
+				continue
+			}
+			if got, want := verbs(translated), verbs(msg); got != want {
+				t.Errorf("%s: key %q has verbs %q, want %q", lang, key, got, want)
+			}
+		}
+		for key := range messages {
+			if _, ok := messagesDE[key]; !ok {
+				t.Errorf("%s: key %q is not in the German catalog", lang, key)
+			}
+		}
+	}
+}
+
+func verbs(msg string) string {
+	found := verbPattern.FindAllString(msg, -1)
+	sort.Strings(found)
+	var out string
+	for _, v := range found {
+		out += v
+	}
+	return out
+}
+
+func TestLookup(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"en", EN, true},
+		{"EN", EN, true},
+		{"en_US.UTF-8", EN, true},
+		{"de-AT", DE, true},
+		{"de_DE@euro", DE, true},
+		{"C.UTF-8", Default, false},
+		{"", Default, false},
+		{"fr", Default, false},
+	}
+	for _, c := range cases {
+		got, ok := Lookup(c.in)
+		if got != c.want || ok != c.ok {
+			t.Errorf("Lookup(%q) = %q, %t; want %q, %t", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestTranslator(t *testing.T) {
+	en := New("en_GB")
+	if en.Lang() != EN {
+		t.Fatalf("expected en, got %q", en.Lang())
+	}
+	if got := en.T("progress.found_issues", 3); got != "📊 Found: 3 issues" {
+		t.Errorf("unexpected translation: %q", got)
+	}
+	if got := New("").T("section.open"); got != "Offen" {
+		t.Errorf("expected German default, got %q", got)
+	}
+	if got := en.T("does.not.exist"); got != "does.not.exist" {
+		t.Errorf("missing keys should return the key, got %q", got)
+	}
+
+	cause := errors.New("boom")
+	err := en.Errorf("err.file_export", cause)
+	if err.Error() != "file export failed: boom" || !errors.Is(err, cause) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTranslator_Variants(t *testing.T) {
+	if got := New(EN).Variants("section.open"); !slices.Equal(got, []string{"Open", "Offen"}) {
+		t.Errorf("en: got %q", got)
+	}
+	if got := New(DE).Variants("section.closed"); !slices.Equal(got, []string{"Geschlossen", "Closed"}) {
+		t.Errorf("de: got %q", got)
+	}
+	if got := New(EN).Variants("does.not.exist"); !slices.Equal(got, []string{"does.not.exist"}) {
+		t.Errorf("missing key: got %q", got)
+	}
+}
+
+func TestTranslator_FormatDate(t *testing.T) {
+	if got := New(DE).FormatDate("2024-02-15"); got != "15.02.2024" {
+		t.Errorf("de: got %q", got)
+	}
+	if got := New(EN).FormatDate("2024-02-15"); got != "2024-02-15" {
+		t.Errorf("en: got %q", got)
+	}
+	if got := New(EN).FormatDate(""); got != "No date" {
+		t.Errorf("en empty: got %q", got)
+	}
+}
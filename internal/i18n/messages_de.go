@@ -0,0 +1,479 @@
+package i18n
+
+// messagesDE enthält die deutschen Texte (Standardsprache)
+var messagesDE = map[string]string{
+	// CLI
+	"usage.header": `GitLab zu Todoist Exporter
+
+VERWENDUNG:
+  gitlab-exporter [OPTIONS] [BEFEHL] [OPTIONS]
+
+BEFEHLE:
+  export    Einmaliger Export (Standard)
+  watch     Synchronisation regelmäßig in einem langlebigen Prozess ausführen
+  serve     Webhook-Server für GitLab-Events (Sync einzelner Issues in Echtzeit)
+            und Todoist-Events (Erledigen, Kommentare usw. zurück nach GitLab)
+  diff      Änderungen zwischen zwei Snapshots der Historie (neu, geschlossen,
+            umbenannt, Labels, Zuweisung, Fälligkeit) als Markdown oder JSON
+  release-notes
+            Release Notes (Keep a Changelog) aus geschlossenen Issues und
+            gemergten Merge Requests eines Milestones oder Zeitraums
+  analytics Flow-Metriken: Lead Time, Cycle Time, Durchsatz pro Woche und WIP
+            pro Person als Markdown, JSON oder CSV
+  report    Handlungsbedarf: überfällige, bald fällige, lange unveränderte und
+            nicht zugewiesene Issues pro Person, optional als Todoist-Aufgaben
+  timesheet Zeitbuchungen eines Projekts oder einer Gruppe je Tag, Person und
+            Issue mit Summen als Markdown, JSON oder CSV (z.B. zur Abrechnung)
+  todos     Persönliche GitLab-To-Do-Liste (Erwähnungen, Review-Anfragen,
+            Zuweisungen) als Todoist-Projekt; erledigte Tasks erledigen das To-Do
+
+KONFIGURATION:
+  Die Konfiguration kann über CLI-Flags, Umgebungsvariablen oder .env-Datei erfolgen.
+  Priorität: CLI-Flags > ENV-Variablen > .env-Datei
+
+ENV-DATEI BEISPIEL (.env):
+  GITLAB_TOKEN=glpat-your-token
+  PROJECT_PATH=user/project
+  TODOIST_TOKEN=your-todoist-token
+  TODOIST_API=true
+
+CLI-OPTIONEN:`,
+	"usage.footer": `
+BEISPIELE:
+  # Export zu Markdown-Datei (mit .env-Konfiguration)
+  gitlab-exporter
+
+  # Export zu Todoist mit CLI-Parametern
+  gitlab-exporter --gitlab-token glpat-123 --project-path user/repo --todoist --todoist-token abc123
+
+  # Nur bestimmtes Milestone
+  gitlab-exporter --milestone "v1.0.0" --output milestone-v1.md
+
+  # Alle 10 Minuten zu Todoist synchronisieren
+  gitlab-exporter --todoist watch --interval 10m
+
+  # Werktags stündlich von 8 bis 18 Uhr synchronisieren
+  gitlab-exporter --todoist watch --cron "0 8-18 * * 1-5"
+
+  # GitLab-Webhooks auf Port 9000 entgegennehmen
+  gitlab-exporter --todoist serve --listen :9000 --gitlab-webhook-secret geheim
+
+  # Issues als JSON inkl. Todoist-Abbildung nach stdout
+  gitlab-exporter --format json --include-todoist --output - | jq '.issues[].iid'
+
+  # CSV für deutsches Excel (Semikolon, BOM) mit eigenen Spalten
+  gitlab-exporter --format csv --csv-bom --csv-columns iid,title,due_date,assignees
+
+  # HTML-Report für Nicht-Entwickler (Filter, Suche, Sortierung)
+  gitlab-exporter --format html --output report.html
+
+  # Eine Obsidian-Notiz pro Issue (eigene Notizen unter dem Marker bleiben erhalten)
+  gitlab-exporter --format obsidian --output ~/Vault/GitLab
+
+  # Import in Taskwarrior (wiederholbar dank stabiler UUIDs)
+  gitlab-exporter --format taskwarrior --output - | task import
+
+  # Todoist-Vorlage zum Import ohne API-Token
+  gitlab-exporter --format todoist-csv --output vorlage.csv
+
+  # Abhängigkeitsgraph als SVG (Graphviz)
+  gitlab-exporter --format dot --output - | dot -Tsvg > abhaengigkeiten.svg
+
+  # Nur Issues nach Todoist, die sofort bearbeitet werden können
+  gitlab-exporter --todoist --only-unblocked
+
+  # Fälligkeiten als Termine für den Teamkalender
+  gitlab-exporter --format ics --ics-component vevent --output team.ics
+
+  # Was hat sich seit letzter Woche geändert? (Snapshots entstehen bei jedem Export)
+  gitlab-exporter diff --from 2024-03-01 > aenderungen.md
+
+  # Release Notes für Milestone v1.2.0 oben in CHANGELOG.md einfügen
+  gitlab-exporter release-notes --milestone v1.2.0 --changelog CHANGELOG.md
+
+  # Release Notes eines Zeitraums nach stdout, eigene Kategorien
+  gitlab-exporter release-notes --since 2024-01-01 --until 2024-03-31 --release-categories "Features=feature;Bugfixes=bug"
+
+  # Flow-Metriken der Bugs eines Milestones als CSV
+  gitlab-exporter analytics --milestone v1.2.0 --labels bug --format csv > flow.csv
+
+  # Handlungsbedarf der nächsten 3 Tage, wichtige Fälle als Todoist-Aufgaben
+  gitlab-exporter report --due-soon-days 3 --stale-days 30 --push-todoist
+
+  # Stunden der Gruppe im März, je Zeile auf 15 Minuten aufgerundet, als CSV
+  gitlab-exporter timesheet --group kunde --since 2024-03-01 --until 2024-03-31 --rounding 15m --format csv > maerz.csv
+
+  # GitLab-To-Do-Liste in das Todoist-Projekt "GitLab Inbox" spiegeln
+  gitlab-exporter todos --todoist-token abc123
+
+  # Markdown mit eigenem Template (z.B. nach Labels gruppiert)
+  gitlab-exporter --template report.md.tmpl --output report.md
+
+  # Hilfe, Meldungen und Markdown auf Englisch
+  gitlab-exporter --lang en
+
+  # Logs als JSON (z.B. für CI), Fortschritt weiterhin auf stdout
+  gitlab-exporter --log-format json --log-level debug
+
+  # Todoist-Webhooks testweise ohne Änderungen an GitLab verarbeiten
+  gitlab-exporter serve --todoist-client-secret abc --webhook-dry-run
+
+ENV-VARIABLEN:
+  GITLAB_TOKEN     GitLab API Token
+  GITLAB_URL       GitLab URL (default: https://gitlab.com)
+  PROJECT_PATH     GitLab Projekt-Pfad (user/repository)
+  MILESTONE_TITLE  Milestone-Filter
+  TODOIST_TOKEN    Todoist API Token
+  TODOIST_PROJECT  Todoist Projekt-Name
+  TODOIST_API      Export zu Todoist (true/false)
+  OUTPUT_FILE      Output-Datei für den Export ("-" für stdout)
+  OUTPUT_FORMAT    Dateiformat: markdown, html, json, ndjson, csv, ics, obsidian,
+                   taskwarrior, org, todotxt, todoist-csv, dot, mermaid
+                   (default: markdown)
+  EXPORT_TODOIST_MAPPING Todoist-Abbildung im JSON-Export (true/false)
+  CSV_COLUMNS      CSV: Spalten, kommagetrennt (z.B. iid,title,labels,todoist_priority)
+  CSV_SEPARATOR    CSV: Trennzeichen (default: ";" bei de, "," bei en)
+  CSV_LIST_DELIMITER CSV: Trenner für Labels/Assignees (default: ", ")
+  CSV_BOM          CSV: UTF-8 BOM für Excel voranstellen (true/false)
+  ICS_COMPONENT    iCalendar: vtodo (Aufgaben) oder vevent (Termine) (default: vtodo)
+  MARKDOWN_TEMPLATE Eigenes text/template für den Markdown-Export
+  VERBOSE          Verbose-Modus (true/false, entspricht LOG_LEVEL=debug)
+  LOG_FORMAT       Log-Format: text oder json (default: text)
+  LOG_LEVEL        Log-Level: debug, info, warn, error (default: info)
+  WATCH_INTERVAL   Watch: Intervall (z.B. 15m)
+  WATCH_CRON       Watch: Cron-Ausdruck (z.B. "*/30 * * * *")
+  WATCH_MAX_BACKOFF Watch: maximale Wartezeit nach Fehlern (z.B. 1h)
+  LISTEN_ADDR      Serve: Adresse des Webhook-Servers (default: :8080)
+  GITLAB_WEBHOOK_SECRET Serve: Secret Token des GitLab-Webhooks
+  GITLAB_WEBHOOK_NOTES  Serve: Note Hooks verarbeiten (true/false)
+  TODOIST_CLIENT_SECRET Serve: Client Secret der Todoist-App (HMAC-Prüfung)
+  TODOIST_WEBHOOK_ACTIONS Serve: z.B. item:completed=close,note:added=comment
+  WEBHOOK_DRY_RUN  Serve: GitLab-Aktionen nur protokollieren (true/false)
+  MILESTONE_PROGRESS Markdown/HTML: Fortschritt und Burndown je Milestone (default: true)
+  ISSUE_LINKS      Verknüpfte Issues laden und Blockaden markieren (true/false)
+  ONLY_UNBLOCKED   Nur Issues exportieren, die nicht blockiert sind (true/false)
+  ISSUE_HIERARCHY  Epics und Child-Tasks als verschachtelte Todoist-Tasks (true/false)
+  HISTORY_DIR      Snapshot pro Lauf für diff (default: .gitlab-exporter/history, leer = aus)
+  DIFF_FROM        Diff: älterer Snapshot, Pfad oder Datum (default: vorletzter)
+  DIFF_TO          Diff: neuerer Snapshot, Pfad oder Datum (default: letzter)
+  RELEASE_VERSION  Release Notes: Version der Überschrift (default: Milestone)
+  RELEASE_SINCE    Release Notes: geschlossen ab (YYYY-MM-DD)
+  RELEASE_UNTIL    Release Notes: geschlossen bis einschließlich (YYYY-MM-DD)
+  RELEASE_CATEGORIES Release Notes: Kategorie=Labels;... ("*" für alle übrigen)
+  RELEASE_CHANGELOG Release Notes: CHANGELOG.md, in das eingefügt wird (leer = stdout)
+  RELEASE_MERGE_REQUESTS Release Notes: gemergte Merge Requests aufnehmen (default: true)
+  ANALYTICS_LABELS Analytics: nur Issues mit allen diesen Labels (kommagetrennt)
+  ANALYTICS_DOING_LABELS Analytics: Labels für "in Arbeit" (default: doing,in progress)
+  REPORT_DUE_SOON_DAYS Report: "bald fällig" innerhalb von N Tagen (default: 7)
+  REPORT_STALE_DAYS Report: "unverändert" nach N Tagen ohne Änderung (default: 14, 0 = aus)
+  REPORT_TODOIST   Report: Aufgaben mit Priorität 1 in Todoist anlegen (true/false)
+  TIMESHEET_SINCE  Timesheet: gebucht ab (YYYY-MM-DD, default: Monatsanfang)
+  TIMESHEET_UNTIL  Timesheet: gebucht bis einschließlich (YYYY-MM-DD, default: heute)
+  TIMESHEET_GROUP  Timesheet: Gruppe statt PROJECT_PATH auswerten
+  TIMESHEET_USERS  Timesheet: nur diese Personen (Username oder Name, kommagetrennt)
+  TIMESHEET_ROUNDING Timesheet: Rundung je Zeile, z.B. 15m, nearest:6m, down:30m
+  TODOS_PROJECT    Todos: Todoist-Projekt für die GitLab-To-Do-Liste (default: GitLab Inbox)
+  STATE_FILE       Sync-Zustand (default: .gitlab-exporter/state.json)
+  LANG             Sprache der Ausgaben: de oder en (z.B. en_US.UTF-8)`,
+	"flag.gitlab-token":            "GitLab API Token (oder GITLAB_TOKEN)",
+	"flag.gitlab-url":              "GitLab URL (oder GITLAB_URL)",
+	"flag.project-path":            "GitLab Projekt-Pfad (oder PROJECT_PATH)",
+	"flag.milestone":               "Milestone-Filter (oder MILESTONE_TITLE)",
+	"flag.todoist-token":           "Todoist API Token (oder TODOIST_TOKEN)",
+	"flag.todoist-project":         "Todoist Projekt-Name (oder TODOIST_PROJECT)",
+	"flag.todoist":                 "Export zu Todoist API (oder TODOIST_API=true)",
+	"flag.output":                  "Output-Datei für den Export, \"-\" für stdout (oder OUTPUT_FILE)",
+	"flag.verbose":                 "Verbose-Modus (oder VERBOSE=true)",
+	"flag.help":                    "Hilfe anzeigen",
+	"flag.log-format":              "Log-Format: text oder json (oder LOG_FORMAT)",
+	"flag.log-level":               "Log-Level: debug, info, warn, error (oder LOG_LEVEL)",
+	"flag.interval":                "Watch: Intervall zwischen Synchronisationen (oder WATCH_INTERVAL)",
+	"flag.cron":                    "Watch: Cron-Ausdruck statt Intervall (oder WATCH_CRON)",
+	"flag.max-backoff":             "Watch: maximale Wartezeit nach Fehlern (oder WATCH_MAX_BACKOFF)",
+	"flag.listen":                  "Serve: Adresse des Webhook-Servers (oder LISTEN_ADDR)",
+	"flag.gitlab-webhook-secret":   "Serve: erwarteter X-Gitlab-Token (oder GITLAB_WEBHOOK_SECRET)",
+	"flag.webhook-notes":           "Serve: auch Note Hooks verarbeiten (oder GITLAB_WEBHOOK_NOTES=true)",
+	"flag.todoist-client-secret":   "Serve: Client Secret zur Prüfung der Todoist-Signatur (oder TODOIST_CLIENT_SECRET)",
+	"flag.todoist-webhook-actions": "Serve: Zuordnung Todoist-Event=GitLab-Aktion (oder TODOIST_WEBHOOK_ACTIONS)",
+	"flag.webhook-dry-run":         "Serve: GitLab-Aktionen nur protokollieren (oder WEBHOOK_DRY_RUN=true)",
+	"flag.state-file":              "Datei für den Sync-Zustand (oder STATE_FILE)",
+	"flag.format":                  "Dateiformat: markdown, html, json, ndjson, csv, ics, obsidian, taskwarrior, org, todotxt, todoist-csv, dot oder mermaid (oder OUTPUT_FORMAT)",
+	"flag.include-todoist":         "JSON/NDJSON: berechnete Todoist-Abbildung mit ausgeben (oder EXPORT_TODOIST_MAPPING=true)",
+	"flag.csv-columns":             "CSV: Spalten, kommagetrennt (oder CSV_COLUMNS)",
+	"flag.csv-separator":           "CSV: Trennzeichen, leer = passend zur Sprache (oder CSV_SEPARATOR)",
+	"flag.csv-list-delimiter":      "CSV: Trenner für mehrwertige Felder wie Labels (oder CSV_LIST_DELIMITER)",
+	"flag.csv-bom":                 "CSV: UTF-8 BOM für Excel voranstellen (oder CSV_BOM=true)",
+	"flag.ics-component":           "iCalendar: Fälligkeiten als vtodo oder vevent exportieren (oder ICS_COMPONENT)",
+	"flag.milestone-progress":      "Markdown/HTML: Fortschritt und Burndown je Milestone (oder MILESTONE_PROGRESS)",
+	"flag.issue-links":             "Verknüpfte Issues laden und blockierte Issues markieren (oder ISSUE_LINKS=true)",
+	"flag.hierarchy":               "Epics und Child-Tasks laden, in Todoist verschachteln und in Markdown gliedern (oder ISSUE_HIERARCHY=true)",
+	"flag.only-unblocked":          "Nur Issues exportieren, die von keinem offenen Issue blockiert werden (oder ONLY_UNBLOCKED=true)",
+	"flag.history-dir":             "Verzeichnis für einen Snapshot pro Lauf, leer = deaktiviert (oder HISTORY_DIR)",
+	"flag.from":                    "Diff: älterer Snapshot als Pfad oder Datum YYYY-MM-DD (oder DIFF_FROM)",
+	"flag.to":                      "Diff: neuerer Snapshot als Pfad oder Datum YYYY-MM-DD (oder DIFF_TO)",
+	"flag.release-version":         "Release Notes: Version der Überschrift, Standard ist der Milestone (oder RELEASE_VERSION)",
+	"flag.since":                   "Release Notes/Timesheet: ab diesem Tag geschlossen bzw. gebucht, YYYY-MM-DD (oder RELEASE_SINCE/TIMESHEET_SINCE)",
+	"flag.until":                   "Release Notes/Timesheet: bis einschließlich diesem Tag geschlossen bzw. gebucht, YYYY-MM-DD (oder RELEASE_UNTIL/TIMESHEET_UNTIL)",
+	"flag.release-categories":      "Release Notes: Kategorien als Name=label,label;... mit * für alle übrigen (oder RELEASE_CATEGORIES)",
+	"flag.changelog":               "Release Notes: Abschnitt oben in diese Datei einfügen statt nach stdout (oder RELEASE_CHANGELOG)",
+	"flag.merge-requests":          "Release Notes: gemergte Merge Requests aufnehmen (oder RELEASE_MERGE_REQUESTS)",
+	"flag.labels":                  "Analytics: nur Issues mit allen diesen Labels, kommagetrennt (oder ANALYTICS_LABELS)",
+	"flag.doing-labels":            "Analytics: Labels, deren erstes Setzen die Cycle Time startet, kommagetrennt (oder ANALYTICS_DOING_LABELS)",
+	"flag.due-soon-days":           "Report: Issues, die innerhalb von N Tagen fällig sind (oder REPORT_DUE_SOON_DAYS)",
+	"flag.stale-days":              "Report: Issues ohne Änderung seit N Tagen, 0 = aus (oder REPORT_STALE_DAYS)",
+	"flag.push-todoist":            "Report: betroffene Issues als Aufgaben mit Priorität 1 nach Todoist (oder REPORT_TODOIST)",
+	"flag.group":                   "Timesheet: Zeitbuchungen dieser Gruppe statt des Projekts (oder TIMESHEET_GROUP)",
+	"flag.users":                   "Timesheet: nur diese Personen, Username oder Name, kommagetrennt (oder TIMESHEET_USERS)",
+	"flag.todos-project":           "Todos: Todoist-Projekt für die GitLab-To-Do-Liste (oder TODOS_PROJECT)",
+	"flag.rounding":                "Timesheet: Rundung je Zeile wie 15m (aufrunden), nearest:6m oder down:30m (oder TIMESHEET_ROUNDING)",
+	"flag.template":                "Eigenes text/template für den Markdown-Export (oder MARKDOWN_TEMPLATE)",
+	"flag.lang":                    "Sprache der Ausgaben: de oder en (oder LANG)",
+	"cli.unexpected_args":          "unerwartete Argumente: %v",
+	"cli.unsupported_lang":         "nicht unterstützte Sprache %q (verfügbar: %s)",
+	"cli.parse_failed":             "❌ Fehler beim Parsen der Flags: %v",
+	"cli.logging_failed":           "❌ Ungültige Logging-Konfiguration: %v",
+
+	// Konfiguration
+	"config.missing_gitlab_token":  "GitLab Token fehlt (GITLAB_TOKEN)",
+	"config.missing_project_path":  "GitLab Projekt-Pfad fehlt (PROJECT_PATH)",
+	"config.missing_todoist_token": "todoist Token fehlt für API-Export (TODOIST_TOKEN)",
+
+	// Fortschritt
+	"progress.loading_issues":        "🔍 Lade Issues aus GitLab: %s",
+	"progress.found_issues":          "📊 Gefunden: %d Issues",
+	"progress.no_issues":             "ℹ️  Keine Issues gefunden",
+	"progress.milestone_filter":      "🎯 Filter nach Milestone: %s",
+	"progress.loading_all":           "📋 Lade alle Issues...",
+	"progress.exporting_todoist":     "🚀 Exportiere zu Todoist...",
+	"progress.existing_tasks":        "🔍 Gefunden: %d bestehende Tasks",
+	"progress.sync_done":             "\n🎉 Synchronisation abgeschlossen:",
+	"progress.created":               "  ✅  Erstellt: %d",
+	"progress.updated":               "  🔄  Aktualisiert: %d",
+	"progress.skipped":               "  ⏭️  Übersprungen: %d",
+	"progress.exporting_file":        "📄 Exportiere zu %s-Datei...",
+	"progress.stdout_written":        "✅ %d Issues nach stdout geschrieben",
+	"progress.file_written":          "✅ Datei erstellt: %s (%d Issues)",
+	"progress.dir_written":           "✅ Verzeichnis aktualisiert: %s (%d Issues)",
+	"progress.diff_compare":          "🔀 Vergleiche %s mit %s",
+	"progress.loading_release":       "📝 Lade geschlossene Issues und Merge Requests: %s",
+	"progress.release_notes_written": "✅ Release Notes mit %d Einträgen nach stdout geschrieben",
+	"progress.changelog_written":     "✅ Changelog aktualisiert: %s (%d Einträge)",
+	"progress.loading_label_events":  "🏷️ Lade Label-Historie von %d geschlossenen Issues...",
+	"progress.report_found":          "🚨 Überfällig: %d, bald fällig: %d, unverändert: %d, nicht zugewiesen: %d",
+	"progress.report_closed":         "  📪  Geschlossen (nicht mehr auffällig): %d",
+	"progress.loading_timelogs":      "⏱️ Lade Zeitbuchungen aus GitLab: %s (%s – %s)",
+	"progress.timesheet_found":       "📊 %d Zeilen, %s Stunden",
+	"progress.loading_links":         "🔗 Lade Verknüpfungen von %d Issues...",
+	"progress.loading_todos":         "📥 Lade GitLab-To-Do-Liste...",
+	"progress.found_todos":           "📊 Gefunden: %d offene To-Dos",
+	"progress.todos_done":            "  ☑️  In GitLab erledigt: %d",
+	"progress.todos_closed":          "  📪  In Todoist geschlossen: %d",
+	"progress.loading_hierarchy":     "🗂️ Lade Epics und Child-Tasks...",
+	"progress.blocked_skipped":       "⛔ %d blockierte Issues ausgelassen",
+
+	// Fehler des Exporters
+	"err.invalid_config":        "konfiguration ungültig: %w",
+	"err.loading_issues":        "fehler beim Laden der GitLab Issues: %w",
+	"err.loading_issue":         "fehler beim Laden von Issue #%s: %w",
+	"err.issue_not_found":       "issue #%s nicht gefunden in %s",
+	"err.loading_tasks":         "fehler beim Laden bestehender Tasks: %w",
+	"err.gitlab_connection":     "GitLab-Verbindung fehlgeschlagen: %w",
+	"err.todoist_connection":    "Todoist-Verbindung fehlgeschlagen: %w",
+	"err.project_setup":         "projekt-Setup fehlgeschlagen: %w",
+	"err.section_setup":         "section-Setup fehlgeschlagen: %w",
+	"err.section_create":        "fehler beim Erstellen der Section '%s': %w",
+	"err.task_create":           "task-Erstellung fehlgeschlagen: %w",
+	"err.task_update":           "task-Update fehlgeschlagen: %w",
+	"err.task_move":             "verschieben des Tasks fehlgeschlagen: %w",
+	"err.unknown_format":        "unbekanntes Format %q (verfügbar: %s)",
+	"err.csv_column":            "unbekannte CSV-Spalte %q (verfügbar: %s)",
+	"err.csv_separator":         "ungültiges CSV-Trennzeichen %q (genau ein Zeichen erwartet)",
+	"err.ics_component":         "unbekannte iCalendar-Komponente %q (verfügbar: vtodo, vevent)",
+	"err.stdout_unsupported":    "format %s schreibt mehrere Dateien und unterstützt keine Ausgabe nach stdout",
+	"err.history_not_enough":    "zu wenige Snapshots für %s in %s – diff braucht zwei Exporte (oder --from/--to)",
+	"err.snapshot_not_found":    "kein Snapshot für %q gefunden (Pfad oder Datum YYYY-MM-DD erwartet)",
+	"err.snapshot_load":         "snapshot konnte nicht gelesen werden: %w",
+	"err.release_scope":         "release Notes brauchen einen Milestone (--milestone) oder einen Zeitraum (--since/--until)",
+	"err.release_date":          "ungültiges Datum %q für --%s (erwartet: YYYY-MM-DD)",
+	"err.release_category":      "ungültige Release-Kategorie %q (erwartet: Name=label,label;...)",
+	"err.report_days":           "--due-soon-days und --stale-days dürfen nicht negativ sein",
+	"err.loading_todos":         "fehler beim Laden der GitLab-To-Dos: %w",
+	"err.loading_timelogs":      "fehler beim Laden der Zeitbuchungen: %w",
+	"err.timesheet_range":       "--until (%s) liegt vor --since (%s)",
+	"err.timesheet_rounding":    "ungültige Rundung %q (erwartet: z.B. 15m, up:15m, nearest:6m oder down:30m)",
+	"err.changelog":             "changelog %s konnte nicht aktualisiert werden: %w",
+	"err.file_export":           "datei-Export fehlgeschlagen: %w",
+	"err.template_read":         "template %s konnte nicht gelesen werden: %w",
+	"err.template_parse":        "template %s ist ungültig: %w",
+	"err.template_render":       "template-Ausgabe fehlgeschlagen: %w",
+	"err.webhook_mapping":       "ungültige Aktionszuordnung %q (erwartet event=aktion)",
+	"err.webhook_action":        "unbekannte Aktion %q für %s",
+	"err.webhook_gitlab":        "GitLab-Aktion %s für Issue #%s fehlgeschlagen: %w",
+	"err.webhook_todo_done":     "GitLab-To-Do %s konnte nicht erledigt werden: %w",
+	"err.webhook_secret":        "webhook-Secret fehlt (GITLAB_WEBHOOK_SECRET und/oder TODOIST_CLIENT_SECRET)",
+	"err.webhook_needs_todoist": "GitLab-Webhooks benötigen den Todoist-Export (--todoist oder TODOIST_API=true)",
+	"err.watch_cron":            "ungültiger Cron-Ausdruck: %w",
+	"err.watch_interval":        "watch-Intervall muss größer als 0 sein (WATCH_INTERVAL)",
+	"err.watch_no_next_run":     "kein weiterer Ausführungszeitpunkt für Cron-Ausdruck %q",
+	"err.template_default":      "standard-Template ungültig: %w",
+	"err.group_field":           "unbekanntes Gruppierungsfeld %q (state, label, assignee, milestone)",
+	"err.html_template":         "HTML-Template ungültig: %w",
+	"err.invalid_iid":           "ungültige IID %q: %w",
+
+	// Datumsformate
+	"date.layout":     "02.01.2006",
+	"date.none":       "Kein Datum",
+	"datetime.layout": "02.01.2006 15:04:05",
+
+	// Todoist Sections
+	"section.open":   "Offen",
+	"section.closed": "Geschlossen",
+	// Section für Aufgaben aus dem report-Befehl
+	"section.attention": "⚠️ Handlungsbedarf",
+
+	// Markdown-Export
+	"md.title":               "GitLab Issues Export - %s",
+	"md.export_time":         "Export-Zeit",
+	"md.issue_count":         "Anzahl Issues",
+	"md.milestone":           "Milestone",
+	"md.open_issues":         "🟢 Offene Issues",
+	"md.closed_issues":       "✅ Geschlossene Issues",
+	"md.field":               "Feld",
+	"md.value":               "Wert",
+	"md.status":              "Status",
+	"md.due":                 "Fällig",
+	"md.assigned":            "Zugewiesen",
+	"md.labels":              "Labels",
+	"md.description":         "Beschreibung",
+	"md.progress":            "📈 Milestone-Fortschritt",
+	"md.weight":              "Gewicht",
+	"md.time_estimate":       "Schätzung",
+	"md.time_spent":          "Aufgewendet",
+	"md.time_spent_estimate": "Zeit (aufgewendet / geschätzt)",
+	"md.blocked_by":          "Blockiert durch",
+	"md.hierarchy":           "🗂️ Struktur",
+	"md.no_epic":             "Ohne Epic",
+	"md.done":                "Erledigt",
+	"md.burndown":            "Burndown %s",
+	"md.chart_issues":        "Issues",
+	"md.chart_remaining":     "Offen",
+	"md.chart_scope":         "Umfang",
+	"md.chart_caption":       "Linien: offene Issues (Burndown) und Gesamtumfang, Quelle: %s",
+	"md.source_gitlab":       "GitLab-Timebox-Report",
+	"md.source_history":      "Snapshots der Historie",
+	"md.ungrouped":           "Ohne Zuordnung",
+
+	// Diff zwischen Snapshots
+	"diff.title":       "Änderungen in %s",
+	"diff.from":        "Von",
+	"diff.to":          "Bis",
+	"diff.no_changes":  "Keine Änderungen.",
+	"diff.new":         "🆕 Neu",
+	"diff.closed":      "✅ Geschlossen",
+	"diff.reopened":    "🔁 Wieder geöffnet",
+	"diff.retitled":    "✏️ Umbenannt",
+	"diff.relabelled":  "🏷️ Labels geändert",
+	"diff.reassigned":  "👥 Zuweisung geändert",
+	"diff.due_changed": "📅 Fälligkeit geändert",
+	"diff.removed":     "🗑️ Nicht mehr enthalten",
+
+	// Flow-Metriken (analytics)
+	"analytics.title":       "Flow-Metriken – %s",
+	"analytics.durations":   "⏱️ Durchlaufzeiten (Tage)",
+	"analytics.metric":      "Metrik",
+	"analytics.count":       "Anzahl",
+	"analytics.mean":        "Mittelwert",
+	"analytics.lead_time":   "Lead Time (erstellt → geschlossen)",
+	"analytics.cycle_time":  "Cycle Time (in Arbeit → geschlossen)",
+	"analytics.throughput":  "📦 Durchsatz pro Woche",
+	"analytics.week":        "Woche",
+	"analytics.closed":      "Geschlossen",
+	"analytics.wip":         "🚧 Work in Progress",
+	"analytics.assignee":    "Person",
+	"analytics.in_progress": "In Arbeit",
+	"analytics.open":        "Offen",
+	"analytics.none":        "Keine Daten.",
+
+	// Handlungsbedarf (report)
+	"report.title":             "Handlungsbedarf – %s",
+	"report.none":              "Keine Issues mit Handlungsbedarf. 🎉",
+	"report.issue":             "Issue",
+	"report.overdue":           "🔥 Überfällig",
+	"report.due_soon":          "⏰ Fällig in den nächsten %d Tagen",
+	"report.stale":             "💤 Seit mindestens %d Tagen unverändert",
+	"report.unassigned":        "👤 Nicht zugewiesen",
+	"report.days_overdue":      "Tage überfällig",
+	"report.days_until":        "Tage bis fällig",
+	"report.days_stale":        "Tage unverändert",
+	"report.days_open":         "Tage offen",
+	"report.by_assignee":       "👥 Pro Person",
+	"report.col_overdue":       "Überfällig",
+	"report.col_due_soon":      "Bald fällig",
+	"report.col_stale":         "Unverändert",
+	"report.reason_overdue":    "🔥 War fällig am %s",
+	"report.reason_due_soon":   "⏰ Fällig am %s",
+	"report.reason_stale":      "💤 Unverändert seit %s",
+	"report.reason_unassigned": "👤 Niemandem zugewiesen",
+
+	// Abhängigkeitsgraph (dot, mermaid)
+	"graph.blocks": "blockiert",
+
+	// Timesheet
+	"timesheet.title":            "Timesheet – %s",
+	"timesheet.period":           "Zeitraum",
+	"timesheet.users":            "Personen",
+	"timesheet.rounding":         "Rundung",
+	"timesheet.rounding_up":      "je Zeile auf %s aufgerundet",
+	"timesheet.rounding_down":    "je Zeile auf %s abgerundet",
+	"timesheet.rounding_nearest": "je Zeile auf %s gerundet",
+	"timesheet.total":            "Summe",
+	"timesheet.none":             "Keine Zeitbuchungen im Zeitraum.",
+	"timesheet.entries":          "🗓️ Buchungen",
+	"timesheet.by_user":          "👥 Pro Person",
+	"timesheet.by_issue":         "📌 Pro Issue",
+	"timesheet.by_day":           "📅 Pro Tag",
+	"timesheet.date":             "Datum",
+	"timesheet.person":           "Person",
+	"timesheet.summary":          "Notiz",
+	"timesheet.hours":            "Stunden",
+	"timesheet.no_issue":         "(ohne Issue)",
+
+	// GitLab-To-Do-Liste (todos)
+	"todo.assigned":            "Zugewiesen",
+	"todo.mentioned":           "Erwähnt",
+	"todo.directly_addressed":  "Direkt angesprochen",
+	"todo.review_requested":    "Review angefragt",
+	"todo.review_submitted":    "Review abgegeben",
+	"todo.approval_required":   "Freigabe erforderlich",
+	"todo.build_failed":        "Pipeline fehlgeschlagen",
+	"todo.unmergeable":         "Nicht mergebar",
+	"todo.merge_train_removed": "Aus dem Merge Train entfernt",
+	"todo.marked":              "Als To-Do markiert",
+
+	// Changelog (Kopf einer neu angelegten Datei)
+	"changelog.header": `# Changelog
+
+Alle nennenswerten Änderungen an diesem Projekt werden in dieser Datei dokumentiert.
+
+Das Format basiert auf [Keep a Changelog](https://keepachangelog.com/de/1.1.0/).
+`,
+
+	// Obsidian
+	"obsidian.notes": "Notizen",
+
+	// HTML-Report
+	"html.title":      "Titel",
+	"html.search":     "Suche nach Titel, Label, Person, Milestone…",
+	"html.all_states": "Alle Status",
+	"html.visible":    "%d von %d Issues",
+
+	// Todoist Task-Beschreibung
+	"task.assignees":   "Assignees",
+	"task.labels":      "Labels",
+	"task.due":         "Due Date",
+	"task.description": "Beschreibung",
+	"task.blocked_by":  "Blockiert durch",
+
+	// Kommentar aus einem Todoist-Webhook (serve)
+	"webhook.todoist_comment": "💬 Kommentar aus Todoist:\n\n%s",
+}
@@ -0,0 +1,479 @@
+package i18n
+
+// messagesEN enthält die englischen Texte
+var messagesEN = map[string]string{
+	// CLI
+	"usage.header": `GitLab to Todoist Exporter
+
+USAGE:
+  gitlab-exporter [OPTIONS] [COMMAND] [OPTIONS]
+
+COMMANDS:
+  export    One-shot export (default)
+  watch     Run the sync repeatedly in one long-lived process
+  serve     Webhook server for GitLab events (near-real-time sync of single issues)
+            and Todoist events (completing, comments etc. back to GitLab)
+  diff      Changes between two snapshots of the history (new, closed, retitled,
+            labels, assignees, due dates) as Markdown or JSON
+  release-notes
+            Release notes (Keep a Changelog) from closed issues and
+            merged merge requests of a milestone or date range
+  analytics Flow metrics: lead time, cycle time, weekly throughput and WIP
+            per assignee as Markdown, JSON or CSV
+  report    Needs attention: overdue, due-soon, stale and unassigned issues per
+            assignee, optionally pushed to Todoist as tasks
+  timesheet Time logs of a project or group per day, person and issue with
+            totals as Markdown, JSON or CSV (e.g. for billing)
+  todos     Personal GitLab to-do list (mentions, review requests, assignments)
+            as a Todoist project; completing a task marks the to-do done
+
+CONFIGURATION:
+  Configuration can be provided via CLI flags, environment variables or a .env file.
+  Priority: CLI flags > environment variables > .env file
+
+ENV FILE EXAMPLE (.env):
+  GITLAB_TOKEN=glpat-your-token
+  PROJECT_PATH=user/project
+  TODOIST_TOKEN=your-todoist-token
+  TODOIST_API=true
+
+CLI OPTIONS:`,
+	"usage.footer": `
+EXAMPLES:
+  # Export to a Markdown file (using the .env configuration)
+  gitlab-exporter
+
+  # Export to Todoist with CLI parameters
+  gitlab-exporter --gitlab-token glpat-123 --project-path user/repo --todoist --todoist-token abc123
+
+  # Only a specific milestone
+  gitlab-exporter --milestone "v1.0.0" --output milestone-v1.md
+
+  # Sync to Todoist every 10 minutes
+  gitlab-exporter --todoist watch --interval 10m
+
+  # Sync hourly from 8 to 18 on weekdays
+  gitlab-exporter --todoist watch --cron "0 8-18 * * 1-5"
+
+  # Receive GitLab webhooks on port 9000
+  gitlab-exporter --todoist serve --listen :9000 --gitlab-webhook-secret secret
+
+  # Issues as JSON including the Todoist mapping to stdout
+  gitlab-exporter --format json --include-todoist --output - | jq '.issues[].iid'
+
+  # CSV for Excel with a BOM and custom columns
+  gitlab-exporter --format csv --csv-bom --csv-columns iid,title,due_date,assignees
+
+  # HTML report for non-developers (filters, search, sorting)
+  gitlab-exporter --format html --output report.html
+
+  # One Obsidian note per issue (your notes below the marker are kept)
+  gitlab-exporter --format obsidian --output ~/Vault/GitLab
+
+  # Import into Taskwarrior (repeatable thanks to stable UUIDs)
+  gitlab-exporter --format taskwarrior --output - | task import
+
+  # Todoist template for importing without an API token
+  gitlab-exporter --format todoist-csv --output template.csv
+
+  # Dependency graph as SVG (Graphviz)
+  gitlab-exporter --format dot --output - | dot -Tsvg > dependencies.svg
+
+  # Only push issues to Todoist that can be worked on right away
+  gitlab-exporter --todoist --only-unblocked
+
+  # Due dates as events for the team calendar
+  gitlab-exporter --format ics --ics-component vevent --output team.ics
+
+  # What changed since last week? (every export stores a snapshot)
+  gitlab-exporter diff --from 2024-03-01 > changes.md
+
+  # Prepend the release notes of milestone v1.2.0 to CHANGELOG.md
+  gitlab-exporter release-notes --milestone v1.2.0 --changelog CHANGELOG.md
+
+  # Release notes of a date range to stdout with custom categories
+  gitlab-exporter release-notes --since 2024-01-01 --until 2024-03-31 --release-categories "Features=feature;Bugfixes=bug"
+
+  # Flow metrics of a milestone's bugs as CSV
+  gitlab-exporter analytics --milestone v1.2.0 --labels bug --format csv > flow.csv
+
+  # Issues needing attention within 3 days, pushed to Todoist as tasks
+  gitlab-exporter report --due-soon-days 3 --stale-days 30 --push-todoist
+
+  # Hours of a group in March, rounded up to 15 minutes per row, as CSV
+  gitlab-exporter timesheet --group customer --since 2024-03-01 --until 2024-03-31 --rounding 15m --format csv > march.csv
+
+  # Mirror your GitLab to-do list into the Todoist project "GitLab Inbox"
+  gitlab-exporter todos --todoist-token abc123
+
+  # Markdown from a custom template (e.g. grouped by label)
+  gitlab-exporter --template report.md.tmpl --output report.md
+
+  # Help, messages and Markdown in German
+  gitlab-exporter --lang de
+
+  # JSON logs (e.g. for CI), progress stays on stdout
+  gitlab-exporter --log-format json --log-level debug
+
+  # Process Todoist webhooks without changing GitLab
+  gitlab-exporter serve --todoist-client-secret abc --webhook-dry-run
+
+ENVIRONMENT VARIABLES:
+  GITLAB_TOKEN     GitLab API token
+  GITLAB_URL       GitLab URL (default: https://gitlab.com)
+  PROJECT_PATH     GitLab project path (user/repository)
+  MILESTONE_TITLE  Milestone filter
+  TODOIST_TOKEN    Todoist API token
+  TODOIST_PROJECT  Todoist project name
+  TODOIST_API      Export to Todoist (true/false)
+  OUTPUT_FILE      Output file of the export ("-" for stdout)
+  OUTPUT_FORMAT    File format: markdown, html, json, ndjson, csv, ics, obsidian,
+                   taskwarrior, org, todotxt, todoist-csv, dot, mermaid
+                   (default: markdown)
+  EXPORT_TODOIST_MAPPING Todoist mapping in the JSON export (true/false)
+  CSV_COLUMNS      CSV: comma-separated columns (e.g. iid,title,labels,todoist_priority)
+  CSV_SEPARATOR    CSV: field separator (default: ";" for de, "," for en)
+  CSV_LIST_DELIMITER CSV: delimiter for labels/assignees (default: ", ")
+  CSV_BOM          CSV: prepend a UTF-8 BOM for Excel (true/false)
+  ICS_COMPONENT    iCalendar: vtodo (tasks) or vevent (events) (default: vtodo)
+  MARKDOWN_TEMPLATE Custom text/template for the Markdown export
+  VERBOSE          Verbose mode (true/false, same as LOG_LEVEL=debug)
+  LOG_FORMAT       Log format: text or json (default: text)
+  LOG_LEVEL        Log level: debug, info, warn, error (default: info)
+  WATCH_INTERVAL   Watch: interval (e.g. 15m)
+  WATCH_CRON       Watch: cron expression (e.g. "*/30 * * * *")
+  WATCH_MAX_BACKOFF Watch: maximum wait after failures (e.g. 1h)
+  LISTEN_ADDR      Serve: listen address of the webhook server (default: :8080)
+  GITLAB_WEBHOOK_SECRET Serve: secret token of the GitLab webhook
+  GITLAB_WEBHOOK_NOTES  Serve: process Note Hooks (true/false)
+  TODOIST_CLIENT_SECRET Serve: client secret of the Todoist app (HMAC check)
+  TODOIST_WEBHOOK_ACTIONS Serve: e.g. item:completed=close,note:added=comment
+  WEBHOOK_DRY_RUN  Serve: only log GitLab actions (true/false)
+  MILESTONE_PROGRESS Markdown/HTML: progress and burndown per milestone (default: true)
+  ISSUE_LINKS      Load linked issues and flag blocked ones (true/false)
+  ONLY_UNBLOCKED   Only export issues that are not blocked (true/false)
+  ISSUE_HIERARCHY  Epics and child tasks as nested Todoist tasks (true/false)
+  HISTORY_DIR      Snapshot per run for diff (default: .gitlab-exporter/history, empty = off)
+  DIFF_FROM        Diff: older snapshot, path or date (default: second latest)
+  DIFF_TO          Diff: newer snapshot, path or date (default: latest)
+  RELEASE_VERSION  Release notes: version in the heading (default: milestone)
+  RELEASE_SINCE    Release notes: closed on or after (YYYY-MM-DD)
+  RELEASE_UNTIL    Release notes: closed on or before (YYYY-MM-DD)
+  RELEASE_CATEGORIES Release notes: Category=labels;... ("*" for all others)
+  RELEASE_CHANGELOG Release notes: CHANGELOG.md to prepend to (empty = stdout)
+  RELEASE_MERGE_REQUESTS Release notes: include merged merge requests (default: true)
+  ANALYTICS_LABELS Analytics: only issues with all of these labels (comma-separated)
+  ANALYTICS_DOING_LABELS Analytics: labels meaning "in progress" (default: doing,in progress)
+  REPORT_DUE_SOON_DAYS Report: "due soon" within N days (default: 7)
+  REPORT_STALE_DAYS Report: "stale" after N days without updates (default: 14, 0 = off)
+  REPORT_TODOIST   Report: create priority 1 tasks in Todoist (true/false)
+  TIMESHEET_SINCE  Timesheet: logged on or after (YYYY-MM-DD, default: start of month)
+  TIMESHEET_UNTIL  Timesheet: logged on or before (YYYY-MM-DD, default: today)
+  TIMESHEET_GROUP  Timesheet: evaluate this group instead of PROJECT_PATH
+  TIMESHEET_USERS  Timesheet: only these people (username or name, comma-separated)
+  TIMESHEET_ROUNDING Timesheet: rounding per row, e.g. 15m, nearest:6m, down:30m
+  TODOS_PROJECT    Todos: Todoist project for the GitLab to-do list (default: GitLab Inbox)
+  STATE_FILE       Sync state (default: .gitlab-exporter/state.json)
+  LANG             Output language: de or en (e.g. en_US.UTF-8)`,
+	"flag.gitlab-token":            "GitLab API token (or GITLAB_TOKEN)",
+	"flag.gitlab-url":              "GitLab URL (or GITLAB_URL)",
+	"flag.project-path":            "GitLab project path (or PROJECT_PATH)",
+	"flag.milestone":               "Milestone filter (or MILESTONE_TITLE)",
+	"flag.todoist-token":           "Todoist API token (or TODOIST_TOKEN)",
+	"flag.todoist-project":         "Todoist project name (or TODOIST_PROJECT)",
+	"flag.todoist":                 "Export to the Todoist API (or TODOIST_API=true)",
+	"flag.output":                  "Output file of the export, \"-\" for stdout (or OUTPUT_FILE)",
+	"flag.verbose":                 "Verbose mode (or VERBOSE=true)",
+	"flag.help":                    "Show help",
+	"flag.log-format":              "Log format: text or json (or LOG_FORMAT)",
+	"flag.log-level":               "Log level: debug, info, warn, error (or LOG_LEVEL)",
+	"flag.interval":                "Watch: interval between syncs (or WATCH_INTERVAL)",
+	"flag.cron":                    "Watch: cron expression instead of an interval (or WATCH_CRON)",
+	"flag.max-backoff":             "Watch: maximum wait after failures (or WATCH_MAX_BACKOFF)",
+	"flag.listen":                  "Serve: listen address of the webhook server (or LISTEN_ADDR)",
+	"flag.gitlab-webhook-secret":   "Serve: expected X-Gitlab-Token (or GITLAB_WEBHOOK_SECRET)",
+	"flag.webhook-notes":           "Serve: also process Note Hooks (or GITLAB_WEBHOOK_NOTES=true)",
+	"flag.todoist-client-secret":   "Serve: client secret to verify the Todoist signature (or TODOIST_CLIENT_SECRET)",
+	"flag.todoist-webhook-actions": "Serve: Todoist event=GitLab action mapping (or TODOIST_WEBHOOK_ACTIONS)",
+	"flag.webhook-dry-run":         "Serve: only log GitLab actions (or WEBHOOK_DRY_RUN=true)",
+	"flag.state-file":              "File for the sync state (or STATE_FILE)",
+	"flag.format":                  "File format: markdown, html, json, ndjson, csv, ics, obsidian, taskwarrior, org, todotxt, todoist-csv, dot or mermaid (or OUTPUT_FORMAT)",
+	"flag.include-todoist":         "JSON/NDJSON: include the computed Todoist mapping (or EXPORT_TODOIST_MAPPING=true)",
+	"flag.csv-columns":             "CSV: comma-separated columns (or CSV_COLUMNS)",
+	"flag.csv-separator":           "CSV: field separator, empty = depends on the language (or CSV_SEPARATOR)",
+	"flag.csv-list-delimiter":      "CSV: delimiter for multi-valued fields such as labels (or CSV_LIST_DELIMITER)",
+	"flag.csv-bom":                 "CSV: prepend a UTF-8 BOM for Excel (or CSV_BOM=true)",
+	"flag.ics-component":           "iCalendar: export due dates as vtodo or vevent (or ICS_COMPONENT)",
+	"flag.milestone-progress":      "Markdown/HTML: progress and burndown per milestone (or MILESTONE_PROGRESS)",
+	"flag.issue-links":             "Load linked issues and flag blocked issues (or ISSUE_LINKS=true)",
+	"flag.hierarchy":               "Load epics and child tasks, nest them in Todoist and outline them in Markdown (or ISSUE_HIERARCHY=true)",
+	"flag.only-unblocked":          "Only export issues not blocked by any open issue (or ONLY_UNBLOCKED=true)",
+	"flag.history-dir":             "Directory for one snapshot per run, empty = disabled (or HISTORY_DIR)",
+	"flag.from":                    "Diff: older snapshot as path or date YYYY-MM-DD (or DIFF_FROM)",
+	"flag.to":                      "Diff: newer snapshot as path or date YYYY-MM-DD (or DIFF_TO)",
+	"flag.release-version":         "Release notes: version in the heading, defaults to the milestone (or RELEASE_VERSION)",
+	"flag.since":                   "Release notes/timesheet: closed or logged on or after this day, YYYY-MM-DD (or RELEASE_SINCE/TIMESHEET_SINCE)",
+	"flag.until":                   "Release notes/timesheet: closed or logged on or before this day, YYYY-MM-DD (or RELEASE_UNTIL/TIMESHEET_UNTIL)",
+	"flag.release-categories":      "Release notes: categories as Name=label,label;... with * for all others (or RELEASE_CATEGORIES)",
+	"flag.changelog":               "Release notes: prepend the section to this file instead of stdout (or RELEASE_CHANGELOG)",
+	"flag.merge-requests":          "Release notes: include merged merge requests (or RELEASE_MERGE_REQUESTS)",
+	"flag.labels":                  "Analytics: only issues with all of these labels, comma-separated (or ANALYTICS_LABELS)",
+	"flag.doing-labels":            "Analytics: labels whose first assignment starts the cycle time, comma-separated (or ANALYTICS_DOING_LABELS)",
+	"flag.due-soon-days":           "Report: issues due within N days (or REPORT_DUE_SOON_DAYS)",
+	"flag.stale-days":              "Report: issues without updates for N days, 0 = off (or REPORT_STALE_DAYS)",
+	"flag.push-todoist":            "Report: push affected issues to Todoist as priority 1 tasks (or REPORT_TODOIST)",
+	"flag.group":                   "Timesheet: time logs of this group instead of the project (or TIMESHEET_GROUP)",
+	"flag.users":                   "Timesheet: only these people, username or name, comma-separated (or TIMESHEET_USERS)",
+	"flag.todos-project":           "Todos: Todoist project for the GitLab to-do list (or TODOS_PROJECT)",
+	"flag.rounding":                "Timesheet: rounding per row such as 15m (round up), nearest:6m or down:30m (or TIMESHEET_ROUNDING)",
+	"flag.template":                "Custom text/template for the Markdown export (or MARKDOWN_TEMPLATE)",
+	"flag.lang":                    "Output language: de or en (or LANG)",
+	"cli.unexpected_args":          "unexpected arguments: %v",
+	"cli.unsupported_lang":         "unsupported language %q (available: %s)",
+	"cli.parse_failed":             "❌ Failed to parse flags: %v",
+	"cli.logging_failed":           "❌ Invalid logging configuration: %v",
+
+	// Konfiguration
+	"config.missing_gitlab_token":  "GitLab token missing (GITLAB_TOKEN)",
+	"config.missing_project_path":  "GitLab project path missing (PROJECT_PATH)",
+	"config.missing_todoist_token": "todoist token missing for the API export (TODOIST_TOKEN)",
+
+	// Fortschritt
+	"progress.loading_issues":        "🔍 Loading issues from GitLab: %s",
+	"progress.found_issues":          "📊 Found: %d issues",
+	"progress.no_issues":             "ℹ️  No issues found",
+	"progress.milestone_filter":      "🎯 Filtering by milestone: %s",
+	"progress.loading_all":           "📋 Loading all issues...",
+	"progress.exporting_todoist":     "🚀 Exporting to Todoist...",
+	"progress.existing_tasks":        "🔍 Found: %d existing tasks",
+	"progress.sync_done":             "\n🎉 Sync finished:",
+	"progress.created":               "  ✅  Created: %d",
+	"progress.updated":               "  🔄  Updated: %d",
+	"progress.skipped":               "  ⏭️  Skipped: %d",
+	"progress.exporting_file":        "📄 Exporting to %s file...",
+	"progress.stdout_written":        "✅ %d issues written to stdout",
+	"progress.file_written":          "✅ File written: %s (%d issues)",
+	"progress.dir_written":           "✅ Directory updated: %s (%d issues)",
+	"progress.diff_compare":          "🔀 Comparing %s with %s",
+	"progress.loading_release":       "📝 Loading closed issues and merge requests: %s",
+	"progress.release_notes_written": "✅ Release notes with %d entries written to stdout",
+	"progress.changelog_written":     "✅ Changelog updated: %s (%d entries)",
+	"progress.loading_label_events":  "🏷️ Loading label history of %d closed issues...",
+	"progress.report_found":          "🚨 Overdue: %d, due soon: %d, stale: %d, unassigned: %d",
+	"progress.report_closed":         "  📪  Closed (no longer flagged): %d",
+	"progress.loading_timelogs":      "⏱️ Loading time logs from GitLab: %s (%s – %s)",
+	"progress.timesheet_found":       "📊 %d rows, %s hours",
+	"progress.loading_links":         "🔗 Loading links of %d issues...",
+	"progress.loading_todos":         "📥 Loading GitLab to-do list...",
+	"progress.found_todos":           "📊 Found: %d pending to-dos",
+	"progress.todos_done":            "  ☑️  Marked done in GitLab: %d",
+	"progress.todos_closed":          "  📪  Closed in Todoist: %d",
+	"progress.loading_hierarchy":     "🗂️ Loading epics and child tasks...",
+	"progress.blocked_skipped":       "⛔ %d blocked issues skipped",
+
+	// Fehler des Exporters
+	"err.invalid_config":        "invalid configuration: %w",
+	"err.loading_issues":        "loading GitLab issues failed: %w",
+	"err.loading_issue":         "loading issue #%s failed: %w",
+	"err.issue_not_found":       "issue #%s not found in %s",
+	"err.loading_tasks":         "loading existing tasks failed: %w",
+	"err.gitlab_connection":     "GitLab connection failed: %w",
+	"err.todoist_connection":    "Todoist connection failed: %w",
+	"err.project_setup":         "project setup failed: %w",
+	"err.section_setup":         "section setup failed: %w",
+	"err.section_create":        "creating section '%s' failed: %w",
+	"err.task_create":           "creating task failed: %w",
+	"err.task_update":           "updating task failed: %w",
+	"err.task_move":             "moving task failed: %w",
+	"err.unknown_format":        "unknown format %q (available: %s)",
+	"err.csv_column":            "unknown CSV column %q (available: %s)",
+	"err.csv_separator":         "invalid CSV separator %q (exactly one character expected)",
+	"err.ics_component":         "unknown iCalendar component %q (available: vtodo, vevent)",
+	"err.stdout_unsupported":    "format %s writes several files and cannot write to stdout",
+	"err.history_not_enough":    "not enough snapshots for %s in %s – diff needs two exports (or --from/--to)",
+	"err.snapshot_not_found":    "no snapshot found for %q (expected a path or a date YYYY-MM-DD)",
+	"err.snapshot_load":         "snapshot could not be read: %w",
+	"err.release_scope":         "release notes need a milestone (--milestone) or a date range (--since/--until)",
+	"err.release_date":          "invalid date %q for --%s (expected: YYYY-MM-DD)",
+	"err.release_category":      "invalid release category %q (expected: Name=label,label;...)",
+	"err.report_days":           "--due-soon-days and --stale-days must not be negative",
+	"err.loading_todos":         "failed to load GitLab to-dos: %w",
+	"err.loading_timelogs":      "failed to load time logs: %w",
+	"err.timesheet_range":       "--until (%s) is before --since (%s)",
+	"err.timesheet_rounding":    "invalid rounding %q (expected e.g. 15m, up:15m, nearest:6m or down:30m)",
+	"err.changelog":             "changelog %s could not be updated: %w",
+	"err.file_export":           "file export failed: %w",
+	"err.template_read":         "reading template %s failed: %w",
+	"err.template_parse":        "template %s is invalid: %w",
+	"err.template_render":       "rendering template failed: %w",
+	"err.webhook_mapping":       "invalid action mapping %q (expected event=action)",
+	"err.webhook_action":        "unknown action %q for %s",
+	"err.webhook_gitlab":        "GitLab action %s for issue #%s failed: %w",
+	"err.webhook_todo_done":     "GitLab to-do %s could not be marked as done: %w",
+	"err.webhook_secret":        "webhook secret missing (GITLAB_WEBHOOK_SECRET and/or TODOIST_CLIENT_SECRET)",
+	"err.webhook_needs_todoist": "GitLab webhooks need the Todoist export (--todoist or TODOIST_API=true)",
+	"err.watch_cron":            "invalid cron expression: %w",
+	"err.watch_interval":        "watch interval must be greater than 0 (WATCH_INTERVAL)",
+	"err.watch_no_next_run":     "no further run time for cron expression %q",
+	"err.template_default":      "built-in template is invalid: %w",
+	"err.group_field":           "unknown group field %q (state, label, assignee, milestone)",
+	"err.html_template":         "HTML template is invalid: %w",
+	"err.invalid_iid":           "invalid IID %q: %w",
+
+	// Datumsformate
+	"date.layout":     "2006-01-02",
+	"date.none":       "No date",
+	"datetime.layout": "2006-01-02 15:04:05",
+
+	// Todoist Sections
+	"section.open":   "Open",
+	"section.closed": "Closed",
+	// Section for tasks created by the report command
+	"section.attention": "⚠️ Needs attention",
+
+	// Markdown-Export
+	"md.title":               "GitLab Issues Export - %s",
+	"md.export_time":         "Export time",
+	"md.issue_count":         "Issue count",
+	"md.milestone":           "Milestone",
+	"md.open_issues":         "🟢 Open issues",
+	"md.closed_issues":       "✅ Closed issues",
+	"md.field":               "Field",
+	"md.value":               "Value",
+	"md.status":              "Status",
+	"md.due":                 "Due",
+	"md.assigned":            "Assignees",
+	"md.labels":              "Labels",
+	"md.description":         "Description",
+	"md.progress":            "📈 Milestone progress",
+	"md.weight":              "Weight",
+	"md.time_estimate":       "Estimate",
+	"md.time_spent":          "Time spent",
+	"md.time_spent_estimate": "Time (spent / estimated)",
+	"md.blocked_by":          "Blocked by",
+	"md.hierarchy":           "🗂️ Hierarchy",
+	"md.no_epic":             "No epic",
+	"md.done":                "Done",
+	"md.burndown":            "Burndown %s",
+	"md.chart_issues":        "Issues",
+	"md.chart_remaining":     "Open",
+	"md.chart_scope":         "Scope",
+	"md.chart_caption":       "Lines: open issues (burndown) and total scope, source: %s",
+	"md.source_gitlab":       "GitLab timebox report",
+	"md.source_history":      "snapshot history",
+	"md.ungrouped":           "Not set",
+
+	// Diff between snapshots
+	"diff.title":       "Changes in %s",
+	"diff.from":        "From",
+	"diff.to":          "To",
+	"diff.no_changes":  "No changes.",
+	"diff.new":         "🆕 New",
+	"diff.closed":      "✅ Closed",
+	"diff.reopened":    "🔁 Reopened",
+	"diff.retitled":    "✏️ Retitled",
+	"diff.relabelled":  "🏷️ Labels changed",
+	"diff.reassigned":  "👥 Assignees changed",
+	"diff.due_changed": "📅 Due date changed",
+	"diff.removed":     "🗑️ No longer included",
+
+	// Flow metrics (analytics)
+	"analytics.title":       "Flow metrics – %s",
+	"analytics.durations":   "⏱️ Durations (days)",
+	"analytics.metric":      "Metric",
+	"analytics.count":       "Count",
+	"analytics.mean":        "Mean",
+	"analytics.lead_time":   "Lead time (created → closed)",
+	"analytics.cycle_time":  "Cycle time (in progress → closed)",
+	"analytics.throughput":  "📦 Throughput per week",
+	"analytics.week":        "Week",
+	"analytics.closed":      "Closed",
+	"analytics.wip":         "🚧 Work in progress",
+	"analytics.assignee":    "Assignee",
+	"analytics.in_progress": "In progress",
+	"analytics.open":        "Open",
+	"analytics.none":        "No data.",
+
+	// Needs attention (report)
+	"report.title":             "Needs attention – %s",
+	"report.none":              "No issues need attention. 🎉",
+	"report.issue":             "Issue",
+	"report.overdue":           "🔥 Overdue",
+	"report.due_soon":          "⏰ Due within the next %d days",
+	"report.stale":             "💤 Unchanged for at least %d days",
+	"report.unassigned":        "👤 Unassigned",
+	"report.days_overdue":      "Days overdue",
+	"report.days_until":        "Days until due",
+	"report.days_stale":        "Days unchanged",
+	"report.days_open":         "Days open",
+	"report.by_assignee":       "👥 Per assignee",
+	"report.col_overdue":       "Overdue",
+	"report.col_due_soon":      "Due soon",
+	"report.col_stale":         "Stale",
+	"report.reason_overdue":    "🔥 Was due on %s",
+	"report.reason_due_soon":   "⏰ Due on %s",
+	"report.reason_stale":      "💤 Unchanged since %s",
+	"report.reason_unassigned": "👤 Not assigned to anyone",
+
+	// Dependency graph (dot, mermaid)
+	"graph.blocks": "blocks",
+
+	// Timesheet
+	"timesheet.title":            "Timesheet – %s",
+	"timesheet.period":           "Period",
+	"timesheet.users":            "People",
+	"timesheet.rounding":         "Rounding",
+	"timesheet.rounding_up":      "rounded up to %s per row",
+	"timesheet.rounding_down":    "rounded down to %s per row",
+	"timesheet.rounding_nearest": "rounded to the nearest %s per row",
+	"timesheet.total":            "Total",
+	"timesheet.none":             "No time logged in this period.",
+	"timesheet.entries":          "🗓️ Time logs",
+	"timesheet.by_user":          "👥 Per person",
+	"timesheet.by_issue":         "📌 Per issue",
+	"timesheet.by_day":           "📅 Per day",
+	"timesheet.date":             "Date",
+	"timesheet.person":           "Person",
+	"timesheet.summary":          "Summary",
+	"timesheet.hours":            "Hours",
+	"timesheet.no_issue":         "(no issue)",
+
+	// GitLab to-do list (todos)
+	"todo.assigned":            "Assigned",
+	"todo.mentioned":           "Mentioned",
+	"todo.directly_addressed":  "Directly addressed",
+	"todo.review_requested":    "Review requested",
+	"todo.review_submitted":    "Review submitted",
+	"todo.approval_required":   "Approval required",
+	"todo.build_failed":        "Pipeline failed",
+	"todo.unmergeable":         "Cannot be merged",
+	"todo.merge_train_removed": "Removed from merge train",
+	"todo.marked":              "Marked as to-do",
+
+	// Changelog (header of a newly created file)
+	"changelog.header": `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/).
+`,
+
+	// Obsidian
+	"obsidian.notes": "Notes",
+
+	// HTML report
+	"html.title":      "Title",
+	"html.search":     "Search title, label, person, milestone…",
+	"html.all_states": "All states",
+	"html.visible":    "%d of %d issues",
+
+	// Todoist Task-Beschreibung
+	"task.assignees":   "Assignees",
+	"task.labels":      "Labels",
+	"task.due":         "Due Date",
+	"task.description": "Description",
+	"task.blocked_by":  "Blocked by",
+
+	// Comment from a Todoist webhook (serve)
+	"webhook.todoist_comment": "💬 Comment from Todoist:\n\n%s",
+}
@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Log-Formate
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var (
+	progressMu  sync.Mutex
+	progressOut io.Writer = os.Stdout
+)
+
+// Setup konfiguriert den Standard-Logger von log/slog
+func Setup(w io.Writer, format string, level string) error {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case "", FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		return fmt.Errorf("unknown log format %q (text, json)", format)
+	}
+
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// ParseLevel wandelt "debug", "info", "warn" oder "error" in einen slog.Level um
+func ParseLevel(level string) (slog.Level, error) {
+	var lvl slog.Level
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo, fmt.Errorf("unknown log level %q (debug, info, warn, error)", level)
+	}
+	return lvl, nil
+}
+
+// SetProgressOutput legt fest, wohin Fortschrittsmeldungen geschrieben werden
+func SetProgressOutput(w io.Writer) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	progressOut = w
+}
+
+// Progressf schreibt eine Fortschrittsmeldung für Menschen (getrennt von den Logs)
+func Progressf(format string, args ...interface{}) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	_, _ = fmt.Fprintf(progressOut, format+"\n", args...)
+}
@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSetup_JSONHandler(t *testing.T) {
+	defaultLogger := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	var buf bytes.Buffer
+	if err := Setup(&buf, FormatJSON, "warn"); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	slog.Info("filtered out")
+	slog.Warn("something happened", "issue", "42")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected exactly one log line, got %q", buf.String())
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("log line is not JSON: %v", err)
+	}
+	if record["msg"] != "something happened" || record["level"] != "WARN" || record["issue"] != "42" {
+		t.Fatalf("unexpected record: %v", record)
+	}
+}
+
+func TestSetup_TextHandlerAndErrors(t *testing.T) {
+	defaultLogger := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	var buf bytes.Buffer
+	if err := Setup(&buf, "", "debug"); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	slog.Debug("details", "count", 3)
+	if !strings.Contains(buf.String(), `level=DEBUG msg=details count=3`) {
+		t.Fatalf("unexpected text output: %q", buf.String())
+	}
+
+	if err := Setup(&buf, "xml", "info"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if err := Setup(&buf, FormatText, "loud"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}
+
+func TestProgressf(t *testing.T) {
+	var buf bytes.Buffer
+	SetProgressOutput(&buf)
+	t.Cleanup(func() { SetProgressOutput(os.Stdout) })
+
+	Progressf("Lade %d Issues", 3)
+
+	if buf.String() != "Lade 3 Issues\n" {
+		t.Fatalf("unexpected progress output: %q", buf.String())
+	}
+}
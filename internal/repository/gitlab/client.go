@@ -4,7 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
@@ -41,6 +46,281 @@ func (r *Repository) GetMilestoneIssues(projectPath string, milestoneTitle *stri
 	return response.Data.Project.Issues.Nodes, nil
 }
 
+// GetIssue holt ein einzelnes Issue via GraphQL (nil, falls es nicht existiert)
+func (r *Repository) GetIssue(projectPath string, iid string) (*gitlabDomain.Issue, error) {
+	query := fmt.Sprintf(`{
+        project(fullPath: "%s") {
+            issue(iid: "%s") {%s}
+        }
+    }`, projectPath, iid, issueFields)
+
+	var response gitlabDomain.IssueGraphQLResponse
+	if err := r.executeGraphQL(query, &response); err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", err)
+	}
+
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("GraphQL errors: %v", response.Errors[0].Message)
+	}
+
+	if response.Data.Project == nil {
+		return nil, fmt.Errorf("project not found: %s", projectPath)
+	}
+
+	return response.Data.Project.Issue, nil
+}
+
+// GetClosedIssues holt geschlossene Issues eines Milestones und/oder Zeitraums via GraphQL (über alle Seiten)
+func (r *Repository) GetClosedIssues(projectPath string, milestoneTitle *string, closedAfter, closedBefore *time.Time) ([]gitlabDomain.Issue, error) {
+	filters := `, state: closed` + milestoneArgument(milestoneTitle) +
+		timeArgument("closedAfter", closedAfter) + timeArgument("closedBefore", closedBefore)
+
+	return r.getIssuePages(projectPath, filters, issueFields)
+}
+
+// getIssuePages holt die Issues eines Projekts mit den angegebenen Filtern und Feldern über alle Seiten
+func (r *Repository) getIssuePages(projectPath string, filters string, fields string) ([]gitlabDomain.Issue, error) {
+	var issues []gitlabDomain.Issue
+	after := ""
+	for {
+		query := fmt.Sprintf(`{
+        project(fullPath: "%s") {
+            issues(first: 100%s%s) {
+                nodes {%s}
+                page_info: pageInfo {
+                    has_next_page: hasNextPage
+                    end_cursor: endCursor
+                }
+            }
+        }
+    }`, projectPath, filters, after, fields)
+
+		var response gitlabDomain.IssuePageGraphQLResponse
+		if err := r.executeGraphQL(query, &response); err != nil {
+			return nil, fmt.Errorf("GraphQL query failed: %w", err)
+		}
+
+		if len(response.Errors) > 0 {
+			return nil, fmt.Errorf("GraphQL errors: %v", response.Errors[0].Message)
+		}
+
+		if response.Data.Project == nil {
+			return nil, fmt.Errorf("project not found: %s", projectPath)
+		}
+
+		page := response.Data.Project.Issues
+		issues = append(issues, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return issues, nil
+		}
+		after = fmt.Sprintf(`, after: "%s"`, page.PageInfo.EndCursor)
+	}
+}
+
+// GetMergedMergeRequests holt gemergte Merge Requests eines Milestones und/oder Zeitraums via GraphQL (über alle Seiten)
+func (r *Repository) GetMergedMergeRequests(projectPath string, milestoneTitle *string, mergedAfter, mergedBefore *time.Time) ([]gitlabDomain.MergeRequest, error) {
+	filters := `, state: merged` + milestoneArgument(milestoneTitle) +
+		timeArgument("mergedAfter", mergedAfter) + timeArgument("mergedBefore", mergedBefore)
+
+	var mergeRequests []gitlabDomain.MergeRequest
+	after := ""
+	for {
+		query := fmt.Sprintf(`{
+        project(fullPath: "%s") {
+            mergeRequests(first: 100%s%s) {
+                nodes {
+                    iid
+                    title
+                    web_url: webUrl
+                    merged_at: mergedAt
+                    labels {
+                        nodes {
+                            title
+                        }
+                    }
+                }
+                page_info: pageInfo {
+                    has_next_page: hasNextPage
+                    end_cursor: endCursor
+                }
+            }
+        }
+    }`, projectPath, filters, after)
+
+		var response gitlabDomain.MergeRequestGraphQLResponse
+		if err := r.executeGraphQL(query, &response); err != nil {
+			return nil, fmt.Errorf("GraphQL query failed: %w", err)
+		}
+
+		if len(response.Errors) > 0 {
+			return nil, fmt.Errorf("GraphQL errors: %v", response.Errors[0].Message)
+		}
+
+		if response.Data.Project == nil {
+			return nil, fmt.Errorf("project not found: %s", projectPath)
+		}
+
+		page := response.Data.Project.MergeRequests
+		mergeRequests = append(mergeRequests, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return mergeRequests, nil
+		}
+		after = fmt.Sprintf(`, after: "%s"`, page.PageInfo.EndCursor)
+	}
+}
+
+// GetIssueWeights holt die Gewichte der Issues über alle Seiten
+// (GitLab Premium; ohne Lizenz liefert GitLab einen Fehler oder null)
+func (r *Repository) GetIssueWeights(projectPath string, milestoneTitle *string) (map[string]int, error) {
+	issues, err := r.getIssuePages(projectPath, milestoneArgument(milestoneTitle), `
+                    iid
+                    weight
+                `)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[string]int)
+	for _, issue := range issues {
+		if issue.Weight != nil {
+			weights[issue.IID] = *issue.Weight
+		}
+	}
+	return weights, nil
+}
+
+// GetIssueEpics holt die Epics der Issues über alle Seiten (GitLab Premium; ohne Lizenz liefert GitLab einen Fehler)
+func (r *Repository) GetIssueEpics(projectPath string, milestoneTitle *string) (map[string]gitlabDomain.Epic, error) {
+	issues, err := r.getIssuePages(projectPath, milestoneArgument(milestoneTitle), `
+                    iid
+                    epic {
+                        iid
+                        title
+                        state
+                        web_url: webUrl
+                        reference
+                    }
+                `)
+	if err != nil {
+		return nil, err
+	}
+
+	epics := make(map[string]gitlabDomain.Epic)
+	for _, issue := range issues {
+		if issue.Epic != nil {
+			epics[issue.IID] = *issue.Epic
+		}
+	}
+	return epics, nil
+}
+
+// GetWorkItemChildren holt die untergeordneten Work Items (z.B. Tasks) der Issues mit den angegebenen IIDs
+func (r *Repository) GetWorkItemChildren(projectPath string, iids []string) (map[string][]gitlabDomain.WorkItem, error) {
+	quoted := make([]string, len(iids))
+	for i, iid := range iids {
+		quoted[i] = strconv.Quote(iid)
+	}
+
+	query := fmt.Sprintf(`{
+        project(fullPath: "%s") {
+            work_items: workItems(iids: [%s], first: 100) {
+                nodes {
+                    iid
+                    widgets {
+                        ... on WorkItemWidgetHierarchy {
+                            children(first: 100) {
+                                nodes {
+                                    iid
+                                    title
+                                    state
+                                    web_url: webUrl
+                                    work_item_type: workItemType { name }
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    }`, projectPath, strings.Join(quoted, ", "))
+
+	var response gitlabDomain.WorkItemGraphQLResponse
+	if err := r.executeGraphQL(query, &response); err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", err)
+	}
+
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("GraphQL errors: %v", response.Errors[0].Message)
+	}
+	if response.Data.Project == nil {
+		return nil, fmt.Errorf("project not found: %s", projectPath)
+	}
+
+	children := make(map[string][]gitlabDomain.WorkItem)
+	for _, node := range response.Data.Project.WorkItems.Nodes {
+		for _, widget := range node.Widgets {
+			if widget.Children == nil {
+				continue
+			}
+			for _, child := range widget.Children.Nodes {
+				// Work Items kennen OPEN/CLOSED, Issues opened/closed
+				state := "opened"
+				if child.State == "CLOSED" {
+					state = "closed"
+				}
+				children[node.IID] = append(children[node.IID], gitlabDomain.WorkItem{
+					IID:    child.IID,
+					Title:  child.Title,
+					State:  state,
+					WebURL: child.WebURL,
+					Type:   child.WorkItemType.Name,
+				})
+			}
+		}
+	}
+	return children, nil
+}
+
+// GetMilestoneBurnup holt die Burnup-Zeitreihe aus dem Timebox-Report eines Milestones (GitLab Premium)
+func (r *Repository) GetMilestoneBurnup(projectPath string, milestoneTitle string) ([]gitlabDomain.BurnupPoint, error) {
+	query := fmt.Sprintf(`{
+        project(fullPath: %s) {
+            milestones(title: %s, includeAncestors: true, first: 1) {
+                nodes {
+                    report(fullPath: %s) {
+                        burnup_time_series: burnupTimeSeries {
+                            date
+                            scope_count: scopeCount
+                            scope_weight: scopeWeight
+                            completed_count: completedCount
+                            completed_weight: completedWeight
+                        }
+                    }
+                }
+            }
+        }
+    }`, graphQLString(projectPath), graphQLString(milestoneTitle), graphQLString(projectPath))
+
+	var response gitlabDomain.MilestoneReportGraphQLResponse
+	if err := r.executeGraphQL(query, &response); err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", err)
+	}
+
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("GraphQL errors: %v", response.Errors[0].Message)
+	}
+
+	if response.Data.Project == nil {
+		return nil, fmt.Errorf("project not found: %s", projectPath)
+	}
+
+	nodes := response.Data.Project.Milestones.Nodes
+	if len(nodes) == 0 || nodes[0].Report == nil {
+		return nil, fmt.Errorf("milestone not found: %s", milestoneTitle)
+	}
+	return nodes[0].Report.BurnupTimeSeries, nil
+}
+
 // GetProjectIssues holt alle Issues eines Projekts via REST API
 func (r *Repository) GetProjectIssues(projectPath string) ([]gitlabDomain.Issue, error) {
 	url := fmt.Sprintf("%s/projects/%s/issues", r.baseURL, projectPath)
@@ -63,12 +343,7 @@ func (r *Repository) GetProjectIssues(projectPath string) ([]gitlabDomain.Issue,
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err = resp.Body.Close()
-		if err != nil {
-			fmt.Printf("fehler beim Abschliessen des Response bodies.")
-		}
-	}()
+	defer closeBody(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("GitLab API error: %d", resp.StatusCode)
@@ -79,10 +354,253 @@ func (r *Repository) GetProjectIssues(projectPath string) ([]gitlabDomain.Issue,
 	return issues, err
 }
 
+// GetLabelEvents holt die Resource Label Events eines Issues via REST API (älteste zuerst).
+// Es wird über alle Seiten paginiert (X-Next-Page), lange laufende Issues haben mehr als 100 Events.
+func (r *Repository) GetLabelEvents(projectPath string, iid string) ([]gitlabDomain.LabelEvent, error) {
+	var events []gitlabDomain.LabelEvent
+	for page := "1"; page != ""; {
+		endpoint := fmt.Sprintf("%s/projects/%s/issues/%s/resource_label_events?per_page=100&page=%s",
+			r.baseURL, url.PathEscape(projectPath), iid, url.QueryEscape(page))
+
+		pageEvents, next, err := r.getLabelEventsPage(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, pageEvents...)
+		page = next
+	}
+	return events, nil
+}
+
+// getLabelEventsPage holt eine Seite Label Events und liefert die Nummer der nächsten Seite (leer auf der letzten)
+func (r *Repository) getLabelEventsPage(endpoint string) ([]gitlabDomain.LabelEvent, string, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+r.config.GitLabToken)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer closeBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("GitLab API error: %d", resp.StatusCode)
+	}
+
+	var events []gitlabDomain.LabelEvent
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, "", err
+	}
+	return events, resp.Header.Get("X-Next-Page"), nil
+}
+
+// issueLinkResponse ist ein verknüpftes Issue der REST API (IID als Zahl, Referenzen als Objekt)
+type issueLinkResponse struct {
+	IID        int    `json:"iid"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	WebURL     string `json:"web_url"`
+	References struct {
+		Full string `json:"full"`
+	} `json:"references"`
+	LinkType string `json:"link_type"`
+}
+
+// GetIssueLinks holt die verknüpften Issues eines Issues via REST API
+func (r *Repository) GetIssueLinks(projectPath string, iid string) ([]gitlabDomain.IssueLink, error) {
+	endpoint := fmt.Sprintf("%s/projects/%s/issues/%s/links", r.baseURL, url.PathEscape(projectPath), iid)
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+r.config.GitLabToken)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitLab API error: %d", resp.StatusCode)
+	}
+
+	var response []issueLinkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	links := make([]gitlabDomain.IssueLink, 0, len(response))
+	for _, link := range response {
+		links = append(links, gitlabDomain.IssueLink{
+			IID:       strconv.Itoa(link.IID),
+			Title:     link.Title,
+			State:     link.State,
+			WebURL:    link.WebURL,
+			Reference: link.References.Full,
+			LinkType:  link.LinkType,
+		})
+	}
+	return links, nil
+}
+
+// GetTimelogs holt die Zeitbuchungen eines Projekts oder einer Gruppe zwischen startDate und
+// endDate (jeweils inklusive) via GraphQL. Anders als bei Issues wird über alle Seiten paginiert,
+// da ein Abrechnungszeitraum schnell mehr als 100 Buchungen enthält.
+func (r *Repository) GetTimelogs(fullPath string, group bool, startDate, endDate time.Time) ([]gitlabDomain.Timelog, error) {
+	namespace := "project"
+	if group {
+		namespace = "group"
+	}
+
+	var timelogs []gitlabDomain.Timelog
+	after := ""
+	for {
+		query := fmt.Sprintf(`{
+        namespace: %s(fullPath: "%s") {
+            timelogs(startDate: "%s", endDate: "%s", first: 100%s) {
+                nodes {
+                    spent_at: spentAt
+                    time_spent: timeSpent
+                    summary
+                    user {
+                        username
+                        name
+                    }
+                    issue {%s}
+                    merge_request: mergeRequest {%s}
+                }
+                page_info: pageInfo {
+                    has_next_page: hasNextPage
+                    end_cursor: endCursor
+                }
+            }
+        }
+    }`, namespace, fullPath, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"), after, timelogTargetFields, timelogTargetFields)
+
+		var response gitlabDomain.TimelogGraphQLResponse
+		if err := r.executeGraphQL(query, &response); err != nil {
+			return nil, fmt.Errorf("GraphQL query failed: %w", err)
+		}
+
+		if len(response.Errors) > 0 {
+			return nil, fmt.Errorf("GraphQL errors: %v", response.Errors[0].Message)
+		}
+
+		if response.Data.Namespace == nil {
+			return nil, fmt.Errorf("%s not found: %s", namespace, fullPath)
+		}
+
+		page := response.Data.Namespace.Timelogs
+		timelogs = append(timelogs, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return timelogs, nil
+		}
+		after = fmt.Sprintf(`, after: "%s"`, page.PageInfo.EndCursor)
+	}
+}
+
+// GetTodos holt alle offenen To-Dos des Benutzers, dem das Token gehört, via GraphQL (über alle Seiten)
+func (r *Repository) GetTodos() ([]gitlabDomain.Todo, error) {
+	var todos []gitlabDomain.Todo
+	after := ""
+	for {
+		query := fmt.Sprintf(`{
+        current_user: currentUser {
+            todos(state: pending, first: 100%s) {
+                nodes {
+                    id
+                    action
+                    body
+                    target_type: targetType
+                    created_at: createdAt
+                    author {
+                        username
+                        name
+                    }
+                    project {
+                        full_path: fullPath
+                        web_url: webUrl
+                    }
+                    target {
+                        ... on Issue {%s}
+                        ... on MergeRequest {%s}
+                    }
+                }
+                page_info: pageInfo {
+                    has_next_page: hasNextPage
+                    end_cursor: endCursor
+                }
+            }
+        }
+    }`, after, timelogTargetFields, timelogTargetFields)
+
+		var response gitlabDomain.TodoGraphQLResponse
+		if err := r.executeGraphQL(query, &response); err != nil {
+			return nil, fmt.Errorf("GraphQL query failed: %w", err)
+		}
+
+		if len(response.Errors) > 0 {
+			return nil, fmt.Errorf("GraphQL errors: %v", response.Errors[0].Message)
+		}
+
+		if response.Data.CurrentUser == nil {
+			return nil, fmt.Errorf("current user not found")
+		}
+
+		page := response.Data.CurrentUser.Todos
+		todos = append(todos, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return todos, nil
+		}
+		after = fmt.Sprintf(`, after: "%s"`, page.PageInfo.EndCursor)
+	}
+}
+
+// MarkTodoDone markiert ein To-Do (globale ID) über die Mutation todoMarkDone als erledigt
+func (r *Repository) MarkTodoDone(id string) error {
+	query := fmt.Sprintf(`mutation {
+        todo_mark_done: todoMarkDone(input: {id: "%s"}) {
+            errors
+        }
+    }`, id)
+
+	var response gitlabDomain.TodoMarkDoneGraphQLResponse
+	if err := r.executeGraphQL(query, &response); err != nil {
+		return fmt.Errorf("GraphQL query failed: %w", err)
+	}
+
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("GraphQL errors: %v", response.Errors[0].Message)
+	}
+
+	if result := response.Data.TodoMarkDone; result != nil && len(result.Errors) > 0 {
+		return fmt.Errorf("todoMarkDone failed: %s", strings.Join(result.Errors, ", "))
+	}
+
+	return nil
+}
+
+// UpdateIssue ändert Felder eines Issues via REST API (z.B. state_event, title, due_date)
+func (r *Repository) UpdateIssue(projectPath string, iid string, fields map[string]interface{}) error {
+	endpoint := fmt.Sprintf("%s/projects/%s/issues/%s", r.baseURL, url.PathEscape(projectPath), iid)
+	return r.sendJSON(http.MethodPut, endpoint, fields)
+}
+
+// CreateIssueNote fügt einem Issue einen Kommentar hinzu
+func (r *Repository) CreateIssueNote(projectPath string, iid string, body string) error {
+	endpoint := fmt.Sprintf("%s/projects/%s/issues/%s/notes", r.baseURL, url.PathEscape(projectPath), iid)
+	return r.sendJSON(http.MethodPost, endpoint, map[string]string{"body": body})
+}
+
 // ValidateConnection prüft ob die GitLab-Verbindung funktioniert
 func (r *Repository) ValidateConnection() error {
 	url := fmt.Sprintf("%s/user", r.baseURL)
-	fmt.Printf("   Config GitLab URL: %s\n", r.baseURL)
+	slog.Debug("validating gitlab connection", "url", r.baseURL)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return err
@@ -94,12 +612,7 @@ func (r *Repository) ValidateConnection() error {
 	if err != nil {
 		return fmt.Errorf("connection failed: %w", err)
 	}
-	defer func() {
-		err = resp.Body.Close()
-		if err != nil {
-			fmt.Printf("fehler beim Abschliessen des Response bodies.")
-		}
-	}()
+	defer closeBody(resp.Body)
 
 	if resp.StatusCode == http.StatusUnauthorized {
 		return fmt.Errorf("invalid GitLab token")
@@ -114,24 +627,20 @@ func (r *Repository) ValidateConnection() error {
 
 // Private helper methods
 
-func (r *Repository) buildMilestoneQuery(projectPath string, milestoneTitle *string) string {
-	milestoneFilter := ""
-	if milestoneTitle != nil && *milestoneTitle != "" && *milestoneTitle != "*" {
-		milestoneFilter = fmt.Sprintf(`, milestoneTitle: "%s"`, *milestoneTitle)
-	}
-
-	return fmt.Sprintf(`{
-        project(fullPath: "%s") {
-            issues(first: 100%s) {
-                nodes {
+// issueFields sind die Felder, die für jedes Issue abgefragt werden.
+// Aliase bilden die camelCase-Felder von GraphQL auf die snake_case-Tags des Modells ab.
+const issueFields = `
                     iid
                     title
                     description
                     state
-                    webUrl
-                    dueDate
-                    createdAt
-                    updatedAt
+                    web_url: webUrl
+                    due_date: dueDate
+                    created_at: createdAt
+                    updated_at: updatedAt
+                    closed_at: closedAt
+                    time_estimate: timeEstimate
+                    total_time_spent: totalTimeSpent
                     labels {
                         nodes {
                             title
@@ -142,24 +651,102 @@ func (r *Repository) buildMilestoneQuery(projectPath string, milestoneTitle *str
                             name
                         }
                     }
-                }
+                    milestone {
+                        title
+                    }
+                `
+
+// timelogTargetFields sind die Felder des Issues bzw. Merge Requests einer Zeitbuchung
+const timelogTargetFields = `
+                        iid
+                        title
+                        web_url: webUrl
+                        reference(full: true)
+                    `
+
+func (r *Repository) buildMilestoneQuery(projectPath string, milestoneTitle *string) string {
+	milestoneFilter := milestoneArgument(milestoneTitle)
+
+	return fmt.Sprintf(`{
+        project(fullPath: "%s") {
+            issues(first: 100%s) {
+                nodes {%s}
             }
         }
-    }`, projectPath, milestoneFilter)
+    }`, projectPath, milestoneFilter, issueFields)
+}
+
+// milestoneArgument liefert den GraphQL-Filter für einen Milestone ("" oder "*" bedeutet alle)
+func milestoneArgument(milestoneTitle *string) string {
+	if milestoneTitle == nil || *milestoneTitle == "" || *milestoneTitle == "*" {
+		return ""
+	}
+	return fmt.Sprintf(`, milestoneTitle: %s`, graphQLString(*milestoneTitle))
+}
+
+// graphQLString liefert s als GraphQL-String-Literal. Die Escapes von JSON sind eine Teilmenge
+// der GraphQL-Escapes; Anführungszeichen im Titel beenden so nicht den String.
+func graphQLString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
+
+// timeArgument liefert einen GraphQL-Filter für einen Zeitpunkt (leer, wenn nicht gesetzt)
+func timeArgument(name string, t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return fmt.Sprintf(`, %s: "%s"`, name, t.UTC().Format(time.RFC3339))
+}
+
+// sendJSON sendet eine schreibende REST-Anfrage und erwartet einen 2xx-Status
+func (r *Repository) sendJSON(method string, endpoint string, payload interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+r.config.GitLabToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GitLab API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
 }
 
 func (r *Repository) executeGraphQLQuery(query string) (*gitlabDomain.GraphQLResponse, error) {
+	var response gitlabDomain.GraphQLResponse
+	err := r.executeGraphQL(query, &response)
+	return &response, err
+}
+
+// executeGraphQL führt eine GraphQL-Abfrage aus und dekodiert die Antwort in result
+func (r *Repository) executeGraphQL(query string, result interface{}) error {
 	url := r.config.GetGitLabBaseURL() + "/api/graphql"
 
 	requestBody := map[string]string{"query": query}
 	jsonData, err := json.Marshal(requestBody)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+r.config.GitLabToken)
@@ -167,20 +754,20 @@ func (r *Repository) executeGraphQLQuery(query string) (*gitlabDomain.GraphQLRes
 
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer func() {
-		err = resp.Body.Close()
-		if err != nil {
-			fmt.Printf("fehler beim Abschliessen des Response bodies.")
-		}
-	}()
+	defer closeBody(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
 	}
 
-	var response gitlabDomain.GraphQLResponse
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	return &response, err
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// closeBody schließt den Response Body und protokolliert Fehler dabei
+func closeBody(body io.Closer) {
+	if err := body.Close(); err != nil {
+		slog.Warn("closing response body failed", "error", err)
+	}
 }
@@ -176,3 +176,511 @@ func TestGitLab_GetMilestoneIssues_GraphQLErrors(t *testing.T) {
 		t.Fatalf("expected graphQL errors, got %v", err)
 	}
 }
+
+func TestGitLab_GetIssue_Success(t *testing.T) {
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/graphql" || r.Method != http.MethodPost {
+			t.Fatalf("unexpected path/method: %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Query string `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if !strings.Contains(body.Query, `issue(iid: "42")`) || !strings.Contains(body.Query, "web_url: webUrl") {
+			t.Fatalf("unexpected query: %s", body.Query)
+		}
+		_, _ = w.Write([]byte(`{"data":{"project":{"issue":{
+			"iid":"42","title":"Hook","state":"closed","web_url":"https://example/42",
+			"due_date":"2025-05-01","milestone":{"title":"v1"}}}}}`))
+	})
+	defer srv.Close()
+
+	issue, err := repo.GetIssue("group/project", "42")
+	if err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+	if issue == nil || issue.IID != "42" || issue.WebURL != "https://example/42" {
+		t.Fatalf("unexpected issue: %+v", issue)
+	}
+	if issue.DueDate == nil || *issue.DueDate != "2025-05-01" || issue.Milestone == nil || issue.Milestone.Title != "v1" {
+		t.Fatalf("due date/milestone not decoded: %+v", issue)
+	}
+}
+
+func TestGitLab_GetIssue_NotFound(t *testing.T) {
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"project":{"issue":null}}}`))
+	})
+	defer srv.Close()
+
+	issue, err := repo.GetIssue("group/project", "1")
+	if err != nil || issue != nil {
+		t.Fatalf("expected nil issue without error, got %+v, err=%v", issue, err)
+	}
+
+	repo2, srv2 := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"project":null}}`))
+	})
+	defer srv2.Close()
+
+	if _, err := repo2.GetIssue("missing/project", "1"); err == nil || !strings.Contains(err.Error(), "project not found") {
+		t.Fatalf("expected project not found error, got %v", err)
+	}
+}
+
+func TestGitLab_UpdateIssue_And_CreateIssueNote(t *testing.T) {
+	var requests []string
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+
+		switch {
+		case r.Method == http.MethodPut && r.URL.EscapedPath() == "/api/v4/projects/group%2Fproject/issues/5":
+			if body["state_event"] != "close" {
+				t.Fatalf("unexpected update body: %v", body)
+			}
+			_, _ = w.Write([]byte(`{"iid":5}`))
+		case r.Method == http.MethodPost && r.URL.EscapedPath() == "/api/v4/projects/group%2Fproject/issues/5/notes":
+			if body["body"] != "hello" {
+				t.Fatalf("unexpected note body: %v", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"404 Not found"}`))
+		}
+	})
+	defer srv.Close()
+
+	if err := repo.UpdateIssue("group/project", "5", map[string]interface{}{"state_event": "close"}); err != nil {
+		t.Fatalf("UpdateIssue() error = %v", err)
+	}
+	if err := repo.CreateIssueNote("group/project", "5", "hello"); err != nil {
+		t.Fatalf("CreateIssueNote() error = %v", err)
+	}
+
+	err := repo.UpdateIssue("group/project", "404", map[string]interface{}{})
+	if err == nil || !strings.Contains(err.Error(), "GitLab API error 404") {
+		t.Fatalf("expected 404 error, got %v", err)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("unexpected requests: %v", requests)
+	}
+}
+
+func TestGitLab_GetClosedIssues_And_MergedMergeRequests(t *testing.T) {
+	var queries []string
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body["query"])
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(body["query"], "mergeRequests") {
+			_, _ = w.Write([]byte(`{"data":{"project":{"mergeRequests":{"nodes":[{"iid":"34","title":"MR","web_url":"m","merged_at":"2024-03-01T10:00:00Z","labels":{"nodes":[{"title":"bug"}]}}]}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"project":{"issues":{"nodes":[{"iid":"12","state":"closed","closed_at":"2024-02-28T09:00:00Z"}]}}}}`))
+	})
+	defer srv.Close()
+
+	milestone := "v1.2.0"
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	issues, err := repo.GetClosedIssues("group/project", &milestone, &after, nil)
+	if err != nil {
+		t.Fatalf("GetClosedIssues() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].ClosedAt == nil || issues[0].ClosedAt.Day() != 28 {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+
+	mrs, err := repo.GetMergedMergeRequests("group/project", &milestone, &after, nil)
+	if err != nil {
+		t.Fatalf("GetMergedMergeRequests() error = %v", err)
+	}
+	if len(mrs) != 1 || mrs[0].IID != "34" || mrs[0].MergedAt == nil || mrs[0].Labels.Nodes[0].Title != "bug" {
+		t.Fatalf("unexpected merge requests: %+v", mrs)
+	}
+
+	for _, want := range []string{`state: closed, milestoneTitle: "v1.2.0", closedAfter: "2024-01-01T00:00:00Z"`, `state: merged, milestoneTitle: "v1.2.0", mergedAfter: "2024-01-01T00:00:00Z"`} {
+		if !strings.Contains(strings.Join(queries, "\n"), want) {
+			t.Errorf("queries should contain %q:\n%s", want, strings.Join(queries, "\n"))
+		}
+	}
+	if strings.Contains(strings.Join(queries, "\n"), "Before") {
+		t.Error("unset upper bound should not be sent")
+	}
+}
+
+func TestGitLab_ReleaseNotesQueries_PaginateAndQuoteMilestone(t *testing.T) {
+	var queries []string
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body["query"])
+
+		w.Header().Set("Content-Type", "application/json")
+		nextPage := !strings.Contains(body["query"], `after: "c1"`)
+		connection := `{"nodes":[{"iid":"2"}],"page_info":{"has_next_page":false}}`
+		if nextPage {
+			connection = `{"nodes":[{"iid":"1"}],"page_info":{"has_next_page":true,"end_cursor":"c1"}}`
+		}
+		if strings.Contains(body["query"], "mergeRequests") {
+			_, _ = w.Write([]byte(`{"data":{"project":{"mergeRequests":` + connection + `}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"project":{"issues":` + connection + `}}}`))
+	})
+	defer srv.Close()
+
+	milestone := `v2 "beta"`
+
+	issues, err := repo.GetClosedIssues("group/project", &milestone, nil, nil)
+	if err != nil || len(issues) != 2 || issues[1].IID != "2" {
+		t.Fatalf("GetClosedIssues() should load all pages, got %+v, err=%v", issues, err)
+	}
+	mrs, err := repo.GetMergedMergeRequests("group/project", &milestone, nil, nil)
+	if err != nil || len(mrs) != 2 || mrs[1].IID != "2" {
+		t.Fatalf("GetMergedMergeRequests() should load all pages, got %+v, err=%v", mrs, err)
+	}
+
+	if len(queries) != 4 {
+		t.Fatalf("expected two requests per query, got %d", len(queries))
+	}
+	for _, query := range queries {
+		if !strings.Contains(query, `milestoneTitle: "v2 \"beta\""`) {
+			t.Errorf("milestone title should be escaped:\n%s", query)
+		}
+	}
+}
+
+func TestGitLab_GetIssueWeights_And_MilestoneBurnup(t *testing.T) {
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(body["query"], "burnupTimeSeries") {
+			if !strings.Contains(body["query"], `milestones(title: "v1"`) || !strings.Contains(body["query"], `report(fullPath: "group/project")`) {
+				t.Errorf("unexpected report query: %s", body["query"])
+			}
+			_, _ = w.Write([]byte(`{"data":{"project":{"milestones":{"nodes":[{"report":{"burnup_time_series":[` +
+				`{"date":"2024-03-01","scope_count":3,"scope_weight":8,"completed_count":1,"completed_weight":5}]}}]}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"project":{"issues":{"nodes":[{"iid":"1","weight":3},{"iid":"2","weight":null}]}}}}`))
+	})
+	defer srv.Close()
+
+	weights, err := repo.GetIssueWeights("group/project", nil)
+	if err != nil {
+		t.Fatalf("GetIssueWeights() error = %v", err)
+	}
+	if len(weights) != 1 || weights["1"] != 3 {
+		t.Errorf("unexpected weights: %v", weights)
+	}
+
+	points, err := repo.GetMilestoneBurnup("group/project", "v1")
+	if err != nil {
+		t.Fatalf("GetMilestoneBurnup() error = %v", err)
+	}
+	if len(points) != 1 || points[0].ScopeCount != 3 || points[0].CompletedWeight != 5 {
+		t.Errorf("unexpected burnup: %+v", points)
+	}
+}
+
+func TestGitLab_GetIssueWeights_AllPages(t *testing.T) {
+	var queries []string
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body["query"])
+
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.Contains(body["query"], `after: "c1"`) {
+			_, _ = w.Write([]byte(`{"data":{"project":{"issues":{"nodes":[{"iid":"1","weight":3}],` +
+				`"page_info":{"has_next_page":true,"end_cursor":"c1"}}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"project":{"issues":{"nodes":[{"iid":"101","weight":5}],` +
+			`"page_info":{"has_next_page":false,"end_cursor":"c2"}}}}}`))
+	})
+	defer srv.Close()
+
+	weights, err := repo.GetIssueWeights("group/project", nil)
+	if err != nil {
+		t.Fatalf("GetIssueWeights() error = %v", err)
+	}
+	if len(queries) != 2 || weights["1"] != 3 || weights["101"] != 5 {
+		t.Fatalf("weights of all pages expected, got %v after %d requests", weights, len(queries))
+	}
+}
+
+func TestGitLab_GetMilestoneBurnup_WithoutReport(t *testing.T) {
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"project":{"milestones":{"nodes":[]}}}}`))
+	})
+	defer srv.Close()
+
+	if _, err := repo.GetMilestoneBurnup("group/project", "v9"); err == nil {
+		t.Error("expected an error for a missing milestone")
+	}
+}
+
+func TestGitLab_GetMilestoneBurnup_EscapesTitle(t *testing.T) {
+	var query string
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		query = body["query"]
+		_, _ = w.Write([]byte(`{"data":{"project":{"milestones":{"nodes":[{"report":{"burnup_time_series":[]}}]}}}}`))
+	})
+	defer srv.Close()
+
+	if _, err := repo.GetMilestoneBurnup("group/project", `v2 "beta" \ rc`); err != nil {
+		t.Fatalf("GetMilestoneBurnup() error = %v", err)
+	}
+	if !strings.Contains(query, `milestones(title: "v2 \"beta\" \\ rc"`) || !strings.Contains(query, `report(fullPath: "group/project")`) {
+		t.Errorf("milestone title should be escaped:\n%s", query)
+	}
+}
+
+func TestGitLab_GetLabelEvents(t *testing.T) {
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/projects/group%2Fproject/issues/7/resource_label_events" {
+			t.Errorf("unexpected path: %s", r.URL.EscapedPath())
+		}
+		_, _ = w.Write([]byte(`[{"action":"add","created_at":"2024-03-01T10:00:00Z","label":{"name":"workflow::doing"}},` +
+			`{"action":"remove","created_at":"2024-03-02T10:00:00Z","label":null}]`))
+	})
+	defer srv.Close()
+
+	events, err := repo.GetLabelEvents("group/project", "7")
+	if err != nil {
+		t.Fatalf("GetLabelEvents() error = %v", err)
+	}
+	if len(events) != 2 || events[0].Label.Name != "workflow::doing" || events[1].Label != nil ||
+		!events[0].CreatedAt.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected events: %+v", events)
+	}
+}
+
+func TestGitLab_GetLabelEvents_FollowsNextPage(t *testing.T) {
+	var pages []string
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		if page == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = w.Write([]byte(`[{"action":"add","created_at":"2024-03-01T10:00:00Z","label":{"name":"bug"}}]`))
+			return
+		}
+		w.Header().Set("X-Next-Page", "")
+		_, _ = w.Write([]byte(`[{"action":"add","created_at":"2024-03-05T10:00:00Z","label":{"name":"workflow::doing"}}]`))
+	})
+	defer srv.Close()
+
+	events, err := repo.GetLabelEvents("group/project", "7")
+	if err != nil {
+		t.Fatalf("GetLabelEvents() error = %v", err)
+	}
+	if len(events) != 2 || events[1].Label.Name != "workflow::doing" {
+		t.Fatalf("events of the second page are missing: %+v", events)
+	}
+	if strings.Join(pages, ",") != "1,2" {
+		t.Errorf("unexpected pages requested: %v", pages)
+	}
+}
+
+func TestGitLab_GetIssueEpics_AllPages(t *testing.T) {
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.Contains(body["query"], `after: "c1"`) {
+			_, _ = w.Write([]byte(`{"data":{"project":{"issues":{"nodes":[{"iid":"1","epic":{"iid":"4","title":"Auth"}}],` +
+				`"page_info":{"has_next_page":true,"end_cursor":"c1"}}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"project":{"issues":{"nodes":[{"iid":"150","epic":{"iid":"5","title":"Billing"}}],` +
+			`"page_info":{"has_next_page":false}}}}}`))
+	})
+	defer srv.Close()
+
+	epics, err := repo.GetIssueEpics("group/project", nil)
+	if err != nil {
+		t.Fatalf("GetIssueEpics() error = %v", err)
+	}
+	if len(epics) != 2 || epics["150"].Title != "Billing" {
+		t.Fatalf("epics of all pages expected, got %+v", epics)
+	}
+}
+
+func TestGitLab_GetIssueEpics_And_WorkItemChildren(t *testing.T) {
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(body["query"], "WorkItemWidgetHierarchy") {
+			if !strings.Contains(body["query"], `workItems(iids: ["1", "2"], first: 100)`) {
+				t.Errorf("unexpected work item query: %s", body["query"])
+			}
+			_, _ = w.Write([]byte(`{"data":{"project":{"work_items":{"nodes":[` +
+				`{"iid":"1","widgets":[{},{"children":{"nodes":[` +
+				`{"iid":"7","title":"Tests","state":"CLOSED","web_url":"u7","work_item_type":{"name":"Task"}},` +
+				`{"iid":"8","title":"Docs","state":"OPEN","web_url":"u8","work_item_type":{"name":"Task"}}]}}]},` +
+				`{"iid":"2","widgets":[{"children":{"nodes":[]}}]}]}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"project":{"issues":{"nodes":[` +
+			`{"iid":"1","epic":{"iid":"5","title":"Login","state":"opened","web_url":"e5","reference":"&5"}},{"iid":"2","epic":null}]}}}}`))
+	})
+	defer srv.Close()
+
+	epics, err := repo.GetIssueEpics("group/project", nil)
+	if err != nil {
+		t.Fatalf("GetIssueEpics() error = %v", err)
+	}
+	if len(epics) != 1 || epics["1"].Reference != "&5" || epics["1"].WebURL != "e5" {
+		t.Errorf("unexpected epics: %+v", epics)
+	}
+
+	children, err := repo.GetWorkItemChildren("group/project", []string{"1", "2"})
+	if err != nil {
+		t.Fatalf("GetWorkItemChildren() error = %v", err)
+	}
+	want := []domain.WorkItem{
+		{IID: "7", Title: "Tests", State: "closed", WebURL: "u7", Type: "Task"},
+		{IID: "8", Title: "Docs", State: "opened", WebURL: "u8", Type: "Task"},
+	}
+	if len(children) != 1 || len(children["1"]) != 2 || children["1"][0] != want[0] || children["1"][1] != want[1] {
+		t.Errorf("unexpected children: %+v", children)
+	}
+}
+
+func TestGitLab_GetIssueLinks(t *testing.T) {
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/projects/group%2Fproject/issues/7/links" {
+			t.Errorf("unexpected path: %s", r.URL.EscapedPath())
+		}
+		_, _ = w.Write([]byte(`[{"id":1,"iid":12,"title":"API","state":"opened","web_url":"u12",` +
+			`"references":{"short":"#12","relative":"#12","full":"other/api#12"},"link_type":"is_blocked_by","issue_link_id":3}]`))
+	})
+	defer srv.Close()
+
+	links, err := repo.GetIssueLinks("group/project", "7")
+	if err != nil {
+		t.Fatalf("GetIssueLinks() error = %v", err)
+	}
+	want := domain.IssueLink{IID: "12", Title: "API", State: "opened", WebURL: "u12", Reference: "other/api#12", LinkType: "is_blocked_by"}
+	if len(links) != 1 || links[0] != want {
+		t.Errorf("unexpected links: %+v", links)
+	}
+}
+
+func TestGitLab_GetTimelogs_Paginates(t *testing.T) {
+	var queries []string
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body["query"])
+
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.Contains(body["query"], `after: "c1"`) {
+			_, _ = w.Write([]byte(`{"data":{"namespace":{"timelogs":{"nodes":[` +
+				`{"spent_at":"2024-03-01T09:00:00Z","time_spent":3600,"summary":"Review","user":{"username":"anna","name":"Anna"},` +
+				`"issue":{"iid":"12","title":"Login","web_url":"u12","reference":"group/project#12"},"merge_request":null}],` +
+				`"page_info":{"has_next_page":true,"end_cursor":"c1"}}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"namespace":{"timelogs":{"nodes":[` +
+			`{"spent_at":"2024-03-02T09:00:00Z","time_spent":900,"user":{"username":"bob","name":"Bob"},"issue":null,` +
+			`"merge_request":{"iid":"3","title":"Fix","web_url":"m3","reference":"group/project!3"}}],` +
+			`"page_info":{"has_next_page":false,"end_cursor":"c2"}}}}}`))
+	})
+	defer srv.Close()
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	timelogs, err := repo.GetTimelogs("group", true, start, start.AddDate(0, 1, -1))
+	if err != nil {
+		t.Fatalf("GetTimelogs() error = %v", err)
+	}
+	if len(timelogs) != 2 || timelogs[0].Issue == nil || timelogs[0].Issue.Reference != "group/project#12" ||
+		timelogs[0].User.Username != "anna" || timelogs[1].MergeRequest == nil || timelogs[1].TimeSpent != 900 {
+		t.Fatalf("unexpected timelogs: %+v", timelogs)
+	}
+
+	if len(queries) != 2 || !strings.Contains(queries[0], `namespace: group(fullPath: "group")`) ||
+		!strings.Contains(queries[0], `startDate: "2024-03-01", endDate: "2024-03-31", first: 100)`) {
+		t.Errorf("unexpected queries:\n%s", strings.Join(queries, "\n"))
+	}
+}
+
+func TestGitLab_GetTimelogs_ProjectNotFound(t *testing.T) {
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"namespace":null}}`))
+	})
+	defer srv.Close()
+
+	now := time.Now()
+	if _, err := repo.GetTimelogs("group/missing", false, now, now); err == nil || !strings.Contains(err.Error(), "project not found") {
+		t.Errorf("expected project not found, got %v", err)
+	}
+}
+
+func TestGitLab_GetTodos_AndMarkDone(t *testing.T) {
+	var queries []string
+	repo, srv := newGitLabRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body["query"])
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(body["query"], "todoMarkDone"):
+			if strings.Contains(body["query"], "Todo/2") {
+				_, _ = w.Write([]byte(`{"data":{"todo_mark_done":{"errors":["Todo not found"]}}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"todo_mark_done":{"errors":[]}}}`))
+		case !strings.Contains(body["query"], `after: "c1"`):
+			_, _ = w.Write([]byte(`{"data":{"current_user":{"todos":{"nodes":[` +
+				`{"id":"gid://gitlab/Todo/1","action":"review_requested","body":"Fix login","target_type":"MERGEREQUEST",` +
+				`"created_at":"2024-03-01T09:00:00Z","author":{"username":"anna","name":"Anna"},` +
+				`"project":{"full_path":"group/project","web_url":"p"},` +
+				`"target":{"iid":"3","title":"Fix login","web_url":"m3","reference":"group/project!3"}}],` +
+				`"page_info":{"has_next_page":true,"end_cursor":"c1"}}}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":{"current_user":{"todos":{"nodes":[` +
+				`{"id":"gid://gitlab/Todo/2","action":"mentioned","body":"@me look","target_type":"DESIGN","target":{}}],` +
+				`"page_info":{"has_next_page":false}}}}}`))
+		}
+	})
+	defer srv.Close()
+
+	todos, err := repo.GetTodos()
+	if err != nil {
+		t.Fatalf("GetTodos() error = %v", err)
+	}
+	if len(todos) != 2 || todos[0].Target == nil || todos[0].Target.Reference != "group/project!3" ||
+		todos[0].Author.Name != "Anna" || todos[0].Project.FullPath != "group/project" || todos[1].Action != "mentioned" {
+		t.Fatalf("unexpected todos: %+v", todos)
+	}
+	if !strings.Contains(queries[0], "todos(state: pending, first: 100)") {
+		t.Errorf("unexpected query:\n%s", queries[0])
+	}
+
+	if err := repo.MarkTodoDone("gid://gitlab/Todo/1"); err != nil {
+		t.Errorf("MarkTodoDone() error = %v", err)
+	}
+	if !strings.Contains(queries[len(queries)-1], `todoMarkDone(input: {id: "gid://gitlab/Todo/1"})`) {
+		t.Errorf("unexpected mutation:\n%s", queries[len(queries)-1])
+	}
+	if err := repo.MarkTodoDone("gid://gitlab/Todo/2"); err == nil || !strings.Contains(err.Error(), "Todo not found") {
+		t.Errorf("expected mutation error, got %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	gitlabDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+// timestampLayout bildet den Dateinamen eines Snapshots (UTC, sortiert chronologisch)
+const timestampLayout = "20060102T150405Z"
+
+// Snapshot ist eine gespeicherte Momentaufnahme auf der Festplatte
+type Snapshot struct {
+	Path    string
+	TakenAt time.Time
+}
+
+// Store legt Snapshots als JSON-Exporte unter <dir>/<projekt>/<zeitstempel>.json ab.
+// Läufe mit Filtern (Milestone, --only-unblocked) bekommen ein eigenes Unterverzeichnis
+// <dir>/<projekt>/<filter>/, damit ein geänderter Filter nicht als Änderung der Issues erscheint.
+type Store struct {
+	dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Save speichert einen Snapshot atomar. Ist der Inhalt identisch mit dem letzten Snapshot
+// des Projekts und Filters, wird nichts geschrieben (saved = false), damit z.B. der Watch-Modus
+// keine Duplikate erzeugt.
+func (s *Store) Save(document gitlabDomain.ExportDocument) (path string, saved bool, err error) {
+	snapshots, err := s.List(document.Metadata.Project, document.Metadata.Filters)
+	if err != nil {
+		return "", false, err
+	}
+	if len(snapshots) > 0 {
+		latest, err := Load(snapshots[len(snapshots)-1].Path)
+		if err == nil && sameIssues(latest.Issues, document.Issues) {
+			return snapshots[len(snapshots)-1].Path, false, nil
+		}
+	}
+
+	content, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return "", false, err
+	}
+
+	dir := s.snapshotDir(document.Metadata.Project, document.Metadata.Filters)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", false, fmt.Errorf("create history directory: %w", err)
+	}
+
+	path = filepath.Join(dir, document.Metadata.ExportedAt.UTC().Format(timestampLayout)+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return "", false, fmt.Errorf("write snapshot %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", false, fmt.Errorf("replace snapshot %s: %w", path, err)
+	}
+
+	return path, true, nil
+}
+
+// List liefert die Snapshots eines Projekts mit den angegebenen Filtern, der älteste zuerst
+// (ein fehlendes Verzeichnis ist kein Fehler)
+func (s *Store) List(project string, filters gitlabDomain.ExportFilters) ([]Snapshot, error) {
+	dir := s.snapshotDir(project, filters)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+
+	var snapshots []Snapshot
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		takenAt, err := time.Parse(timestampLayout, strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, Snapshot{Path: filepath.Join(dir, name), TakenAt: takenAt})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].TakenAt.Before(snapshots[j].TakenAt)
+	})
+	return snapshots, nil
+}
+
+// Load liest einen Snapshot oder einen beliebigen JSON-Export
+func Load(path string) (gitlabDomain.ExportDocument, error) {
+	var document gitlabDomain.ExportDocument
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return document, fmt.Errorf("read snapshot %s: %w", path, err)
+	}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return document, fmt.Errorf("parse snapshot %s: %w", path, err)
+	}
+	return document, nil
+}
+
+// snapshotDir liefert das Verzeichnis der Snapshots eines Projekts (z.B. history/group/project),
+// bei Filtern ergänzt um deren Schlüssel (z.B. history/group/project/milestone=v1.0)
+func (s *Store) snapshotDir(project string, filters gitlabDomain.ExportFilters) string {
+	dir := filepath.Join(s.dir, filepath.FromSlash(project))
+	if key := filterKey(filters); key != "" {
+		dir = filepath.Join(dir, key)
+	}
+	return dir
+}
+
+// filterKey bildet einen eindeutigen Verzeichnisnamen aus den Filtern (leer ohne Filter).
+// Jeder Teil enthält "=", das in GitLab-Pfaden nicht erlaubt ist, sodass kein Unterprojekt
+// denselben Namen haben kann; Werte werden escaped, damit "/" keine Ebene erzeugt.
+func filterKey(filters gitlabDomain.ExportFilters) string {
+	var parts []string
+	// "*" lädt wie ohne Filter alle Issues
+	if milestone := strings.TrimSpace(filters.Milestone); milestone != "" && milestone != "*" {
+		parts = append(parts, "milestone="+url.QueryEscape(milestone))
+	}
+	if len(filters.Labels) > 0 {
+		labels := make([]string, 0, len(filters.Labels))
+		for _, label := range filters.Labels {
+			if label = strings.TrimSpace(label); label != "" {
+				labels = append(labels, url.QueryEscape(label))
+			}
+		}
+		sort.Strings(labels)
+		if len(labels) > 0 {
+			parts = append(parts, "labels="+strings.Join(labels, "+"))
+		}
+	}
+	if filters.OnlyUnblocked {
+		parts = append(parts, "unblocked=true")
+	}
+	return strings.Join(parts, ",")
+}
+
+// sameIssues vergleicht Issues über ihre JSON-Darstellung, damit Zeitzonen nach dem Laden keine Rolle spielen
+func sameIssues(a, b []gitlabDomain.NormalizedIssue) bool {
+	left, errA := json.Marshal(a)
+	right, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(left) == string(right)
+}
@@ -0,0 +1,103 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gitlabDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+func snapshotDocument(at time.Time, title string) gitlabDomain.ExportDocument {
+	return gitlabDomain.ExportDocument{
+		SchemaVersion: gitlabDomain.ExportSchemaVersion,
+		Metadata:      gitlabDomain.ExportMetadata{ExportedAt: at, Project: "group/project", IssueCount: 1},
+		Issues:        []gitlabDomain.NormalizedIssue{{IID: "1", Title: title, CreatedAt: time.Date(2024, 2, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600))}},
+	}
+}
+
+func TestStore_SaveListLoad(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	first := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	path, saved, err := store.Save(snapshotDocument(first, "A"))
+	if err != nil || !saved {
+		t.Fatalf("Save() = %v, %v", saved, err)
+	}
+	if path != filepath.Join(dir, "group", "project", "20240301T090000Z.json") {
+		t.Errorf("unexpected path %s", path)
+	}
+
+	// Unveränderte Issues erzeugen keinen weiteren Snapshot
+	if _, saved, err := store.Save(snapshotDocument(first.Add(time.Hour), "A")); err != nil || saved {
+		t.Errorf("identical snapshot should be skipped: saved=%v err=%v", saved, err)
+	}
+	if _, saved, err := store.Save(snapshotDocument(first.Add(2*time.Hour), "B")); err != nil || !saved {
+		t.Errorf("changed snapshot should be saved: saved=%v err=%v", saved, err)
+	}
+
+	// Fremde Dateien werden ignoriert
+	if err := os.WriteFile(filepath.Join(dir, "group", "project", "notes.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err := store.List("group/project", gitlabDomain.ExportFilters{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 || !snapshots[0].TakenAt.Equal(first) || !snapshots[1].TakenAt.Equal(first.Add(2*time.Hour)) {
+		t.Fatalf("unexpected snapshots: %+v", snapshots)
+	}
+
+	latest, err := Load(snapshots[1].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Issues[0].Title != "B" || latest.Metadata.Project != "group/project" {
+		t.Errorf("unexpected snapshot content: %+v", latest)
+	}
+
+	if missing, err := store.List("other/project", gitlabDomain.ExportFilters{}); err != nil || len(missing) != 0 {
+		t.Errorf("missing project should yield no snapshots: %v, %v", missing, err)
+	}
+}
+
+func TestStore_HistoryPerFilter(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	at := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	unfiltered := snapshotDocument(at, "A")
+	filtered := snapshotDocument(at, "A")
+	filtered.Metadata.Filters = gitlabDomain.ExportFilters{Milestone: " v1/rc ", OnlyUnblocked: true}
+
+	if _, _, err := store.Save(unfiltered); err != nil {
+		t.Fatal(err)
+	}
+	path, saved, err := store.Save(filtered)
+	if err != nil || !saved {
+		t.Fatalf("same issues under another filter should get their own snapshot: saved=%v err=%v", saved, err)
+	}
+	if want := filepath.Join(dir, "group", "project", "milestone=v1%2Frc,unblocked=true", "20240301T090000Z.json"); path != want {
+		t.Errorf("unexpected path %s, want %s", path, want)
+	}
+
+	// Die Snapshots mit Filter tauchen nicht in der Historie ohne Filter auf
+	if snapshots, err := store.List("group/project", gitlabDomain.ExportFilters{}); err != nil || len(snapshots) != 1 {
+		t.Errorf("expected one unfiltered snapshot, got %v, %v", snapshots, err)
+	}
+	if snapshots, err := store.List("group/project", gitlabDomain.ExportFilters{Milestone: "v1/rc", OnlyUnblocked: true}); err != nil || len(snapshots) != 1 {
+		t.Errorf("expected one filtered snapshot, got %v, %v", snapshots, err)
+	}
+
+	if key := filterKey(gitlabDomain.ExportFilters{Milestone: "*"}); key != "" {
+		t.Errorf("milestone * loads all issues and should use the unfiltered history, got %q", key)
+	}
+
+	// Labels sind unabhängig von der Reihenfolge
+	if filterKey(gitlabDomain.ExportFilters{Labels: []string{"b", "a"}}) != filterKey(gitlabDomain.ExportFilters{Labels: []string{"a", "b"}}) {
+		t.Error("label order should not change the key")
+	}
+}
@@ -0,0 +1,236 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// fileVersion ist die Version des Dateiformats
+const fileVersion = 1
+
+// Link verknüpft einen Todoist Task mit einem GitLab Issue
+type Link struct {
+	TaskID      string    `json:"task_id"`
+	ProjectPath string    `json:"project_path"`
+	IssueIID    string    `json:"issue_iid"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TodoLink verknüpft einen Todoist Task mit einem To-Do aus der GitLab-To-Do-Liste
+type TodoLink struct {
+	TaskID string `json:"task_id"`
+	TodoID string `json:"todo_id"`
+	// ProjectID ist das Todoist-Projekt, in dem der Task angelegt wurde
+	ProjectID string    `json:"project_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type stateFile struct {
+	Version int                 `json:"version"`
+	Links   map[string]Link     `json:"links"`
+	Todos   map[string]TodoLink `json:"todos,omitempty"`
+}
+
+// Store speichert den Sync-Zustand als JSON-Datei
+type Store struct {
+	path  string
+	mu    sync.Mutex
+	data  stateFile
+	dirty bool
+
+	// modTime und size beschreiben die Datei beim letzten Laden/Speichern,
+	// um Änderungen durch andere Prozesse zu erkennen
+	modTime time.Time
+	size    int64
+}
+
+func NewStore(path string) *Store {
+	return &Store{
+		path: path,
+		data: stateFile{Version: fileVersion, Links: make(map[string]Link), Todos: make(map[string]TodoLink)},
+	}
+}
+
+// Load liest den Zustand von der Festplatte (eine fehlende Datei ist kein Fehler)
+func (s *Store) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+// ReloadIfChanged liest den Zustand neu, wenn ein anderer Prozess (export, watch) die Datei
+// seit dem letzten Laden/Speichern geschrieben hat. Ungespeicherte eigene Änderungen gehen vor.
+func (s *Store) ReloadIfChanged() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dirty {
+		return nil
+	}
+
+	info, err := os.Stat(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("stat state %s: %w", s.path, err)
+	}
+	if info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return nil
+	}
+
+	return s.load()
+}
+
+// load liest die Datei; der Aufrufer hält s.mu
+func (s *Store) load() error {
+	info, err := os.Stat(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("stat state %s: %w", s.path, err)
+	}
+
+	content, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read state %s: %w", s.path, err)
+	}
+
+	var data stateFile
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("parse state %s: %w", s.path, err)
+	}
+	if data.Links == nil {
+		data.Links = make(map[string]Link)
+	}
+	if data.Todos == nil {
+		data.Todos = make(map[string]TodoLink)
+	}
+
+	s.data = data
+	s.dirty = false
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return nil
+}
+
+// Save schreibt den Zustand atomar, sofern er sich geändert hat
+func (s *Store) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.dirty {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("create state directory: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return fmt.Errorf("write state %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("replace state %s: %w", s.path, err)
+	}
+	if info, err := os.Stat(s.path); err == nil {
+		s.modTime = info.ModTime()
+		s.size = info.Size()
+	}
+
+	s.dirty = false
+	return nil
+}
+
+// SetLink merkt sich die Verknüpfung eines Tasks mit einem Issue
+func (s *Store) SetLink(taskID, projectPath, issueIID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.data.Links[taskID]
+	if ok && existing.ProjectPath == projectPath && existing.IssueIID == issueIID {
+		return
+	}
+
+	s.data.Links[taskID] = Link{
+		TaskID:      taskID,
+		ProjectPath: projectPath,
+		IssueIID:    issueIID,
+		UpdatedAt:   time.Now().UTC(),
+	}
+	s.dirty = true
+}
+
+// LinkByTaskID sucht die Verknüpfung zu einem Todoist Task
+func (s *Store) LinkByTaskID(taskID string) (Link, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link, ok := s.data.Links[taskID]
+	return link, ok
+}
+
+// SetTodoLink merkt sich die Verknüpfung eines Tasks mit einem GitLab-To-Do
+func (s *Store) SetTodoLink(taskID, todoID, projectID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.data.Todos[taskID]; ok && existing.TodoID == todoID && existing.ProjectID == projectID {
+		return
+	}
+
+	s.data.Todos[taskID] = TodoLink{TaskID: taskID, TodoID: todoID, ProjectID: projectID, UpdatedAt: time.Now().UTC()}
+	s.dirty = true
+}
+
+// TodoLinkByTaskID sucht das GitLab-To-Do zu einem Todoist Task
+func (s *Store) TodoLinkByTaskID(taskID string) (TodoLink, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link, ok := s.data.Todos[taskID]
+	return link, ok
+}
+
+// TodoLinks liefert alle To-Do-Verknüpfungen, sortiert nach Task-ID
+func (s *Store) TodoLinks() []TodoLink {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	links := make([]TodoLink, 0, len(s.data.Todos))
+	for _, link := range s.data.Todos {
+		links = append(links, link)
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].TaskID < links[j].TaskID
+	})
+	return links
+}
+
+// RemoveTodoLink vergisst die Verknüpfung eines Tasks mit einem GitLab-To-Do
+func (s *Store) RemoveTodoLink(taskID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.Todos[taskID]; !ok {
+		return
+	}
+	delete(s.data.Todos, taskID)
+	s.dirty = true
+}
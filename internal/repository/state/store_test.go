@@ -0,0 +1,147 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+
+	store := NewStore(path)
+	if err := store.Load(); err != nil {
+		t.Fatalf("Load() on missing file should not fail: %v", err)
+	}
+
+	store.SetLink("task-1", "group/project", "42")
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reloaded := NewStore(path)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	link, ok := reloaded.LinkByTaskID("task-1")
+	if !ok || link.ProjectPath != "group/project" || link.IssueIID != "42" || link.UpdatedAt.IsZero() {
+		t.Fatalf("unexpected link: %+v (found=%t)", link, ok)
+	}
+	if _, ok := reloaded.LinkByTaskID("unknown"); ok {
+		t.Fatal("unexpected link for unknown task")
+	}
+}
+
+func TestStore_SaveOnlyWhenDirty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	store := NewStore(path)
+
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("Save() without changes should not create a file")
+	}
+
+	store.SetLink("task-1", "group/project", "1")
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	info, _ := os.Stat(path)
+
+	// Gleiche Verknüpfung erneut → keine Änderung
+	store.SetLink("task-1", "group/project", "1")
+	if store.dirty {
+		t.Fatal("identical link should not mark the store dirty")
+	}
+	if info == nil {
+		t.Fatal("state file should exist")
+	}
+}
+
+func TestStore_LoadInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte("{invalid"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewStore(path).Load(); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
+
+func TestStore_TodoLinks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	store := NewStore(path)
+
+	store.SetTodoLink("task-2", "gid://gitlab/Todo/2", "inbox")
+	store.SetTodoLink("task-1", "gid://gitlab/Todo/1", "inbox")
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := NewStore(path)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	links := reloaded.TodoLinks()
+	if len(links) != 2 || links[0].TaskID != "task-1" || links[1].TodoID != "gid://gitlab/Todo/2" || links[1].ProjectID != "inbox" {
+		t.Fatalf("unexpected todo links: %+v", links)
+	}
+	if _, ok := reloaded.LinkByTaskID("task-1"); ok {
+		t.Fatal("todo links must not be mistaken for issue links")
+	}
+
+	reloaded.RemoveTodoLink("task-1")
+	if _, ok := reloaded.TodoLinkByTaskID("task-1"); ok || !reloaded.dirty {
+		t.Fatal("removed todo link should be gone and mark the store dirty")
+	}
+}
+
+func TestStore_ReloadIfChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	store := NewStore(path)
+	store.SetLink("task-1", "group/project", "1")
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unveränderte Datei → nichts zu tun
+	if err := store.ReloadIfChanged(); err != nil {
+		t.Fatal(err)
+	}
+
+	other := NewStore(path)
+	if err := other.Load(); err != nil {
+		t.Fatal(err)
+	}
+	other.SetLink("task-2", "group/project", "2")
+	if err := other.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Eigene, ungespeicherte Änderungen werden nicht überschrieben
+	store.SetLink("task-3", "group/project", "3")
+	if err := store.ReloadIfChanged(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.LinkByTaskID("task-3"); !ok {
+		t.Fatal("unsaved link must survive a reload attempt")
+	}
+
+	fresh := NewStore(path)
+	if err := fresh.Load(); err != nil {
+		t.Fatal(err)
+	}
+	other.SetLink("task-4", "group/project", "4")
+	if err := other.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := fresh.ReloadIfChanged(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fresh.LinkByTaskID("task-4"); !ok {
+		t.Fatal("link written by another store should be visible after ReloadIfChanged")
+	}
+}
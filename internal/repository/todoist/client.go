@@ -2,9 +2,11 @@ package todoist
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -16,13 +18,15 @@ type Repository struct {
 	config     *config.Config
 	httpClient *http.Client
 	baseURL    string
+	syncURL    string
 }
 
 func NewRepository(cfg *config.Config) *Repository {
 	return &Repository{
 		config:     cfg,
 		httpClient: &http.Client{Timeout: 30 * time.Second},
-		baseURL:    "https://api.todoist.com/rest/v2",
+		baseURL:    cfg.GetTodoistBaseURL(),
+		syncURL:    cfg.GetTodoistSyncURL(),
 	}
 }
 
@@ -42,12 +46,7 @@ func (r *Repository) GetProjects() ([]todoistDomain.Project, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err = resp.Body.Close()
-		if err != nil {
-			fmt.Printf("fehler beim Abschliessen des Response bodies.")
-		}
-	}()
+	defer closeBody(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
@@ -84,12 +83,7 @@ func (r *Repository) CreateProject(name string) (*todoistDomain.Project, error)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err = resp.Body.Close()
-		if err != nil {
-			fmt.Printf("fehler beim Abschliessen des Response bodies.")
-		}
-	}()
+	defer closeBody(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
@@ -101,6 +95,33 @@ func (r *Repository) CreateProject(name string) (*todoistDomain.Project, error)
 	return &project, err
 }
 
+// GetProjectCollaborators liefert die Mitglieder eines geteilten Projekts (leer bei privaten Projekten)
+func (r *Repository) GetProjectCollaborators(projectID string) ([]todoistDomain.Collaborator, error) {
+	url := fmt.Sprintf("%s/projects/%s/collaborators", r.baseURL, projectID)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+r.config.TodoistToken)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get collaborators failed %d: %s", resp.StatusCode, string(body))
+	}
+
+	var collaborators []todoistDomain.Collaborator
+	err = json.NewDecoder(resp.Body).Decode(&collaborators)
+	return collaborators, err
+}
+
 // Section operations
 
 func (r *Repository) GetProjectSections(projectID string) ([]todoistDomain.Section, error) {
@@ -117,12 +138,7 @@ func (r *Repository) GetProjectSections(projectID string) ([]todoistDomain.Secti
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err = resp.Body.Close()
-		if err != nil {
-			fmt.Printf("fehler beim Abschliessen des Response bodies.")
-		}
-	}()
+	defer closeBody(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
@@ -160,12 +176,7 @@ func (r *Repository) CreateSection(projectID string, name string, order int) (*t
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err = resp.Body.Close()
-		if err != nil {
-			fmt.Printf("fehler beim Abschliessen des Response bodies.")
-		}
-	}()
+	defer closeBody(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
@@ -193,12 +204,7 @@ func (r *Repository) GetProjectTasks(projectID string) ([]todoistDomain.Task, er
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err = resp.Body.Close()
-		if err != nil {
-			fmt.Printf("fehler beim Abschliessen des Response bodies.")
-		}
-	}()
+	defer closeBody(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
@@ -230,12 +236,7 @@ func (r *Repository) CreateTask(taskRequest todoistDomain.CreateTaskRequest) (*t
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err = resp.Body.Close()
-		if err != nil {
-			fmt.Printf("fehler beim Abschliessen des Response bodies.")
-		}
-	}()
+	defer closeBody(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
@@ -267,12 +268,7 @@ func (r *Repository) UpdateTask(taskID string, updates map[string]interface{}) (
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err = resp.Body.Close()
-		if err != nil {
-			fmt.Printf("fehler beim Abschliessen des Response bodies.")
-		}
-	}()
+	defer closeBody(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
@@ -284,6 +280,90 @@ func (r *Repository) UpdateTask(taskID string, updates map[string]interface{}) (
 	return &task, err
 }
 
+// CloseTask schließt (erledigt) einen Task
+func (r *Repository) CloseTask(taskID string) error {
+	url := fmt.Sprintf("%s/tasks/%s/close", r.baseURL, taskID)
+
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+r.config.TodoistToken)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp.Body)
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("close task failed %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// MoveTask verschiebt einen Task unter parentID oder, bei leerer parentID, auf die oberste Ebene
+// des Projekts. Die REST API kann den Parent nicht ändern, daher über die Sync API (item_move).
+func (r *Repository) MoveTask(taskID, parentID, projectID string) error {
+	args := map[string]string{"id": taskID}
+	if parentID != "" {
+		args["parent_id"] = parentID
+	} else {
+		args["project_id"] = projectID
+	}
+
+	uuid := commandUUID()
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"commands": []map[string]interface{}{{"type": "item_move", "uuid": uuid, "args": args}},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", r.syncURL+"/sync", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+r.config.TodoistToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp.Body)
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("move task failed %d: %s", resp.StatusCode, string(body))
+	}
+
+	// Jeder Befehl meldet "ok" oder ein Fehlerobjekt
+	var result struct {
+		SyncStatus map[string]json.RawMessage `json:"sync_status"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return err
+	}
+	if status := string(result.SyncStatus[uuid]); status != `"ok"` {
+		return fmt.Errorf("move task failed: %s", status)
+	}
+	return nil
+}
+
+// commandUUID erzeugt eine zufällige UUID (v4) für Befehle der Sync API
+func commandUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
 // ValidateConnection prüft ob die Todoist-Verbindung funktioniert
 func (r *Repository) ValidateConnection() error {
 	_, err := r.GetProjects()
@@ -309,16 +389,19 @@ func (r *Repository) FindProjectByName(name string) (*todoistDomain.Project, err
 	return nil, nil // Nicht gefunden
 }
 
-// FindSectionByName sucht Section nach Namen in einem Projekt
-func (r *Repository) FindSectionByName(projectID, name string) (*todoistDomain.Section, error) {
+// FindSectionByName sucht Section nach Namen in einem Projekt. Bei mehreren Namen
+// (z.B. Übersetzungen) gewinnt der erste Name, zu dem es eine Section gibt.
+func (r *Repository) FindSectionByName(projectID string, names ...string) (*todoistDomain.Section, error) {
 	sections, err := r.GetProjectSections(projectID)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, section := range sections {
-		if section.Name == name {
-			return &section, nil
+	for _, name := range names {
+		for _, section := range sections {
+			if section.Name == name {
+				return &section, nil
+			}
 		}
 	}
 
@@ -340,3 +423,10 @@ func (r *Repository) FindTaskByTitle(projectID, title string) (*todoistDomain.Ta
 
 	return nil, nil // Nicht gefunden
 }
+
+// closeBody schließt den Response Body und protokolliert Fehler dabei
+func closeBody(body io.Closer) {
+	if err := body.Close(); err != nil {
+		slog.Warn("closing response body failed", "error", err)
+	}
+}
@@ -20,6 +20,7 @@ func newTodoistRepoWithServer(t *testing.T, handler http.HandlerFunc) (*Reposito
 	repo := NewRepository(cfg)
 	// Redirect baseURL to our test server (field is package-private, and we’re in package todoist)
 	repo.baseURL = srv.URL
+	repo.syncURL = srv.URL
 
 	return repo, srv
 }
@@ -112,6 +113,15 @@ func TestTodoist_Sections_CRUD_and_Find(t *testing.T) {
 	if err != nil || sec == nil || sec.ID != "s1" {
 		t.Fatalf("FindSectionByName() got %v err=%v", sec, err)
 	}
+
+	// Alternative Namen: die erste vorhandene Section gewinnt
+	sec, err = repo.FindSectionByName("p1", "Open", "Offen")
+	if err != nil || sec == nil || sec.ID != "s1" {
+		t.Fatalf("FindSectionByName() with translations got %v err=%v", sec, err)
+	}
+	if sec, err = repo.FindSectionByName("p1", "Closed", "Geschlossen"); err != nil || sec != nil {
+		t.Fatalf("FindSectionByName() should not find a section, got %v err=%v", sec, err)
+	}
 }
 
 func TestTodoist_Tasks_CRUD_and_Find(t *testing.T) {
@@ -165,6 +175,21 @@ func TestTodoist_Tasks_CRUD_and_Find(t *testing.T) {
 	}
 }
 
+func TestTodoist_GetProjectCollaborators(t *testing.T) {
+	repo, srv := newTodoistRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/projects/p1/collaborators" {
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[{"id":"u1","name":"Anna","email":"anna@example.com"}]`))
+	})
+	defer srv.Close()
+
+	collaborators, err := repo.GetProjectCollaborators("p1")
+	if err != nil || len(collaborators) != 1 || collaborators[0].ID != "u1" || collaborators[0].Name != "Anna" {
+		t.Fatalf("GetProjectCollaborators() got %v err=%v", collaborators, err)
+	}
+}
+
 func TestTodoist_ValidateConnection_ErrorWrapped(t *testing.T) {
 	repo, srv := newTodoistRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/projects" && r.Method == http.MethodGet {
@@ -181,3 +206,66 @@ func TestTodoist_ValidateConnection_ErrorWrapped(t *testing.T) {
 		t.Fatalf("expected wrapped error, got %v", err)
 	}
 }
+
+func TestTodoist_MoveTask(t *testing.T) {
+	var commands []map[string]interface{}
+	repo, srv := newTodoistRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sync" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Commands []map[string]interface{} `json:"commands"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		commands = append(commands, body.Commands...)
+
+		uuid := body.Commands[0]["uuid"].(string)
+		args := body.Commands[0]["args"].(map[string]interface{})
+		if args["parent_id"] == "bad" {
+			_, _ = w.Write([]byte(`{"sync_status":{"` + uuid + `":{"error":"Invalid argument value"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"sync_status":{"` + uuid + `":"ok"}}`))
+	})
+	defer srv.Close()
+
+	if err := repo.MoveTask("t1", "p1", "proj"); err != nil {
+		t.Fatalf("MoveTask() error = %v", err)
+	}
+	if err := repo.MoveTask("t1", "", "proj"); err != nil {
+		t.Fatalf("MoveTask() to root error = %v", err)
+	}
+	if err := repo.MoveTask("t1", "bad", "proj"); err == nil || !strings.Contains(err.Error(), "Invalid argument") {
+		t.Errorf("expected sync error, got %v", err)
+	}
+
+	if commands[0]["type"] != "item_move" || len(commands[0]["uuid"].(string)) != 36 {
+		t.Errorf("unexpected command: %v", commands[0])
+	}
+	first, second := commands[0]["args"].(map[string]interface{}), commands[1]["args"].(map[string]interface{})
+	if first["parent_id"] != "p1" || first["project_id"] != nil || second["project_id"] != "proj" || second["parent_id"] != nil {
+		t.Errorf("unexpected move arguments: %v %v", first, second)
+	}
+}
+
+func TestTodoist_CloseTask(t *testing.T) {
+	repo, srv := newTodoistRepoWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/tasks/t1/close":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer srv.Close()
+
+	if err := repo.CloseTask("t1"); err != nil {
+		t.Fatalf("CloseTask() error = %v", err)
+	}
+	if err := repo.CloseTask("missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected close error, got %v", err)
+	}
+}
@@ -0,0 +1,429 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+// analyticsCSVColumns sind die Spalten des CSV-Exports (eine Zeile pro Issue)
+var analyticsCSVColumns = []string{"iid", "title", "state", "created_at", "started_at", "closed_at", "lead_time_days", "cycle_time_days"}
+
+// Analytics berechnet Flow-Metriken (Lead Time, Cycle Time, Durchsatz, WIP) und schreibt sie nach stdout
+func (e *Exporter) Analytics() error {
+	return e.writeAnalytics(os.Stdout)
+}
+
+func (e *Exporter) writeAnalytics(w io.Writer) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	format := strings.ToLower(e.config.Format)
+	if format == "" {
+		format = FormatMarkdown
+	}
+	if format != FormatMarkdown && format != FormatJSON && format != FormatCSV {
+		return e.tr.Errorf("err.unknown_format", e.config.Format, FormatCSV+", "+FormatJSON+", "+FormatMarkdown)
+	}
+
+	if err := e.config.Validate(); err != nil {
+		return e.tr.Errorf("err.invalid_config", err)
+	}
+
+	e.progress("progress.loading_issues", e.config.ProjectPath)
+
+	issues, err := e.loadGitLabIssues()
+	if err != nil {
+		return e.tr.Errorf("err.loading_issues", err)
+	}
+	issues = filterIssuesByLabels(issues, splitList(e.config.AnalyticsLabels))
+	e.progress("progress.found_issues", len(issues))
+
+	report := e.flowReport(issues, e.loadLabelEvents(issues))
+
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case FormatCSV:
+		return e.writeAnalyticsCSV(w, report)
+	default:
+		_, err = io.WriteString(w, e.renderAnalyticsMarkdown(report))
+		return err
+	}
+}
+
+// loadLabelEvents lädt die Label-Historie geschlossener Issues für die Cycle Time.
+// Fehlt sie (z.B. ohne Berechtigung), bleibt die Cycle Time des Issues leer.
+func (e *Exporter) loadLabelEvents(issues []todoistDomain.Issue) map[string][]todoistDomain.LabelEvent {
+	closed := filterIssuesByState(issues, "closed")
+	e.progress("progress.loading_label_events", len(closed))
+
+	events := make(map[string][]todoistDomain.LabelEvent, len(closed))
+	for _, issue := range closed {
+		issueEvents, err := e.gitlabRepo.GetLabelEvents(e.config.ProjectPath, issue.IID)
+		if err != nil {
+			slog.Warn("loading label events failed", "iid", issue.IID, "error", err)
+			continue
+		}
+		events[issue.IID] = issueEvents
+	}
+	return events
+}
+
+// flowReport berechnet die Kennzahlen aus Issues und ihrer Label-Historie
+func (e *Exporter) flowReport(issues []todoistDomain.Issue, events map[string][]todoistDomain.LabelEvent) todoistDomain.FlowReport {
+	doing := splitList(e.config.AnalyticsDoingLabels)
+
+	report := todoistDomain.FlowReport{
+		SchemaVersion: todoistDomain.ExportSchemaVersion,
+		Metadata:      e.exportMetadata(issues),
+		Throughput:    []todoistDomain.WeeklyThroughput{},
+		WIP:           []todoistDomain.AssigneeWIP{},
+		Issues:        make([]todoistDomain.FlowIssue, 0, len(issues)),
+	}
+	report.Metadata.Filters.Labels = splitList(e.config.AnalyticsLabels)
+
+	var leadTimes, cycleTimes []float64
+	var closedAt []time.Time
+	wip := make(map[string]*todoistDomain.AssigneeWIP)
+
+	for _, issue := range issues {
+		flow := todoistDomain.FlowIssue{
+			IID:       issue.IID,
+			Title:     issue.Title,
+			State:     issue.State,
+			WebURL:    issue.WebURL,
+			CreatedAt: issue.CreatedAt,
+		}
+
+		if issue.State == "closed" {
+			// Ältere Instanzen liefern kein closedAt, dann gilt die letzte Änderung
+			closed := issue.UpdatedAt
+			if issue.ClosedAt != nil {
+				closed = *issue.ClosedAt
+			}
+			flow.ClosedAt = &closed
+			closedAt = append(closedAt, closed)
+
+			lead := durationDays(closed.Sub(issue.CreatedAt))
+			flow.LeadTimeDays = &lead
+			leadTimes = append(leadTimes, lead)
+
+			if started := firstDoingEvent(events[issue.IID], doing); started != nil && !started.After(closed) {
+				cycle := durationDays(closed.Sub(*started))
+				flow.StartedAt, flow.CycleTimeDays = started, &cycle
+				cycleTimes = append(cycleTimes, cycle)
+			}
+		} else {
+			inProgress := hasAnyLabel(labelTitles(issue), doing)
+			assignees := assigneeNames(issue)
+			if len(assignees) == 0 {
+				assignees = []string{e.tr.T("md.ungrouped")}
+			}
+			for _, name := range assignees {
+				entry, ok := wip[name]
+				if !ok {
+					entry = &todoistDomain.AssigneeWIP{Assignee: name}
+					wip[name] = entry
+				}
+				entry.Open++
+				if inProgress {
+					entry.InProgress++
+				}
+			}
+		}
+
+		report.Issues = append(report.Issues, flow)
+	}
+
+	report.LeadTime = durationStats(leadTimes)
+	report.CycleTime = durationStats(cycleTimes)
+	report.Throughput = weeklyThroughput(closedAt)
+
+	for _, entry := range wip {
+		report.WIP = append(report.WIP, *entry)
+	}
+	sort.Slice(report.WIP, func(i, j int) bool {
+		a, b := report.WIP[i], report.WIP[j]
+		if a.InProgress != b.InProgress {
+			return a.InProgress > b.InProgress
+		}
+		if a.Open != b.Open {
+			return a.Open > b.Open
+		}
+		return a.Assignee < b.Assignee
+	})
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return iidLess(report.Issues[i].IID, report.Issues[j].IID)
+	})
+	return report
+}
+
+// firstDoingEvent liefert den Zeitpunkt, zu dem erstmals ein "in Arbeit"-Label gesetzt wurde
+func firstDoingEvent(events []todoistDomain.LabelEvent, doing []string) *time.Time {
+	var first *time.Time
+	for _, event := range events {
+		if event.Action != "add" || event.Label == nil || !hasAnyLabel([]string{event.Label.Name}, doing) {
+			continue
+		}
+		if first == nil || event.CreatedAt.Before(*first) {
+			created := event.CreatedAt
+			first = &created
+		}
+	}
+	return first
+}
+
+// durationStats berechnet Mittelwert und Perzentile (Nearest-Rank) in Tagen
+func durationStats(days []float64) todoistDomain.DurationStats {
+	stats := todoistDomain.DurationStats{Count: len(days)}
+	if len(days) == 0 {
+		return stats
+	}
+
+	sorted := make([]float64, len(days))
+	copy(sorted, days)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, d := range sorted {
+		sum += d
+	}
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		return sorted[max(rank, 1)-1]
+	}
+
+	stats.MeanDays = roundDays(sum / float64(len(sorted)))
+	stats.P50Days = percentile(50)
+	stats.P85Days = percentile(85)
+	stats.P95Days = percentile(95)
+	return stats
+}
+
+// weeklyThroughput zählt geschlossene Issues je ISO-Woche; Wochen ohne Abschluss erscheinen mit 0
+func weeklyThroughput(closed []time.Time) []todoistDomain.WeeklyThroughput {
+	result := []todoistDomain.WeeklyThroughput{}
+	if len(closed) == 0 {
+		return result
+	}
+
+	counts := make(map[string]int)
+	first, last := weekStart(closed[0]), weekStart(closed[0])
+	for _, t := range closed {
+		counts[isoWeek(t)]++
+		if start := weekStart(t); start.Before(first) {
+			first = start
+		} else if start.After(last) {
+			last = start
+		}
+	}
+
+	for week := first; !week.After(last); week = week.AddDate(0, 0, 7) {
+		result = append(result, todoistDomain.WeeklyThroughput{Week: isoWeek(week), Closed: counts[isoWeek(week)]})
+	}
+	return result
+}
+
+// isoWeek formatiert z.B. "2024-W09"
+func isoWeek(t time.Time) string {
+	year, week := t.Local().ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// weekStart liefert den Montag der Woche (lokale Zeit, 0 Uhr)
+func weekStart(t time.Time) time.Time {
+	t = t.Local()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func durationDays(d time.Duration) float64 {
+	return roundDays(d.Hours() / 24)
+}
+
+// roundDays rundet auf zwei Nachkommastellen, damit JSON und CSV lesbar bleiben
+func roundDays(days float64) float64 {
+	return math.Round(days*100) / 100
+}
+
+// renderAnalyticsMarkdown erzeugt Tabellen für Durchlaufzeiten, Durchsatz und WIP
+func (e *Exporter) renderAnalyticsMarkdown(report todoistDomain.FlowReport) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", e.tr.T("analytics.title", report.Metadata.Project))
+	fmt.Fprintf(&b, "**%s:** %s  \n", e.tr.T("md.export_time"), report.Metadata.ExportedAt.Local().Format(e.tr.T("datetime.layout")))
+	fmt.Fprintf(&b, "**%s:** %d  \n", e.tr.T("md.issue_count"), len(report.Issues))
+	if milestone := report.Metadata.Filters.Milestone; milestone != "" {
+		fmt.Fprintf(&b, "**%s:** %s  \n", e.tr.T("md.milestone"), utils.EscapeMarkdown(milestone))
+	}
+	if labels := report.Metadata.Filters.Labels; len(labels) > 0 {
+		fmt.Fprintf(&b, "**%s:** `%s`  \n", e.tr.T("md.labels"), strings.Join(labels, "`, `"))
+	}
+
+	fmt.Fprintf(&b, "\n## %s\n\n", e.tr.T("analytics.durations"))
+	fmt.Fprintf(&b, "| %s | %s | %s | P50 | P85 | P95 |\n", e.tr.T("analytics.metric"), e.tr.T("analytics.count"), e.tr.T("analytics.mean"))
+	b.WriteString("|---|---:|---:|---:|---:|---:|\n")
+	for _, row := range []struct {
+		key   string
+		stats todoistDomain.DurationStats
+	}{
+		{"analytics.lead_time", report.LeadTime},
+		{"analytics.cycle_time", report.CycleTime},
+	} {
+		if row.stats.Count == 0 {
+			fmt.Fprintf(&b, "| %s | 0 | – | – | – | – |\n", e.tr.T(row.key))
+			continue
+		}
+		fmt.Fprintf(&b, "| %s | %d | %s | %s | %s | %s |\n", e.tr.T(row.key), row.stats.Count,
+			e.formatDecimal(row.stats.MeanDays, 1), e.formatDecimal(row.stats.P50Days, 1),
+			e.formatDecimal(row.stats.P85Days, 1), e.formatDecimal(row.stats.P95Days, 1))
+	}
+
+	fmt.Fprintf(&b, "\n## %s\n\n", e.tr.T("analytics.throughput"))
+	if len(report.Throughput) == 0 {
+		fmt.Fprintf(&b, "%s\n", e.tr.T("analytics.none"))
+	} else {
+		fmt.Fprintf(&b, "| %s | %s |\n|---|---:|\n", e.tr.T("analytics.week"), e.tr.T("analytics.closed"))
+		for _, week := range report.Throughput {
+			fmt.Fprintf(&b, "| %s | %d |\n", week.Week, week.Closed)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## %s\n\n", e.tr.T("analytics.wip"))
+	if len(report.WIP) == 0 {
+		fmt.Fprintf(&b, "%s\n", e.tr.T("analytics.none"))
+	} else {
+		fmt.Fprintf(&b, "| %s | %s | %s |\n|---|---:|---:|\n", e.tr.T("analytics.assignee"), e.tr.T("analytics.in_progress"), e.tr.T("analytics.open"))
+		for _, entry := range report.WIP {
+			fmt.Fprintf(&b, "| %s | %d | %d |\n", utils.EscapeMarkdown(entry.Assignee), entry.InProgress, entry.Open)
+		}
+	}
+
+	return b.String()
+}
+
+// writeAnalyticsCSV schreibt eine Zeile pro Issue, z.B. für eigene Auswertungen in Excel
+func (e *Exporter) writeAnalyticsCSV(w io.Writer, report todoistDomain.FlowReport) error {
+	separator, err := e.csvSeparator()
+	if err != nil {
+		return err
+	}
+
+	if e.config.CSVBOM {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return err
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = separator
+	writer.UseCRLF = true
+
+	if err := writer.Write(analyticsCSVColumns); err != nil {
+		return err
+	}
+
+	optionalTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return e.csvDateTime(t.Local())
+	}
+	optionalDays := func(days *float64) string {
+		if days == nil {
+			return ""
+		}
+		return e.formatDecimal(*days, 2)
+	}
+
+	for _, issue := range report.Issues {
+		record := []string{
+			issue.IID,
+			csvCell(issue.Title),
+			issue.State,
+			e.csvDateTime(issue.CreatedAt.Local()),
+			optionalTime(issue.StartedAt),
+			optionalTime(issue.ClosedAt),
+			optionalDays(issue.LeadTimeDays),
+			optionalDays(issue.CycleTimeDays),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatDecimal formatiert Zahlen (Tage, Stunden) mit dem Dezimaltrennzeichen der Sprache
+func (e *Exporter) formatDecimal(value float64, precision int) string {
+	formatted := strconv.FormatFloat(value, 'f', precision, 64)
+	if e.tr.Lang() == i18n.DE {
+		formatted = strings.Replace(formatted, ".", ",", 1)
+	}
+	return formatted
+}
+
+// filterIssuesByLabels behält Issues, die alle angegebenen Labels tragen (wie der Label-Filter in GitLab)
+func filterIssuesByLabels(issues []todoistDomain.Issue, labels []string) []todoistDomain.Issue {
+	if len(labels) == 0 {
+		return issues
+	}
+
+	var filtered []todoistDomain.Issue
+	for _, issue := range issues {
+		titles := labelTitles(issue)
+		matches := true
+		for _, want := range labels {
+			if !hasAnyLabel(titles, []string{want}) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// hasAnyLabel prüft, ob eines der Labels zu einem der gesuchten passt (siehe labelMatches)
+func hasAnyLabel(labels []string, wanted []string) bool {
+	for _, label := range labels {
+		for _, want := range wanted {
+			if labelMatches(label, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitList zerlegt eine kommagetrennte Liste und entfernt leere Einträge
+func splitList(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+func analyticsTestIssues() []todoistDomain.Issue {
+	day := func(d int) time.Time { return time.Date(2024, 2, d, 12, 0, 0, 0, time.UTC) }
+	closed1, closed2 := day(28), day(28).AddDate(0, 0, 14)
+	return []todoistDomain.Issue{
+		{IID: "1", Title: "Login", State: "closed", CreatedAt: day(18), ClosedAt: &closed1,
+			Labels: todoistDomain.Labels{Nodes: []todoistDomain.Label{{Title: "bug"}}}},
+		{IID: "2", Title: "Export", State: "closed", CreatedAt: day(22), ClosedAt: &closed2},
+		{IID: "3", Title: "Dark mode", State: "opened", CreatedAt: day(1),
+			Labels:    todoistDomain.Labels{Nodes: []todoistDomain.Label{{Title: "workflow::Doing"}}},
+			Assignees: todoistDomain.Assignees{Nodes: []todoistDomain.Assignee{{Name: "Anna"}}}},
+		{IID: "4", Title: "Docs", State: "opened", CreatedAt: day(2),
+			Assignees: todoistDomain.Assignees{Nodes: []todoistDomain.Assignee{{Name: "Anna"}}}},
+		{IID: "5", Title: "Idea", State: "opened", CreatedAt: day(3)},
+	}
+}
+
+func analyticsTestEvents() map[string][]todoistDomain.LabelEvent {
+	label := func(name string) *todoistDomain.EventLabel { return &todoistDomain.EventLabel{Name: name} }
+	return map[string][]todoistDomain.LabelEvent{
+		"1": {
+			{Action: "add", CreatedAt: time.Date(2024, 2, 20, 12, 0, 0, 0, time.UTC), Label: label("bug")},
+			{Action: "add", CreatedAt: time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC), Label: label("workflow::doing")},
+			{Action: "remove", CreatedAt: time.Date(2024, 2, 27, 0, 0, 0, 0, time.UTC), Label: label("workflow::doing")},
+		},
+	}
+}
+
+func TestFlowReport(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", AnalyticsDoingLabels: config.DefaultAnalyticsDoingLabels})
+	report := exporter.flowReport(analyticsTestIssues(), analyticsTestEvents())
+
+	if report.LeadTime.Count != 2 || report.LeadTime.MeanDays != 15 || report.LeadTime.P50Days != 10 || report.LeadTime.P95Days != 20 {
+		t.Errorf("unexpected lead time: %+v", report.LeadTime)
+	}
+	if report.CycleTime.Count != 1 || report.CycleTime.P85Days != 2.5 {
+		t.Errorf("unexpected cycle time: %+v", report.CycleTime)
+	}
+
+	throughput, _ := json.Marshal(report.Throughput)
+	if string(throughput) != `[{"week":"2024-W09","closed":1},{"week":"2024-W10","closed":0},{"week":"2024-W11","closed":1}]` {
+		t.Errorf("unexpected throughput: %s", throughput)
+	}
+
+	wip, _ := json.Marshal(report.WIP)
+	if string(wip) != `[{"assignee":"Anna","in_progress":1,"open":2},{"assignee":"Ohne Zuordnung","in_progress":0,"open":1}]` {
+		t.Errorf("unexpected WIP: %s", wip)
+	}
+
+	if issue := report.Issues[0]; issue.StartedAt == nil || issue.CycleTimeDays == nil || *issue.CycleTimeDays != 2.5 {
+		t.Errorf("issue #1 should have a cycle time: %+v", issue)
+	}
+	if issue := report.Issues[1]; issue.StartedAt != nil || issue.LeadTimeDays == nil || *issue.LeadTimeDays != 20 {
+		t.Errorf("issue #2 should only have a lead time: %+v", issue)
+	}
+}
+
+func TestDurationStats_NearestRank(t *testing.T) {
+	stats := durationStats([]float64{5, 1, 4, 2, 3, 10, 6, 7, 8, 9})
+	if stats.Count != 10 || stats.MeanDays != 5.5 || stats.P50Days != 5 || stats.P85Days != 9 || stats.P95Days != 10 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if empty := durationStats(nil); empty != (todoistDomain.DurationStats{}) {
+		t.Errorf("empty input should give zero stats: %+v", empty)
+	}
+}
+
+func TestRenderAnalyticsMarkdown(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", AnalyticsDoingLabels: config.DefaultAnalyticsDoingLabels})
+	got := exporter.renderAnalyticsMarkdown(exporter.flowReport(analyticsTestIssues(), analyticsTestEvents()))
+
+	for _, want := range []string{
+		"# Flow-Metriken – g/p\n",
+		"| Lead Time (erstellt → geschlossen) | 2 | 15,0 | 10,0 | 20,0 | 20,0 |\n",
+		"| Cycle Time (in Arbeit → geschlossen) | 1 | 2,5 | 2,5 | 2,5 | 2,5 |\n",
+		"| 2024-W10 | 0 |\n",
+		"| Anna | 1 | 2 |\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown should contain %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestAnalytics_CSVWithLabelFilter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v4/user":
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		case strings.HasSuffix(r.URL.Path, "/resource_label_events"):
+			if !strings.Contains(r.URL.Path, "/issues/1/") {
+				t.Errorf("label events should only be loaded for matching closed issues: %s", r.URL.Path)
+			}
+			_, _ = w.Write([]byte(`[{"action":"add","created_at":"2024-03-02T00:00:00Z","label":{"name":"doing"}}]`))
+		default:
+			_, _ = w.Write([]byte(`{"data":{"project":{"issues":{"nodes":[` +
+				`{"iid":"1","title":"=Crash","state":"closed","created_at":"2024-03-01T00:00:00Z","closed_at":"2024-03-04T12:00:00Z","labels":{"nodes":[{"title":"type::bug"}]}},` +
+				`{"iid":"2","title":"Feature","state":"closed","created_at":"2024-03-01T00:00:00Z","closed_at":"2024-03-05T00:00:00Z","labels":{"nodes":[]}}]}}}}`))
+		}
+	}))
+	defer srv.Close()
+
+	exporter := NewExporter(&config.Config{
+		GitLabToken: "t", GitLabURL: srv.URL, ProjectPath: "g/p", Lang: "en", Format: FormatCSV,
+		AnalyticsLabels: "bug", AnalyticsDoingLabels: config.DefaultAnalyticsDoingLabels,
+	})
+
+	var buf bytes.Buffer
+	if err := exporter.writeAnalytics(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\r\n")
+	if len(lines) != 2 || lines[0] != "iid,title,state,created_at,started_at,closed_at,lead_time_days,cycle_time_days" {
+		t.Fatalf("unexpected CSV:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(lines[1], "1,'=Crash,closed,") || !strings.HasSuffix(lines[1], ",3.50,2.50") {
+		t.Errorf("unexpected row: %s", lines[1])
+	}
+}
+
+func TestAnalytics_RejectsUnsupportedFormat(t *testing.T) {
+	exporter := NewExporter(&config.Config{GitLabToken: "t", ProjectPath: "g/p", Format: FormatHTML})
+	if err := exporter.writeAnalytics(&bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "html") {
+		t.Errorf("expected unknown format error, got %v", err)
+	}
+}
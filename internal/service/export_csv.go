@@ -0,0 +1,180 @@
+package service
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
+)
+
+// DefaultCSVColumns sind die Spalten des CSV-Exports, wenn keine konfiguriert sind
+const DefaultCSVColumns = "iid,title,state,due_date,assignees,labels,milestone,web_url"
+
+// utf8BOM lässt Excel die Datei als UTF-8 erkennen
+const utf8BOM = "\ufeff"
+
+// csvColumn liefert den Wert einer Spalte für ein Issue
+type csvColumn func(e *Exporter, issue todoistDomain.Issue) string
+
+// csvColumns enthält alle verfügbaren Spalten: Felder des Issues und Ausgaben des Mappers
+var csvColumns = map[string]csvColumn{
+	"iid":         func(e *Exporter, issue todoistDomain.Issue) string { return issue.IID },
+	"title":       func(e *Exporter, issue todoistDomain.Issue) string { return issue.Title },
+	"description": func(e *Exporter, issue todoistDomain.Issue) string { return issue.Description },
+	"state":       func(e *Exporter, issue todoistDomain.Issue) string { return issue.State },
+	"web_url":     func(e *Exporter, issue todoistDomain.Issue) string { return issue.WebURL },
+	"due_date": func(e *Exporter, issue todoistDomain.Issue) string {
+		return e.csvDate(normalizeIssue(issue).DueDate)
+	},
+	"created_at": func(e *Exporter, issue todoistDomain.Issue) string { return e.csvDateTime(issue.CreatedAt) },
+	"updated_at": func(e *Exporter, issue todoistDomain.Issue) string { return e.csvDateTime(issue.UpdatedAt) },
+	"labels":     func(e *Exporter, issue todoistDomain.Issue) string { return e.csvList(labelTitles(issue)) },
+	"assignees":  func(e *Exporter, issue todoistDomain.Issue) string { return e.csvList(assigneeNames(issue)) },
+	"milestone": func(e *Exporter, issue todoistDomain.Issue) string {
+		return normalizeIssue(issue).Milestone
+	},
+	"todoist_content": func(e *Exporter, issue todoistDomain.Issue) string {
+		return e.mapper.TodoistMapping(issue).Content
+	},
+	"todoist_priority": func(e *Exporter, issue todoistDomain.Issue) string {
+		return strconv.Itoa(e.mapper.TodoistMapping(issue).Priority)
+	},
+	"todoist_labels": func(e *Exporter, issue todoistDomain.Issue) string {
+		return e.csvList(e.mapper.TodoistMapping(issue).Labels)
+	},
+	"todoist_section": func(e *Exporter, issue todoistDomain.Issue) string {
+		return e.mapper.TodoistMapping(issue).Section
+	},
+}
+
+// writeCSV schreibt die Issues als CSV nach RFC 4180
+func (e *Exporter) writeCSV(w io.Writer, issues []todoistDomain.Issue) error {
+	columns, err := e.parseCSVColumns(e.config.CSVColumns)
+	if err != nil {
+		return err
+	}
+
+	separator, err := e.csvSeparator()
+	if err != nil {
+		return err
+	}
+
+	if e.config.CSVBOM {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return err
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = separator
+	writer.UseCRLF = true
+
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+
+	record := make([]string, len(columns))
+	for _, issue := range issues {
+		for i, column := range columns {
+			record[i] = csvCell(csvColumns[column](e, issue))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// parseCSVColumns prüft die konfigurierten Spalten, z.B. "iid,title,labels"
+func (e *Exporter) parseCSVColumns(spec string) ([]string, error) {
+	if strings.TrimSpace(spec) == "" {
+		spec = DefaultCSVColumns
+	}
+
+	var columns []string
+	for _, column := range strings.Split(spec, ",") {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column == "" {
+			continue
+		}
+		if _, ok := csvColumns[column]; !ok {
+			return nil, e.tr.Errorf("err.csv_column", column, strings.Join(csvColumnNames(), ", "))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func csvColumnNames() []string {
+	names := make([]string, 0, len(csvColumns))
+	for name := range csvColumns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// csvSeparator liefert das Trennzeichen: konfiguriert oder passend zur Sprache (";" für deutsches Excel)
+func (e *Exporter) csvSeparator() (rune, error) {
+	separator := e.config.CSVSeparator
+	if separator == "" {
+		if e.tr.Lang() == i18n.DE {
+			return ';', nil
+		}
+		return ',', nil
+	}
+
+	if separator == `\t` {
+		return '\t', nil
+	}
+	if utf8.RuneCountInString(separator) != 1 {
+		return 0, e.tr.Errorf("err.csv_separator", separator)
+	}
+	r, _ := utf8.DecodeRuneInString(separator)
+	if r == '"' || r == '\r' || r == '\n' {
+		return 0, e.tr.Errorf("err.csv_separator", separator)
+	}
+	return r, nil
+}
+
+// csvCell entschärft Zellen, die Excel oder LibreOffice als Formel auswerten würden
+// (CSV-Injection), indem ein ' vorangestellt wird
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// csvList verbindet mehrwertige Felder mit dem konfigurierten Trennzeichen
+func (e *Exporter) csvList(values []string) string {
+	delimiter := e.config.CSVListDelimiter
+	if delimiter == "" {
+		delimiter = ", "
+	}
+	return strings.Join(values, delimiter)
+}
+
+// csvDate formatiert ein Datum (YYYY-MM-DD) im Format der Sprache; leere Daten bleiben leer
+func (e *Exporter) csvDate(date string) string {
+	if date == "" {
+		return ""
+	}
+	return e.tr.FormatDate(date)
+}
+
+// csvDateTime formatiert einen Zeitpunkt im Format der Sprache
+func (e *Exporter) csvDateTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(e.tr.T("datetime.layout"))
+}
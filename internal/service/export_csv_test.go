@@ -0,0 +1,110 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+func TestWriteCSV_GermanLocaleUsesSemicolonAndGermanDates(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", CSVBOM: true})
+
+	var buf bytes.Buffer
+	if err := exporter.writeCSV(&buf, templateTestIssues()); err != nil {
+		t.Fatal(err)
+	}
+
+	content := buf.String()
+	if !strings.HasPrefix(content, utf8BOM) {
+		t.Fatal("expected UTF-8 BOM")
+	}
+
+	expected := "iid;title;state;due_date;assignees;labels;milestone;web_url\r\n" +
+		"1;Fix *bold*;opened;15.02.2024;A, B;bug, high;v1;u1\r\n" +
+		"2;Done;closed;;;bug;;u2\r\n"
+	if got := strings.TrimPrefix(content, utf8BOM); got != expected {
+		t.Errorf("unexpected CSV:\n%q\nwant:\n%q", got, expected)
+	}
+}
+
+func TestWriteCSV_CustomColumnsAndQuoting(t *testing.T) {
+	exporter := NewExporter(&config.Config{
+		ProjectPath:      "g/p",
+		Lang:             "en",
+		CSVColumns:       "iid, description, labels, todoist_priority, todoist_section, due_date",
+		CSVListDelimiter: "|",
+	})
+
+	var buf bytes.Buffer
+	if err := exporter.writeCSV(&buf, templateTestIssues()); err != nil {
+		t.Fatal(err)
+	}
+	if strings.HasPrefix(buf.String(), utf8BOM) {
+		t.Error("BOM should only be written on request")
+	}
+
+	// Mehrzeilige Felder werden nach RFC 4180 gequotet und lassen sich zurücklesen
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d", len(records))
+	}
+
+	want := []string{"1", "Line1\nLine2", "bug|high", "3", "Open", "2024-02-15"}
+	if strings.Join(records[1], "\x00") != strings.Join(want, "\x00") {
+		t.Errorf("unexpected row: %q", records[1])
+	}
+}
+
+func TestWriteCSV_EscapesFormulas(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: "en", CSVColumns: "iid,title,labels"})
+
+	issues := templateTestIssues()[:1]
+	issues[0].Title = `=HYPERLINK("http://evil.example","click")`
+	issues[0].Labels.Nodes = []todoistDomain.Label{{Title: "@team"}}
+
+	var buf bytes.Buffer
+	if err := exporter.writeCSV(&buf, issues); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if records[1][1] != `'=HYPERLINK("http://evil.example","click")` || records[1][2] != "'@team" {
+		t.Errorf("formula cells should be prefixed with ': %q", records[1])
+	}
+
+	for in, want := range map[string]string{"-1": "'-1", "+x": "'+x", "\tA": "'\tA", "a=b": "a=b", "": ""} {
+		if got := csvCell(in); got != want {
+			t.Errorf("csvCell(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWriteCSV_InvalidConfiguration(t *testing.T) {
+	exporter := NewExporter(&config.Config{CSVColumns: "iid,unknown"})
+	if err := exporter.writeCSV(&bytes.Buffer{}, templateTestIssues()); err == nil || !strings.Contains(err.Error(), "unknown") {
+		t.Errorf("expected unknown column error, got %v", err)
+	}
+
+	exporter = NewExporter(&config.Config{CSVSeparator: ";;"})
+	if err := exporter.writeCSV(&bytes.Buffer{}, templateTestIssues()); err == nil {
+		t.Error("expected error for multi-character separator")
+	}
+
+	exporter = NewExporter(&config.Config{CSVSeparator: `\t`, CSVColumns: "iid,state"})
+	var buf bytes.Buffer
+	if err := exporter.writeCSV(&buf, templateTestIssues()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "iid\tstate\r\n") {
+		t.Errorf("expected tab separated output, got %q", buf.String())
+	}
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+// Zustände der Knoten im Abhängigkeitsgraphen
+const (
+	graphOpen    = "open"
+	graphBlocked = "blocked"
+	graphClosed  = "closed"
+)
+
+// graphColors enthält Füll- und Randfarbe je Zustand
+var graphColors = map[string][2]string{
+	graphOpen:    {"#d4edda", "#28a745"},
+	graphBlocked: {"#f8d7da", "#dc3545"},
+	graphClosed:  {"#e2e3e5", "#6c757d"},
+}
+
+// graphNode ist ein Issue im Abhängigkeitsgraphen
+type graphNode struct {
+	// key ist die vollständige Referenz ("gruppe/projekt#12")
+	key   string
+	label string
+	title string
+	url   string
+	state string
+	// external markiert verknüpfte Issues, die nicht Teil des Exports sind
+	external bool
+}
+
+// graphEdge ist eine Verknüpfung; bei blocks zeigt sie vom blockierenden zum blockierten Issue
+type graphEdge struct {
+	from   string
+	to     string
+	blocks bool
+}
+
+// issueGraph ist der Abhängigkeitsgraph der exportierten Issues
+type issueGraph struct {
+	nodes []graphNode
+	edges []graphEdge
+}
+
+// buildIssueGraph erstellt den Graphen aus den Issues (nach IID sortiert) und ihren Verknüpfungen.
+// Verknüpfte Issues außerhalb des Exports werden als externe Knoten ergänzt.
+func (e *Exporter) buildIssueGraph(issues []todoistDomain.Issue) issueGraph {
+	sorted := make([]todoistDomain.Issue, len(issues))
+	copy(sorted, issues)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return iidLess(sorted[i].IID, sorted[j].IID)
+	})
+
+	var graph issueGraph
+	index := make(map[string]int)
+	addNode := func(node graphNode) {
+		if _, ok := index[node.key]; !ok {
+			index[node.key] = len(graph.nodes)
+			graph.nodes = append(graph.nodes, node)
+		}
+	}
+
+	for _, issue := range sorted {
+		state := graphOpen
+		switch {
+		case issue.State == "closed":
+			state = graphClosed
+		case len(blockedBy(issue)) > 0:
+			state = graphBlocked
+		}
+		addNode(graphNode{
+			key:   e.config.ProjectPath + "#" + issue.IID,
+			label: "#" + issue.IID,
+			title: issue.Title,
+			url:   issue.WebURL,
+			state: state,
+		})
+	}
+
+	seen := make(map[graphEdge]bool)
+	for _, issue := range sorted {
+		self := e.config.ProjectPath + "#" + issue.IID
+		for _, link := range issue.Links {
+			other := link.Reference
+			if other == "" {
+				other = e.config.ProjectPath + "#" + link.IID
+			}
+			state := graphOpen
+			if link.State == "closed" {
+				state = graphClosed
+			}
+			addNode(graphNode{
+				key:      other,
+				label:    linkReference(e.config.ProjectPath, link),
+				title:    link.Title,
+				url:      link.WebURL,
+				state:    state,
+				external: true,
+			})
+
+			var edge graphEdge
+			switch link.LinkType {
+			case todoistDomain.LinkBlocks:
+				edge = graphEdge{from: self, to: other, blocks: true}
+			case todoistDomain.LinkIsBlockedBy:
+				edge = graphEdge{from: other, to: self, blocks: true}
+			default:
+				// "relates to" ist ungerichtet und wird von beiden Seiten geliefert
+				edge = graphEdge{from: self, to: other}
+				if index[other] < index[self] {
+					edge.from, edge.to = other, self
+				}
+			}
+			if !seen[edge] {
+				seen[edge] = true
+				graph.edges = append(graph.edges, edge)
+			}
+		}
+	}
+
+	sort.SliceStable(graph.edges, func(i, j int) bool {
+		a, b := graph.edges[i], graph.edges[j]
+		if index[a.from] != index[b.from] {
+			return index[a.from] < index[b.from]
+		}
+		return index[a.to] < index[b.to]
+	})
+	return graph
+}
+
+// writeDOT schreibt den Abhängigkeitsgraphen im Graphviz-Format (dot -Tsvg)
+func (e *Exporter) writeDOT(w io.Writer, issues []todoistDomain.Issue) error {
+	graph := e.buildIssueGraph(issues)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(e.config.ProjectPath))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	for _, node := range graph.nodes {
+		attributes := []string{
+			"label=" + dotQuote(node.label+"\n"+node.title),
+			"fillcolor=" + dotQuote(graphColors[node.state][0]),
+			"color=" + dotQuote(graphColors[node.state][1]),
+		}
+		if node.external {
+			attributes = append(attributes, `style="rounded,filled,dashed"`)
+		}
+		if node.url != "" {
+			attributes = append(attributes, "URL="+dotQuote(node.url))
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", dotQuote(node.key), strings.Join(attributes, ", "))
+	}
+
+	for _, edge := range graph.edges {
+		attributes := "dir=none, style=dashed"
+		if edge.blocks {
+			attributes = "label=" + dotQuote(e.tr.T("graph.blocks"))
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(edge.from), dotQuote(edge.to), attributes)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote setzt einen Wert als DOT-String in Anführungszeichen
+func dotQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+// writeMermaid schreibt den Abhängigkeitsgraphen als Mermaid-Flowchart ("graph LR")
+func (e *Exporter) writeMermaid(w io.Writer, issues []todoistDomain.Issue) error {
+	graph := e.buildIssueGraph(issues)
+
+	ids := make(map[string]string, len(graph.nodes))
+	byState := make(map[string][]string)
+	var external []string
+
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for i, node := range graph.nodes {
+		id := fmt.Sprintf("i%d", i+1)
+		ids[node.key] = id
+		byState[node.state] = append(byState[node.state], id)
+		if node.external {
+			external = append(external, id)
+		}
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", id, mermaidText(node.label+" "+node.title))
+	}
+
+	for _, edge := range graph.edges {
+		if edge.blocks {
+			fmt.Fprintf(&b, "  %s -->|%s| %s\n", ids[edge.from], mermaidText(e.tr.T("graph.blocks")), ids[edge.to])
+		} else {
+			fmt.Fprintf(&b, "  %s -.- %s\n", ids[edge.from], ids[edge.to])
+		}
+	}
+
+	for _, state := range []string{graphOpen, graphBlocked, graphClosed} {
+		fmt.Fprintf(&b, "  classDef %s fill:%s,stroke:%s\n", state, graphColors[state][0], graphColors[state][1])
+	}
+	b.WriteString("  classDef external stroke-dasharray:5 5\n")
+	for _, state := range []string{graphOpen, graphBlocked, graphClosed} {
+		if len(byState[state]) > 0 {
+			fmt.Fprintf(&b, "  class %s %s\n", strings.Join(byState[state], ","), state)
+		}
+	}
+	if len(external) > 0 {
+		fmt.Fprintf(&b, "  class %s external\n", strings.Join(external, ","))
+	}
+
+	for _, node := range graph.nodes {
+		if node.url != "" {
+			fmt.Fprintf(&b, "  click %s %q\n", ids[node.key], node.url)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
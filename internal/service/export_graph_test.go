@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	domain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+func graphTestIssues() []domain.Issue {
+	return []domain.Issue{
+		{IID: "2", Title: `Login "SSO"`, State: "opened", WebURL: "u2", Links: []domain.IssueLink{
+			{IID: "1", Title: "API", State: "opened", WebURL: "u1", Reference: "g/p#1", LinkType: domain.LinkIsBlockedBy},
+			{IID: "5", Title: "Specs", State: "closed", WebURL: "o5", Reference: "other/api#5", LinkType: domain.LinkRelatesTo},
+		}},
+		{IID: "1", Title: "API", State: "opened", WebURL: "u1", Links: []domain.IssueLink{
+			{IID: "2", Title: `Login "SSO"`, State: "opened", WebURL: "u2", Reference: "g/p#2", LinkType: domain.LinkBlocks},
+			{IID: "3", Title: "Docs", State: "closed", WebURL: "u3", Reference: "g/p#3", LinkType: domain.LinkRelatesTo},
+		}},
+		{IID: "3", Title: "Docs", State: "closed", WebURL: "u3", Links: []domain.IssueLink{
+			{IID: "1", Title: "API", State: "opened", WebURL: "u1", Reference: "g/p#1", LinkType: domain.LinkRelatesTo},
+		}},
+	}
+}
+
+func TestBuildIssueGraph_DeduplicatesAndAddsExternalNodes(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p"})
+	graph := exporter.buildIssueGraph(graphTestIssues())
+
+	var nodes []string
+	for _, node := range graph.nodes {
+		nodes = append(nodes, node.label+":"+node.state)
+	}
+	if got := strings.Join(nodes, " "); got != "#1:open #2:blocked #3:closed other/api#5:closed" {
+		t.Errorf("unexpected nodes: %s", got)
+	}
+	if !graph.nodes[3].external || graph.nodes[0].external {
+		t.Errorf("only issues outside the export should be external: %+v", graph.nodes)
+	}
+
+	want := []graphEdge{
+		{from: "g/p#1", to: "g/p#2", blocks: true},
+		{from: "g/p#1", to: "g/p#3"},
+		{from: "g/p#2", to: "other/api#5"},
+	}
+	if len(graph.edges) != len(want) {
+		t.Fatalf("expected %d edges, got %+v", len(want), graph.edges)
+	}
+	for i := range want {
+		if graph.edges[i] != want[i] {
+			t.Errorf("edge %d = %+v, want %+v", i, graph.edges[i], want[i])
+		}
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: "en"})
+
+	var buf bytes.Buffer
+	if err := exporter.writeDOT(&buf, graphTestIssues()); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+
+	for _, want := range []string{
+		"digraph \"g/p\" {\n  rankdir=LR;\n",
+		`  "g/p#2" [label="#2\nLogin \"SSO\"", fillcolor="#f8d7da", color="#dc3545", URL="u2"];`,
+		`  "other/api#5" [label="other/api#5\nSpecs", fillcolor="#e2e3e5", color="#6c757d", style="rounded,filled,dashed", URL="o5"];`,
+		`  "g/p#1" -> "g/p#2" [label="blocks"];`,
+		`  "g/p#1" -> "g/p#3" [dir=none, style=dashed];`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("DOT should contain %s:\n%s", want, got)
+		}
+	}
+	if !strings.HasSuffix(got, "}\n") {
+		t.Errorf("DOT should be closed:\n%s", got)
+	}
+}
+
+func TestWriteMermaid(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p"})
+
+	var buf bytes.Buffer
+	if err := exporter.writeMermaid(&buf, graphTestIssues()); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+
+	for _, want := range []string{
+		"graph LR\n  i1[\"#1 API\"]\n  i2[\"#2 Login 'SSO'\"]\n",
+		"  i1 -->|blockiert| i2\n  i1 -.- i3\n  i2 -.- i4\n",
+		"  classDef blocked fill:#f8d7da,stroke:#dc3545\n",
+		"  class i1 open\n  class i2 blocked\n  class i3,i4 closed\n  class i4 external\n",
+		"  click i4 \"o5\"\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Mermaid should contain %q:\n%s", want, got)
+		}
+	}
+}
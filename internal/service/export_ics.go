@@ -0,0 +1,192 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+// Kalender-Komponenten des iCalendar-Exports (--ics-component)
+const (
+	ICSComponentTodo  = "vtodo"
+	ICSComponentEvent = "vevent"
+)
+
+// icsLineLimit ist die maximale Zeilenlänge in Oktetten nach RFC 5545, Abschnitt 3.1
+const icsLineLimit = 75
+
+const icsDateLayout = "20060102"
+const icsDateTimeLayout = "20060102T150405Z"
+
+// writeICS schreibt alle Issues mit Fälligkeitsdatum als iCalendar-Datei (RFC 5545)
+func (e *Exporter) writeICS(w io.Writer, issues []todoistDomain.Issue) error {
+	component, err := e.icsComponent()
+	if err != nil {
+		return err
+	}
+
+	ics := &icsWriter{w: w}
+	ics.line("BEGIN:VCALENDAR")
+	ics.line("VERSION:2.0")
+	ics.line("PRODID:-//hufschlaeger.net//GitLab Tasks Exporter//" + strings.ToUpper(e.tr.Lang()))
+	ics.line("CALSCALE:GREGORIAN")
+	ics.line("METHOD:PUBLISH")
+	ics.property("X-WR-CALNAME", icsEscape("GitLab Issues - "+e.config.ProjectPath))
+
+	now := time.Now().UTC()
+	for _, issue := range issues {
+		due, ok := icsDueDate(issue)
+		if !ok {
+			continue
+		}
+		e.writeICSComponent(ics, component, issue, due, now)
+	}
+
+	ics.line("END:VCALENDAR")
+	return ics.err
+}
+
+func (e *Exporter) writeICSComponent(ics *icsWriter, component string, issue todoistDomain.Issue, due time.Time, now time.Time) {
+	name := strings.ToUpper(component)
+	closed := issue.State == "closed"
+
+	// DTSTAMP folgt der letzten Änderung, damit wiederholte Exporte identisch bleiben
+	stamp := issue.UpdatedAt
+	if stamp.IsZero() {
+		stamp = now
+	}
+
+	ics.line("BEGIN:" + name)
+	ics.property("UID", e.icsUID(issue))
+	ics.property("DTSTAMP", stamp.UTC().Format(icsDateTimeLayout))
+	ics.property("SUMMARY", icsEscape(fmt.Sprintf("#%s - %s", issue.IID, issue.Title)))
+	if issue.WebURL != "" {
+		ics.property("URL", issue.WebURL)
+	}
+	if issue.Description != "" {
+		ics.property("DESCRIPTION", icsEscape(issue.Description))
+	}
+	if labels := labelTitles(issue); len(labels) > 0 {
+		escaped := make([]string, len(labels))
+		for i, label := range labels {
+			escaped[i] = icsEscape(label)
+		}
+		ics.property("CATEGORIES", strings.Join(escaped, ","))
+	}
+
+	if component == ICSComponentEvent {
+		// Ganztägiger Termin am Fälligkeitstag; DTEND ist exklusiv
+		ics.property("DTSTART;VALUE=DATE", due.Format(icsDateLayout))
+		ics.property("DTEND;VALUE=DATE", due.AddDate(0, 0, 1).Format(icsDateLayout))
+		ics.property("TRANSP", "TRANSPARENT")
+		if closed {
+			ics.property("STATUS", "CANCELLED")
+		} else {
+			ics.property("STATUS", "CONFIRMED")
+		}
+	} else {
+		ics.property("DUE;VALUE=DATE", due.Format(icsDateLayout))
+		if closed {
+			ics.property("STATUS", "COMPLETED")
+		} else {
+			ics.property("STATUS", "NEEDS-ACTION")
+		}
+	}
+
+	ics.line("END:" + name)
+}
+
+// icsComponent liefert die konfigurierte Komponente (Standard: VTODO)
+func (e *Exporter) icsComponent() (string, error) {
+	component := strings.ToLower(e.config.ICSComponent)
+	switch component {
+	case "":
+		return ICSComponentTodo, nil
+	case ICSComponentTodo, ICSComponentEvent:
+		return component, nil
+	default:
+		return "", e.tr.Errorf("err.ics_component", e.config.ICSComponent)
+	}
+}
+
+// icsProjectEscaper macht aus dem Projektpfad einen UID-Teil ohne "/". Vorhandene "-" werden
+// vorher escaped, sonst ergäben "a/b-c" und "a-b/c" dieselbe UID; Pfade ohne "-" bleiben wie bisher.
+var icsProjectEscaper = strings.NewReplacer("%", "%25", "-", "%2D", "/", "-")
+
+// icsUID ist aus Projekt und IID abgeleitet und damit über Exporte hinweg stabil
+func (e *Exporter) icsUID(issue todoistDomain.Issue) string {
+	host := "gitlab"
+	if parsed, err := url.Parse(e.config.GetGitLabBaseURL()); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+	project := icsProjectEscaper.Replace(e.config.ProjectPath)
+	return fmt.Sprintf("%s-issue-%s@%s", project, issue.IID, host)
+}
+
+// icsDueDate liefert das Fälligkeitsdatum eines Issues, falls vorhanden und gültig
+func icsDueDate(issue todoistDomain.Issue) (time.Time, bool) {
+	date := normalizeIssue(issue).DueDate
+	if date == "" {
+		return time.Time{}, false
+	}
+	due, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return due, true
+}
+
+// icsEscape maskiert Textwerte nach RFC 5545, Abschnitt 3.3.11
+func icsEscape(value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// icsWriter schreibt CRLF-Zeilen mit Line Folding und merkt sich den ersten Fehler
+type icsWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (i *icsWriter) property(name, value string) {
+	i.line(name + ":" + value)
+}
+
+func (i *icsWriter) line(content string) {
+	if i.err != nil {
+		return
+	}
+	_, i.err = io.WriteString(i.w, foldICSLine(content))
+}
+
+// foldICSLine bricht Zeilen nach 75 Oktetten um, ohne UTF-8-Zeichen zu zerteilen.
+// Folgezeilen beginnen mit einem Leerzeichen, das beim Einlesen entfernt wird.
+func foldICSLine(content string) string {
+	var b strings.Builder
+	limit := icsLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		b.WriteString(content[:cut])
+		b.WriteString("\r\n ")
+		content = content[cut:]
+		// Das führende Leerzeichen zählt zur Zeilenlänge
+		limit = icsLineLimit - 1
+	}
+	b.WriteString(content)
+	b.WriteString("\r\n")
+	return b.String()
+}
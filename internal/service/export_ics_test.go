@@ -0,0 +1,119 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+// unfoldICS macht das Line Folding rückgängig, wie es ein Kalender beim Import tut
+func unfoldICS(content string) string {
+	return strings.ReplaceAll(content, "\r\n ", "")
+}
+
+func TestWriteICS_TodosForIssuesWithDueDate(t *testing.T) {
+	exporter := NewExporter(&config.Config{GitLabURL: "https://gitlab.example.com/", ProjectPath: "g/p"})
+
+	issues := templateTestIssues()
+	issues[0].UpdatedAt = time.Date(2024, 2, 1, 10, 30, 0, 0, time.UTC)
+	issues[0].Labels.Nodes[0].Title = "team, backend"
+
+	var buf bytes.Buffer
+	if err := exporter.writeICS(&buf, issues); err != nil {
+		t.Fatal(err)
+	}
+	content := unfoldICS(buf.String())
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"BEGIN:VTODO\r\n",
+		"UID:g-p-issue-1@gitlab.example.com\r\n",
+		"DTSTAMP:20240201T103000Z\r\n",
+		"SUMMARY:#1 - Fix *bold*\r\n",
+		"URL:u1\r\n",
+		`DESCRIPTION:Line1\nLine2` + "\r\n",
+		`CATEGORIES:team\, backend,high` + "\r\n",
+		"DUE;VALUE=DATE:20240215\r\n",
+		"STATUS:NEEDS-ACTION\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("missing %q in:\n%s", want, content)
+		}
+	}
+
+	// Issue 2 hat kein Fälligkeitsdatum
+	if strings.Count(content, "BEGIN:VTODO") != 1 {
+		t.Errorf("only issues with due date should be exported:\n%s", content)
+	}
+}
+
+func TestWriteICS_EventsAndStatus(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", ICSComponent: "VEVENT"})
+
+	issues := templateTestIssues()
+	due := "2024-03-31"
+	issues[1].DueDate = &due
+
+	var buf bytes.Buffer
+	if err := exporter.writeICS(&buf, issues); err != nil {
+		t.Fatal(err)
+	}
+	content := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VEVENT\r\n",
+		"DTSTART;VALUE=DATE:20240215\r\nDTEND;VALUE=DATE:20240216\r\n",
+		"DTSTART;VALUE=DATE:20240331\r\nDTEND;VALUE=DATE:20240401\r\n",
+		"STATUS:CONFIRMED\r\n",
+		"STATUS:CANCELLED\r\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("missing %q in:\n%s", want, content)
+		}
+	}
+
+	exporter = NewExporter(&config.Config{ICSComponent: "vjournal"})
+	if err := exporter.writeICS(&bytes.Buffer{}, issues); err == nil {
+		t.Error("expected error for unknown component")
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("ä", 100)
+	folded := foldICSLine(line)
+
+	for _, part := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		if len(part) > icsLineLimit {
+			t.Errorf("line exceeds %d octets: %d", icsLineLimit, len(part))
+		}
+		if !strings.HasPrefix(part, "DESCRIPTION:") && !strings.HasPrefix(part, " ") {
+			t.Errorf("continuation line must start with a space: %q", part)
+		}
+	}
+	if unfoldICS(folded) != line+"\r\n" {
+		t.Error("unfolding should restore the original line without breaking UTF-8")
+	}
+
+	if got := icsEscape("a;b,c\\d\r\ne"); got != `a\;b\,c\\d\ne` {
+		t.Errorf("unexpected escaping: %q", got)
+	}
+}
+
+func TestICSUID_DistinctForDashedProjectPaths(t *testing.T) {
+	issue := todoistDomain.Issue{IID: "7"}
+	uid := func(project string) string {
+		return NewExporter(&config.Config{GitLabURL: "https://gitlab.example.com", ProjectPath: project}).icsUID(issue)
+	}
+
+	if uid("a/b-c") == uid("a-b/c") {
+		t.Errorf("different projects share the UID %q", uid("a/b-c"))
+	}
+	if got := uid("a/b-c"); got != "a-b%2Dc-issue-7@gitlab.example.com" {
+		t.Errorf("unexpected UID %q", got)
+	}
+}
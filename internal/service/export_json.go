@@ -0,0 +1,130 @@
+package service
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+// NDJSON-Zeilentypen: zuerst eine Metadaten-Zeile, danach eine Zeile pro Issue
+const (
+	ndjsonTypeMetadata = "metadata"
+	ndjsonTypeIssue    = "issue"
+)
+
+type ndjsonMetadata struct {
+	Type          string `json:"type"`
+	SchemaVersion int    `json:"schema_version"`
+	todoistDomain.ExportMetadata
+}
+
+type ndjsonIssue struct {
+	Type string `json:"type"`
+	todoistDomain.NormalizedIssue
+}
+
+// writeJSON schreibt ein JSON-Dokument mit Schema-Version, Metadaten und allen Issues
+func (e *Exporter) writeJSON(w io.Writer, issues []todoistDomain.Issue) error {
+	document := todoistDomain.ExportDocument{
+		SchemaVersion: todoistDomain.ExportSchemaVersion,
+		Metadata:      e.exportMetadata(issues),
+		Issues:        make([]todoistDomain.NormalizedIssue, 0, len(issues)),
+	}
+	for _, issue := range issues {
+		document.Issues = append(document.Issues, e.normalizeForExport(issue))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(document)
+}
+
+// writeNDJSON schreibt eine Metadaten-Zeile und danach ein Issue pro Zeile
+func (e *Exporter) writeNDJSON(w io.Writer, issues []todoistDomain.Issue) error {
+	encoder := json.NewEncoder(w)
+
+	if err := encoder.Encode(ndjsonMetadata{
+		Type:           ndjsonTypeMetadata,
+		SchemaVersion:  todoistDomain.ExportSchemaVersion,
+		ExportMetadata: e.exportMetadata(issues),
+	}); err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		if err := encoder.Encode(ndjsonIssue{Type: ndjsonTypeIssue, NormalizedIssue: e.normalizeForExport(issue)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportMetadata beschreibt den aktuellen Export
+func (e *Exporter) exportMetadata(issues []todoistDomain.Issue) todoistDomain.ExportMetadata {
+	metadata := todoistDomain.ExportMetadata{
+		ExportedAt: time.Now().UTC().Truncate(time.Second),
+		GitLabURL:  e.config.GetGitLabBaseURL(),
+		Project:    e.config.ProjectPath,
+		IssueCount: len(issues),
+		Filters:    e.exportFilters(),
+	}
+	return metadata
+}
+
+// exportFilters beschreibt die Filter des Laufs, die bestimmen, welche Issues exportiert werden
+func (e *Exporter) exportFilters() todoistDomain.ExportFilters {
+	var filters todoistDomain.ExportFilters
+	if e.config.MilestoneTitle != nil {
+		filters.Milestone = *e.config.MilestoneTitle
+	}
+	filters.OnlyUnblocked = e.config.OnlyUnblocked
+	return filters
+}
+
+// normalizeForExport normalisiert ein Issue und ergänzt optional die Todoist-Abbildung
+func (e *Exporter) normalizeForExport(issue todoistDomain.Issue) todoistDomain.NormalizedIssue {
+	normalized := normalizeIssue(issue)
+	if e.config.IncludeTodoistMapping {
+		normalized.Todoist = e.mapper.TodoistMapping(issue)
+	}
+	return normalized
+}
+
+// normalizeIssue wandelt ein GitLab Issue in das flache Exportmodell um
+func normalizeIssue(issue todoistDomain.Issue) todoistDomain.NormalizedIssue {
+	normalized := todoistDomain.NormalizedIssue{
+		IID:         issue.IID,
+		Title:       issue.Title,
+		Description: issue.Description,
+		State:       issue.State,
+		WebURL:      issue.WebURL,
+		CreatedAt:   issue.CreatedAt,
+		UpdatedAt:   issue.UpdatedAt,
+		Labels:      labelTitles(issue),
+		Assignees:   assigneeNames(issue),
+		Weight:      issue.Weight,
+
+		TimeEstimate:   issue.TimeEstimate,
+		TotalTimeSpent: issue.TotalTimeSpent,
+	}
+
+	if issue.DueDate != nil {
+		normalized.DueDate = utils.ConvertToTodoistDate(*issue.DueDate)
+	}
+	if issue.Milestone != nil {
+		normalized.Milestone = issue.Milestone.Title
+	}
+
+	// Leere Listen statt null, damit Konsumenten nicht prüfen müssen
+	if normalized.Labels == nil {
+		normalized.Labels = []string{}
+	}
+	if normalized.Assignees == nil {
+		normalized.Assignees = []string{}
+	}
+
+	return normalized
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+func TestWriteJSON_DocumentWithMetadata(t *testing.T) {
+	exporter := NewExporter(&config.Config{
+		GitLabURL:      "https://gitlab.example.com/",
+		ProjectPath:    "g/p",
+		MilestoneTitle: stringPtr("v1"),
+	})
+
+	var buf bytes.Buffer
+	if err := exporter.writeJSON(&buf, templateTestIssues()); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc todoistDomain.ExportDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+
+	if doc.SchemaVersion != todoistDomain.ExportSchemaVersion {
+		t.Errorf("unexpected schema version %d", doc.SchemaVersion)
+	}
+	if doc.Metadata.Project != "g/p" || doc.Metadata.GitLabURL != "https://gitlab.example.com" ||
+		doc.Metadata.Filters.Milestone != "v1" || doc.Metadata.IssueCount != 2 || doc.Metadata.ExportedAt.IsZero() {
+		t.Errorf("unexpected metadata: %+v", doc.Metadata)
+	}
+
+	first := doc.Issues[0]
+	if first.IID != "1" || first.DueDate != "2024-02-15" || first.Milestone != "v1" ||
+		strings.Join(first.Labels, ",") != "bug,high" || strings.Join(first.Assignees, ",") != "A,B" {
+		t.Errorf("unexpected issue: %+v", first)
+	}
+	if first.Todoist != nil {
+		t.Error("todoist mapping should only be included on request")
+	}
+
+	// Leere Listen werden als [] geschrieben
+	if !strings.Contains(buf.String(), `"assignees": []`) {
+		t.Errorf("expected empty assignee list for issue 2:\n%s", buf.String())
+	}
+}
+
+func TestWriteNDJSON_WithTodoistMapping(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", IncludeTodoistMapping: true, Lang: "en"})
+
+	var buf bytes.Buffer
+	if err := exporter.writeNDJSON(&buf, templateTestIssues()); err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+
+	if len(lines) != 3 {
+		t.Fatalf("expected metadata + 2 issues, got %d lines", len(lines))
+	}
+	if lines[0]["type"] != "metadata" || lines[0]["schema_version"] != float64(1) || lines[0]["project"] != "g/p" {
+		t.Errorf("unexpected metadata line: %v", lines[0])
+	}
+
+	issue := lines[1]
+	if issue["type"] != "issue" || issue["iid"] != "1" {
+		t.Fatalf("unexpected issue line: %v", issue)
+	}
+	todoist, ok := issue["todoist"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected todoist mapping: %v", issue)
+	}
+	if todoist["content"] != "#1 - Fix *bold*" || todoist["priority"] != float64(3) ||
+		todoist["section"] != "Open" || todoist["due_date"] != "2024-02-15" {
+		t.Errorf("unexpected todoist mapping: %v", todoist)
+	}
+
+	if closed := lines[2]["todoist"].(map[string]interface{}); closed["section"] != "Closed" {
+		t.Errorf("closed issue should map to the closed section: %v", closed)
+	}
+}
+
+func TestExportToFile_FormatSelectsExtensionAndWriter(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", OutputFile: config.DefaultOutputFile, Format: "ndjson"})
+	if got := exporter.generateFilename(); got != "gitlab_issues.ndjson" {
+		t.Fatalf("default file name should follow the format, got %q", got)
+	}
+	if err := exporter.exportToFile(templateTestIssues()); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "gitlab_issues.ndjson"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), `{"type":"metadata"`) {
+		t.Errorf("unexpected NDJSON file: %s", content)
+	}
+
+	// Eigene Dateinamen bleiben unverändert
+	exporter = NewExporter(&config.Config{ProjectPath: "g/p", OutputFile: "issues.txt", Format: "json"})
+	if got := exporter.generateFilename(); got != "issues.txt" {
+		t.Errorf("custom file name should be kept, got %q", got)
+	}
+
+	exporter = NewExporter(&config.Config{ProjectPath: "g/p", OutputFile: "out", Format: "yaml"})
+	if err := exporter.exportToFile(templateTestIssues()); err == nil || !strings.Contains(err.Error(), "markdown") {
+		t.Errorf("expected unknown format error listing the formats, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+// obsidianNotesMarker trennt die generierten Inhalte einer Notiz von eigenen Notizen.
+// Alles ab dem Marker bleibt bei erneuten Exporten unverändert. Als Obsidian-Kommentar
+// (%% ... %%) ist er in der Vorschau unsichtbar.
+const obsidianNotesMarker = "%% gitlab-exporter: notes below are kept %%"
+
+// obsidianIndexNote ist der Dateiname der Übersichtsnotiz
+const obsidianIndexNote = "index.md"
+
+// writeObsidianVault schreibt eine Notiz pro Issue und eine Übersichtsnotiz in das Verzeichnis dir
+func (e *Exporter) writeObsidianVault(dir string, issues []todoistDomain.Issue) error {
+	for _, issue := range issues {
+		if err := e.writeObsidianNote(dir, issue); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(filepath.Join(dir, obsidianIndexNote), []byte(e.obsidianIndex(issues)), 0644)
+}
+
+// writeObsidianNote aktualisiert Frontmatter und Beschreibung einer Notiz und erhält eigene Notizen
+func (e *Exporter) writeObsidianNote(dir string, issue todoistDomain.Issue) error {
+	path := filepath.Join(dir, obsidianNoteName(issue)+".md")
+
+	notes := obsidianNotesMarker + "\n\n## " + e.tr.T("obsidian.notes") + "\n\n"
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		// Der letzte Marker zählt: ältere Exporte konnten ihn noch in der Beschreibung enthalten
+		if idx := strings.LastIndex(string(existing), obsidianNotesMarker); idx >= 0 {
+			notes = string(existing[idx:])
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+
+	return os.WriteFile(path, []byte(e.obsidianNote(issue)+notes), 0644)
+}
+
+// obsidianNote erzeugt den generierten Teil einer Notiz: Frontmatter, Titel und Beschreibung
+func (e *Exporter) obsidianNote(issue todoistDomain.Issue) string {
+	normalized := normalizeIssue(issue)
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	if iid, err := strconv.Atoi(issue.IID); err == nil {
+		fmt.Fprintf(&b, "iid: %d\n", iid)
+	} else {
+		fmt.Fprintf(&b, "iid: %s\n", yamlString(issue.IID))
+	}
+	fmt.Fprintf(&b, "title: %s\n", yamlString(issue.Title))
+	fmt.Fprintf(&b, "state: %s\n", yamlString(issue.State))
+	writeYAMLList(&b, "labels", normalized.Labels)
+	writeYAMLList(&b, "assignees", normalized.Assignees)
+	if normalized.DueDate != "" {
+		fmt.Fprintf(&b, "due: %s\n", normalized.DueDate)
+	}
+	fmt.Fprintf(&b, "url: %s\n", yamlString(issue.WebURL))
+	if normalized.Milestone != "" {
+		fmt.Fprintf(&b, "milestone: %s\n", yamlString(normalized.Milestone))
+	}
+	// Über den Alias findet die Schnellsuche die Notiz auch über den Titel
+	writeYAMLList(&b, "aliases", []string{obsidianTitle(issue)})
+	b.WriteString("---\n\n")
+
+	fmt.Fprintf(&b, "# [#%s %s](%s)\n\n", issue.IID, utils.EscapeMarkdown(issue.Title), issue.WebURL)
+	// Ein Marker in der Beschreibung würde beim nächsten Export als Beginn der Notizen gelten
+	description := strings.TrimSpace(strings.ReplaceAll(issue.Description, obsidianNotesMarker, ""))
+	if description != "" {
+		b.WriteString(description + "\n\n")
+	}
+	return b.String()
+}
+
+// obsidianIndex erzeugt die Übersichtsnotiz mit Links auf alle Issues, gruppiert wie der Markdown-Report
+func (e *Exporter) obsidianIndex(issues []todoistDomain.Issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", e.tr.T("md.title", e.config.ProjectPath))
+	fmt.Fprintf(&b, "**%s:** %s  \n", e.tr.T("md.export_time"), time.Now().Format(e.tr.T("datetime.layout")))
+	fmt.Fprintf(&b, "**%s:** %d  \n", e.tr.T("md.issue_count"), len(issues))
+	if e.config.MilestoneTitle != nil && *e.config.MilestoneTitle != "" {
+		fmt.Fprintf(&b, "**%s:** %s  \n", e.tr.T("md.milestone"), *e.config.MilestoneTitle)
+	}
+
+	for _, group := range e.reportGroups(issues) {
+		fmt.Fprintf(&b, "\n## %s\n\n", group.Name)
+		for _, issue := range group.Issues {
+			fmt.Fprintf(&b, "- [[%s|%s]]", obsidianNoteName(issue), obsidianLinkText(obsidianTitle(issue)))
+			if due := issueDueDate(issue); due != "" {
+				fmt.Fprintf(&b, " – %s %s", e.tr.T("md.due"), e.tr.FormatDate(due))
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// obsidianNoteName ist der Dateiname einer Notiz ohne Endung. Er hängt nur von der IID ab,
+// damit Links und eigene Notizen auch nach einer Umbenennung des Issues erhalten bleiben.
+func obsidianNoteName(issue todoistDomain.Issue) string {
+	return "issue-" + issue.IID
+}
+
+func obsidianTitle(issue todoistDomain.Issue) string {
+	return fmt.Sprintf("#%s %s", issue.IID, issue.Title)
+}
+
+// obsidianLinkText entfernt Zeichen, die einen Wikilink beenden würden
+func obsidianLinkText(text string) string {
+	return strings.NewReplacer("[", "(", "]", ")", "|", "/").Replace(text)
+}
+
+// yamlString schreibt einen String als YAML-Skalar in doppelten Anführungszeichen
+func yamlString(value string) string {
+	return strconv.Quote(value)
+}
+
+func writeYAMLList(b *strings.Builder, key string, values []string) {
+	if len(values) == 0 {
+		fmt.Fprintf(b, "%s: []\n", key)
+		return
+	}
+	fmt.Fprintf(b, "%s:\n", key)
+	for _, value := range values {
+		fmt.Fprintf(b, "  - %s\n", yamlString(value))
+	}
+}
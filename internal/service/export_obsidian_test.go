@@ -0,0 +1,154 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+)
+
+func TestWriteObsidianVault_NotesAndIndex(t *testing.T) {
+	dir := t.TempDir()
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p"})
+
+	if err := exporter.writeObsidianVault(dir, templateTestIssues()); err != nil {
+		t.Fatal(err)
+	}
+
+	note, err := os.ReadFile(filepath.Join(dir, "issue-1.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "---\n" +
+		"iid: 1\n" +
+		"title: \"Fix *bold*\"\n" +
+		"state: \"opened\"\n" +
+		"labels:\n  - \"bug\"\n  - \"high\"\n" +
+		"assignees:\n  - \"A\"\n  - \"B\"\n" +
+		"due: 2024-02-15\n" +
+		"url: \"u1\"\n" +
+		"milestone: \"v1\"\n" +
+		"aliases:\n  - \"#1 Fix *bold*\"\n" +
+		"---\n\n" +
+		"# [#1 Fix \\*bold\\*](u1)\n\n" +
+		"Line1\nLine2\n\n" +
+		obsidianNotesMarker + "\n\n## Notizen\n\n"
+	if string(note) != expected {
+		t.Errorf("unexpected note:\n%s\nwant:\n%s", note, expected)
+	}
+
+	closed, err := os.ReadFile(filepath.Join(dir, "issue-2.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(closed), "assignees: []\n") || strings.Contains(string(closed), "due:") {
+		t.Errorf("unexpected frontmatter for issue without assignees and due date:\n%s", closed)
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, obsidianIndexNote))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# GitLab Issues Export - g/p\n",
+		"## 🟢 Offene Issues\n\n- [[issue-1|#1 Fix *bold*]] – Fällig 15.02.2024\n",
+		"## ✅ Geschlossene Issues\n\n- [[issue-2|#2 Done]]\n",
+	} {
+		if !strings.Contains(string(index), want) {
+			t.Errorf("index misses %q:\n%s", want, index)
+		}
+	}
+}
+
+func TestWriteObsidianVault_KeepsUserNotes(t *testing.T) {
+	dir := t.TempDir()
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: "en"})
+
+	issues := templateTestIssues()[:1]
+	if err := exporter.writeObsidianVault(dir, issues); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "issue-1.md")
+	note, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userNotes := "- call the customer\n- [[Meeting 2024-02-01]]\n"
+	if err := os.WriteFile(path, append(note, userNotes...), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues[0].Title = "Renamed"
+	issues[0].State = "closed"
+	issues[0].Description = "New description"
+	if err := exporter.writeObsidianVault(dir, issues); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(updated)
+	for _, want := range []string{"title: \"Renamed\"", "state: \"closed\"", "New description", "## Notes\n\n" + userNotes} {
+		if !strings.Contains(content, want) {
+			t.Errorf("missing %q after re-run:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "Line1") || strings.Count(content, obsidianNotesMarker) != 1 {
+		t.Errorf("generated part should be replaced, notes kept once:\n%s", content)
+	}
+}
+
+func TestWriteObsidianVault_MarkerInDescription(t *testing.T) {
+	dir := t.TempDir()
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: "en"})
+
+	issues := templateTestIssues()[:1]
+	issues[0].Description = "Copied from a note:\n" + obsidianNotesMarker + "\nold text"
+	path := filepath.Join(dir, "issue-1.md")
+
+	// Notiz eines älteren Exports, der den Marker aus der Beschreibung übernommen hat
+	legacy := exporter.obsidianNote(templateTestIssues()[0]) + issues[0].Description + "\n\n" +
+		obsidianNotesMarker + "\n\n## Notes\n\n- keep me\n"
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for run := 0; run < 2; run++ {
+		if err := exporter.writeObsidianVault(dir, issues); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	updated, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(updated)
+	if !strings.HasSuffix(content, obsidianNotesMarker+"\n\n## Notes\n\n- keep me\n") ||
+		strings.Count(content, obsidianNotesMarker) != 1 || !strings.Contains(content, "old text") {
+		t.Errorf("notes should survive a marker in the description:\n%s", content)
+	}
+}
+
+func TestExportToFile_ObsidianWritesDirectory(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", OutputFile: config.DefaultOutputFile, Format: "obsidian"})
+	if err := exporter.exportToFile(templateTestIssues()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "gitlab_issues", obsidianIndexNote)); err != nil {
+		t.Errorf("expected vault directory named after the default output: %v", err)
+	}
+
+	exporter = NewExporter(&config.Config{ProjectPath: "g/p", OutputFile: config.StdoutOutput, Format: "obsidian"})
+	if err := exporter.exportToFile(templateTestIssues()); err == nil {
+		t.Error("obsidian export to stdout should fail")
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+// orgInvalidTagChars sind Zeichen, die in Org-Tags nicht erlaubt sind
+var orgInvalidTagChars = regexp.MustCompile(`[^\p{L}\p{N}_@#%]`)
+
+// writeOrg schreibt die Issues als Org-Datei: Milestones, darunter Status wie im Markdown-Report
+func (e *Exporter) writeOrg(w io.Writer, issues []todoistDomain.Issue) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "#+TITLE: %s\n", e.tr.T("md.title", e.config.ProjectPath))
+	fmt.Fprintf(&b, "#+DATE: %s\n", orgTimestamp(time.Now(), false))
+	b.WriteString("#+TODO: TODO | DONE\n")
+	b.WriteString("#+PRIORITIES: A D D\n\n")
+
+	milestones, err := e.groupIssues(issues, "milestone")
+	if err != nil {
+		return err
+	}
+
+	for _, milestone := range milestones {
+		fmt.Fprintf(&b, "* %s: %s\n", e.tr.T("md.milestone"), singleLine(milestone.Name))
+		for _, group := range e.reportGroups(milestone.Issues) {
+			fmt.Fprintf(&b, "** %s\n", singleLine(group.Name))
+			for _, issue := range group.Issues {
+				e.writeOrgIssue(&b, issue)
+			}
+		}
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// writeOrgIssue schreibt eine Überschrift mit Keyword, Priorität, Tags, Deadline und Properties
+func (e *Exporter) writeOrgIssue(b *strings.Builder, issue todoistDomain.Issue) {
+	mapping := e.mapper.TodoistMapping(issue)
+
+	keyword := "TODO"
+	if issue.State == "closed" {
+		keyword = "DONE"
+	}
+
+	headline := fmt.Sprintf("*** %s [#%s] %s", keyword, priorityLetters[mapping.Priority], singleLine(mapping.Content))
+	if tags := orgTags(issue); len(tags) > 0 {
+		headline += " :" + strings.Join(tags, ":") + ":"
+	}
+	b.WriteString(headline + "\n")
+
+	if mapping.DueDate != "" {
+		if due, err := time.Parse("2006-01-02", mapping.DueDate); err == nil {
+			fmt.Fprintf(b, "DEADLINE: %s\n", orgTimestamp(due, true))
+		}
+	}
+
+	b.WriteString(":PROPERTIES:\n")
+	fmt.Fprintf(b, ":IID: %s\n", issue.IID)
+	fmt.Fprintf(b, ":URL: %s\n", issue.WebURL)
+	if assignees := assigneeNames(issue); len(assignees) > 0 {
+		fmt.Fprintf(b, ":ASSIGNEES: %s\n", strings.Join(assignees, ", "))
+	}
+	b.WriteString(":END:\n")
+
+	if description := strings.TrimSpace(issue.Description); description != "" {
+		b.WriteString("#+BEGIN_SRC markdown\n")
+		for _, line := range strings.Split(strings.ReplaceAll(description, "\r\n", "\n"), "\n") {
+			b.WriteString(orgEscapeBlockLine(line) + "\n")
+		}
+		b.WriteString("#+END_SRC\n")
+	}
+}
+
+// orgTags wandelt Labels in gültige Org-Tags um
+func orgTags(issue todoistDomain.Issue) []string {
+	var tags []string
+	for _, label := range labelTitles(issue) {
+		if tag := orgInvalidTagChars.ReplaceAllString(normalizeLabel(label), "_"); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// orgTimestamp formatiert einen aktiven (<...>) oder inaktiven ([...]) Org-Zeitstempel
+func orgTimestamp(t time.Time, active bool) string {
+	if active {
+		return "<" + t.Format("2006-01-02 Mon") + ">"
+	}
+	return "[" + t.Format("2006-01-02 Mon 15:04") + "]"
+}
+
+// orgEscapeBlockLine schützt Zeilen in Blöcken, die Org sonst als Überschrift oder Block-Ende liest
+func orgEscapeBlockLine(line string) string {
+	if strings.HasPrefix(line, "*") || strings.HasPrefix(line, "#+") || strings.HasPrefix(line, ",*") || strings.HasPrefix(line, ",#+") {
+		return "," + line
+	}
+	return line
+}
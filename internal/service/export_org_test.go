@@ -0,0 +1,54 @@
+package service
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+func TestWriteOrg_HeadlinesByMilestoneAndState(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: "en"})
+
+	issues := templateTestIssues()
+	issues[0].Description = "* not a headline\n#+END_SRC\ntext"
+	issues[0].Labels.Nodes[0].Title = "needs review!"
+
+	var buf bytes.Buffer
+	if err := exporter.writeOrg(&buf, issues); err != nil {
+		t.Fatal(err)
+	}
+
+	content := regexp.MustCompile(`#\+DATE: \[.*\]`).ReplaceAllString(buf.String(), "#+DATE: <now>")
+
+	expected := "#+TITLE: GitLab Issues Export - g/p\n" +
+		"#+DATE: <now>\n" +
+		"#+TODO: TODO | DONE\n" +
+		"#+PRIORITIES: A D D\n\n" +
+		"* Milestone: v1\n" +
+		"** 🟢 Open issues\n" +
+		"*** TODO [#B] #1 - Fix *bold* :needs_review_:high:\n" +
+		"DEADLINE: <2024-02-15 Thu>\n" +
+		":PROPERTIES:\n:IID: 1\n:URL: u1\n:ASSIGNEES: A, B\n:END:\n" +
+		"#+BEGIN_SRC markdown\n,* not a headline\n,#+END_SRC\ntext\n#+END_SRC\n" +
+		"* Milestone: Not set\n" +
+		"** ✅ Closed issues\n" +
+		"*** DONE [#D] #2 - Done :bug:\n" +
+		":PROPERTIES:\n:IID: 2\n:URL: u2\n:END:\n"
+
+	if content != expected {
+		t.Errorf("unexpected org file:\n%s\nwant:\n%s", content, expected)
+	}
+}
+
+func TestPriorityLetters_CoverMapperRange(t *testing.T) {
+	exporter := NewExporter(&config.Config{})
+	for _, label := range []string{"critical", "high", "medium", "low", "other"} {
+		issue := todoistDomain.Issue{Labels: todoistDomain.Labels{Nodes: []todoistDomain.Label{{Title: label}}}}
+		if _, ok := priorityLetters[exporter.mapper.TodoistMapping(issue).Priority]; !ok {
+			t.Errorf("no priority letter for label %q", label)
+		}
+	}
+}
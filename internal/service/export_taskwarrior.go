@@ -0,0 +1,113 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+// taskwarriorDateLayout ist das Datumsformat von "task import"
+const taskwarriorDateLayout = "20060102T150405Z"
+
+// taskwarriorTask ist ein Task im Importformat von Taskwarrior.
+// gitlaburl und gitlabiid sind UDAs, die in der .taskrc definiert sein sollten.
+type taskwarriorTask struct {
+	UUID        string   `json:"uuid"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Entry       string   `json:"entry,omitempty"`
+	Modified    string   `json:"modified,omitempty"`
+	End         string   `json:"end,omitempty"`
+	Due         string   `json:"due,omitempty"`
+	Priority    string   `json:"priority,omitempty"`
+	Project     string   `json:"project,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	GitLabURL   string   `json:"gitlaburl"`
+	GitLabIID   int      `json:"gitlabiid"`
+}
+
+// taskwarriorPriorities übersetzt die Todoist-Priorität des Mappers in H/M/L
+var taskwarriorPriorities = map[int]string{
+	4: "H",
+	3: "M",
+	2: "L",
+}
+
+// writeTaskwarrior schreibt die Issues als JSON-Array für "task import".
+// Die UUIDs sind aus Projekt und IID abgeleitet, wiederholte Importe aktualisieren also bestehende Tasks.
+func (e *Exporter) writeTaskwarrior(w io.Writer, issues []todoistDomain.Issue) error {
+	tasks := make([]taskwarriorTask, 0, len(issues))
+	now := time.Now().UTC()
+	for _, issue := range issues {
+		task, err := e.taskwarriorTask(issue, now)
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, task)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(tasks)
+}
+
+func (e *Exporter) taskwarriorTask(issue todoistDomain.Issue, now time.Time) (taskwarriorTask, error) {
+	iid, err := strconv.Atoi(issue.IID)
+	if err != nil {
+		return taskwarriorTask{}, e.tr.Errorf("err.invalid_iid", issue.IID, err)
+	}
+
+	mapping := e.mapper.TodoistMapping(issue)
+	task := taskwarriorTask{
+		UUID:        e.taskwarriorUUID(issue),
+		Description: mapping.Content,
+		Status:      "pending",
+		Entry:       taskwarriorDate(issue.CreatedAt),
+		Modified:    taskwarriorDate(issue.UpdatedAt),
+		Priority:    taskwarriorPriorities[mapping.Priority],
+		Project:     strings.ReplaceAll(e.config.ProjectPath, "/", "."),
+		GitLabURL:   issue.WebURL,
+		GitLabIID:   iid,
+	}
+
+	for _, label := range labelTitles(issue) {
+		task.Tags = append(task.Tags, normalizeLabel(label))
+	}
+
+	if mapping.DueDate != "" {
+		if due, err := time.Parse("2006-01-02", mapping.DueDate); err == nil {
+			task.Due = taskwarriorDate(due)
+		}
+	}
+
+	if issue.State == "closed" {
+		task.Status = "completed"
+		// Erledigte Tasks brauchen ein Ende; ohne genauere Angabe gilt die letzte Änderung
+		end := issue.UpdatedAt
+		if end.IsZero() {
+			end = now
+		}
+		task.End = taskwarriorDate(end)
+	}
+
+	return task, nil
+}
+
+// taskwarriorUUID leitet eine stabile UUID aus GitLab-Instanz, Projekt und IID ab
+func (e *Exporter) taskwarriorUUID(issue todoistDomain.Issue) string {
+	name := fmt.Sprintf("%s/%s/-/issues/%s", e.config.GetGitLabBaseURL(), e.config.ProjectPath, issue.IID)
+	return utils.UUIDv5(utils.NamespaceURL, name)
+}
+
+func taskwarriorDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(taskwarriorDateLayout)
+}
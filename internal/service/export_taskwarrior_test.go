@@ -0,0 +1,88 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+func TestWriteTaskwarrior_ImportFormat(t *testing.T) {
+	exporter := NewExporter(&config.Config{GitLabURL: "https://gitlab.com", ProjectPath: "g/p"})
+
+	issues := templateTestIssues()
+	issues[0].Labels.Nodes[0].Title = "Needs Review"
+	issues[1].UpdatedAt = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := exporter.writeTaskwarrior(&buf, issues); err != nil {
+		t.Fatal(err)
+	}
+
+	var tasks []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &tasks); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+
+	open := tasks[0]
+	expected := map[string]interface{}{
+		"uuid":        "5e47d267-5707-5c13-9fc9-5bff35703875",
+		"description": "#1 - Fix *bold*",
+		"status":      "pending",
+		"due":         "20240215T000000Z",
+		"priority":    "M",
+		"project":     "g.p",
+		"gitlaburl":   "u1",
+		"gitlabiid":   float64(1),
+	}
+	for key, want := range expected {
+		if open[key] != want {
+			t.Errorf("%s = %v, want %v", key, open[key], want)
+		}
+	}
+	if tags, _ := open["tags"].([]interface{}); len(tags) != 2 || tags[0] != "needs_review" || tags[1] != "high" {
+		t.Errorf("unexpected tags: %v", open["tags"])
+	}
+	if _, ok := open["end"]; ok {
+		t.Error("pending tasks must not have an end date")
+	}
+
+	closed := tasks[1]
+	if closed["status"] != "completed" || closed["end"] != "20240301T120000Z" {
+		t.Errorf("unexpected closed task: %v", closed)
+	}
+	if _, ok := closed["priority"]; ok {
+		t.Errorf("default priority should be omitted: %v", closed["priority"])
+	}
+}
+
+func TestTaskwarriorUUID_StablePerProjectAndIID(t *testing.T) {
+	issue := templateTestIssues()[0]
+
+	a := NewExporter(&config.Config{GitLabURL: "https://gitlab.com/", ProjectPath: "g/p"})
+	b := NewExporter(&config.Config{GitLabURL: "https://gitlab.com", ProjectPath: "g/p"})
+	other := NewExporter(&config.Config{GitLabURL: "https://gitlab.com", ProjectPath: "g/q"})
+
+	if a.taskwarriorUUID(issue) != b.taskwarriorUUID(issue) {
+		t.Error("UUID should not depend on a trailing slash of the GitLab URL")
+	}
+	if a.taskwarriorUUID(issue) == other.taskwarriorUUID(issue) {
+		t.Error("UUID should differ between projects")
+	}
+}
+
+func TestTaskwarriorTask_InvalidIID(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: "en"})
+
+	_, err := exporter.taskwarriorTask(todoistDomain.Issue{IID: "abc", Title: "Broken"}, time.Now())
+	if err == nil || !strings.HasPrefix(err.Error(), `invalid IID "abc"`) {
+		t.Fatalf("expected English IID error, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+// todoistTemplateColumns sind die Spalten des Todoist-Vorlagenformats (Projekt-Import aus CSV)
+var todoistTemplateColumns = []string{
+	"TYPE", "CONTENT", "DESCRIPTION", "PRIORITY", "INDENT", "AUTHOR", "RESPONSIBLE", "DATE", "DATE_LANG", "TIMEZONE",
+	"DURATION", "DURATION_UNIT",
+}
+
+// writeTodoistCSV schreibt eine Todoist-Projektvorlage als CSV. Sections und Tasks entsprechen
+// dem, was setupTodoistSections und Mapper.GitLabToTodoistTask über die API anlegen würden.
+func (e *Exporter) writeTodoistCSV(w io.Writer, issues []todoistDomain.Issue) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(todoistTemplateColumns); err != nil {
+		return err
+	}
+
+	bySection := make(map[string][]todoistDomain.Issue)
+	for _, issue := range issues {
+		key := e.mapper.SectionKey(issue)
+		bySection[key] = append(bySection[key], issue)
+	}
+
+	for _, section := range e.todoistSectionLayout() {
+		if err := writer.Write(todoistTemplateRow(map[string]string{"TYPE": "section", "CONTENT": section.name})); err != nil {
+			return err
+		}
+
+		for _, issue := range bySection[section.key] {
+			if err := writer.Write(e.todoistTemplateTask(issue)); err != nil {
+				return err
+			}
+		}
+
+		// Leerzeile zwischen Sections wie in Vorlagen, die Todoist selbst exportiert
+		if err := writer.Write(make([]string, len(todoistTemplateColumns))); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// todoistTemplateTask bildet ein Issue auf eine Task-Zeile der Vorlage ab
+func (e *Exporter) todoistTemplateTask(issue todoistDomain.Issue) []string {
+	task := e.mapper.GitLabToTodoistTask(issue, "", "")
+
+	// Labels stehen in Vorlagen als @label im Inhalt
+	content := task.Content
+	for _, label := range task.Labels {
+		content += " @" + label
+	}
+
+	values := map[string]string{
+		"TYPE":        "task",
+		"CONTENT":     content,
+		"DESCRIPTION": task.Description,
+		// In Vorlagen ist 1 die höchste Priorität, in der API 4
+		"PRIORITY": strconv.Itoa(5 - task.Priority),
+		"INDENT":   "1",
+	}
+	if task.DueDate != "" {
+		values["DATE"] = task.DueDate
+		values["DATE_LANG"] = e.tr.Lang()
+	}
+	if task.Duration > 0 {
+		values["DURATION"] = strconv.Itoa(task.Duration)
+		values["DURATION_UNIT"] = task.DurationUnit
+	}
+	return todoistTemplateRow(values)
+}
+
+// todoistTemplateRow ordnet die Werte den Spalten der Vorlage zu
+func todoistTemplateRow(values map[string]string) []string {
+	row := make([]string, len(todoistTemplateColumns))
+	for i, column := range todoistTemplateColumns {
+		row[i] = values[column]
+	}
+	return row
+}
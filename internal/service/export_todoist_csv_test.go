@@ -0,0 +1,59 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+)
+
+func TestWriteTodoistCSV_MatchesAPILayout(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: "en"})
+	issues := templateTestIssues()
+	issues[0].TimeEstimate = 5400
+
+	var buf bytes.Buffer
+	if err := exporter.writeTodoistCSV(&buf, issues); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+
+	if strings.Join(records[0], ",") != "TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE,DURATION,DURATION_UNIT" {
+		t.Fatalf("unexpected header: %v", records[0])
+	}
+
+	var layout []string
+	for _, record := range records[1:] {
+		layout = append(layout, record[0]+":"+record[1])
+	}
+	expectedLayout := []string{
+		"section:Open",
+		"task:#1 - Fix *bold* @bug @high @open",
+		":",
+		"section:Closed",
+		"task:#2 - Done @bug @closed",
+		":",
+	}
+	if strings.Join(layout, "\n") != strings.Join(expectedLayout, "\n") {
+		t.Errorf("unexpected layout:\n%s", strings.Join(layout, "\n"))
+	}
+
+	// Die Task-Zeile entspricht dem, was der Mapper für die API erzeugt
+	api := exporter.mapper.GitLabToTodoistTask(issues[0], "", "")
+	task := records[2]
+	if task[2] != api.Description {
+		t.Errorf("description differs from API task:\n%s\nvs\n%s", task[2], api.Description)
+	}
+	if task[3] != "2" || task[4] != "1" || task[7] != "2024-02-15" || task[8] != "en" || task[10] != "90" || task[11] != "minute" {
+		t.Errorf("unexpected task row: %q", task)
+	}
+	if closed := records[5]; closed[3] != "4" || closed[7] != "" || closed[10] != "" {
+		t.Errorf("closed task without due date should have default priority: %q", closed)
+	}
+}
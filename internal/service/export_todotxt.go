@@ -0,0 +1,104 @@
+package service
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+// todoTxtDateLayout ist das Datumsformat der todo.txt-Spezifikation
+const todoTxtDateLayout = "2006-01-02"
+
+// writeTodoTxt schreibt eine Zeile pro Issue im todo.txt-Format.
+// Die Ausgabe enthält nur Daten aus GitLab und ist nach IID sortiert, damit sie sich versionieren und vergleichen lässt.
+func (e *Exporter) writeTodoTxt(w io.Writer, issues []todoistDomain.Issue) error {
+	sorted := make([]todoistDomain.Issue, len(issues))
+	copy(sorted, issues)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return iidLess(sorted[i].IID, sorted[j].IID)
+	})
+
+	var b strings.Builder
+	for _, issue := range sorted {
+		b.WriteString(e.todoTxtLine(issue))
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// todoTxtLine erzeugt z.B. "(B) 2024-01-10 Fix login +group/project @bug due:2024-02-15 gl:12"
+func (e *Exporter) todoTxtLine(issue todoistDomain.Issue) string {
+	var parts []string
+
+	created := ""
+	if !issue.CreatedAt.IsZero() {
+		created = issue.CreatedAt.UTC().Format(todoTxtDateLayout)
+	}
+
+	if issue.State == "closed" {
+		// Erledigt: "x Abschlussdatum Erstellungsdatum"; das Erstellungsdatum ist nur mit Abschlussdatum erlaubt
+		parts = append(parts, "x")
+		if !issue.UpdatedAt.IsZero() {
+			parts = append(parts, issue.UpdatedAt.UTC().Format(todoTxtDateLayout))
+			if created != "" {
+				parts = append(parts, created)
+			}
+		}
+	} else {
+		parts = append(parts, "("+priorityLetters[e.mapper.determinePriority(issue)]+")")
+		if created != "" {
+			parts = append(parts, created)
+		}
+	}
+
+	if title := todoTxtText(issue.Title); title != "" {
+		parts = append(parts, title)
+	}
+	if e.config.ProjectPath != "" {
+		parts = append(parts, "+"+strings.ReplaceAll(e.config.ProjectPath, " ", "_"))
+	}
+	for _, label := range labelTitles(issue) {
+		parts = append(parts, "@"+normalizeLabel(label))
+	}
+	if due := normalizeIssue(issue).DueDate; due != "" {
+		parts = append(parts, "due:"+due)
+	}
+	parts = append(parts, "gl:"+issue.IID)
+
+	return strings.Join(parts, " ")
+}
+
+// todoTxtText hält den Titel einzeilig und entschärft Wörter, die todo.txt als Projekt (+word),
+// Kontext (@word) oder key:value-Tag lesen würde: vor +/@ steht ein Zero-Width-Space, nach
+// Doppelpunkten ein Leerzeichen.
+func todoTxtText(text string) string {
+	words := strings.Fields(text)
+	for i, word := range words {
+		if len(word) > 1 && (word[0] == '+' || word[0] == '@') {
+			word = "\u200b" + word
+		}
+		words[i] = strings.ReplaceAll(word, ":", ": ")
+	}
+	return singleLine(strings.Join(words, " "))
+}
+
+// iidLess vergleicht IIDs numerisch, nicht numerische IIDs alphabetisch dahinter
+func iidLess(a, b string) bool {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return na < nb
+	case errA == nil:
+		return true
+	case errB == nil:
+		return false
+	default:
+		return a < b
+	}
+}
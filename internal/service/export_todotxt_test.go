@@ -0,0 +1,73 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+func TestWriteTodoTxt_Reproducible(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p"})
+
+	issues := templateTestIssues()
+	issues[0].CreatedAt = time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	issues[1].CreatedAt = time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)
+	issues[1].UpdatedAt = time.Date(2024, 3, 1, 17, 0, 0, 0, time.UTC)
+	issues = append(issues, todoistDomain.Issue{IID: "10", Title: "Third\nline", State: "opened",
+		Labels: todoistDomain.Labels{Nodes: []todoistDomain.Label{{Title: "Needs Review"}, {Title: "critical"}}}})
+
+	// Reihenfolge der Eingabe darf keine Rolle spielen
+	reversed := []todoistDomain.Issue{issues[2], issues[1], issues[0]}
+
+	var first, second bytes.Buffer
+	if err := exporter.writeTodoTxt(&first, issues); err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.writeTodoTxt(&second, reversed); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "(B) 2024-01-10 Fix *bold* +g/p @bug @high due:2024-02-15 gl:1\n" +
+		"x 2024-03-01 2024-01-08 Done +g/p @bug gl:2\n" +
+		"(A) Third line +g/p @needs_review @critical gl:10\n"
+
+	if first.String() != expected {
+		t.Errorf("unexpected todo.txt:\n%s\nwant:\n%s", first.String(), expected)
+	}
+	if first.String() != second.String() {
+		t.Error("output should not depend on the input order")
+	}
+}
+
+func TestTodoTxtLine_TitleCannotInjectTokens(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p"})
+
+	line := exporter.todoTxtLine(todoistDomain.Issue{IID: "4", Title: "Fix @home due:2024-01-01 +ops", State: "opened"})
+	if want := "(D) Fix \u200b@home due: 2024-01-01 \u200b+ops +g/p gl:4"; line != want {
+		t.Errorf("unexpected line %q, want %q", line, want)
+	}
+
+	// Nur die Tokens des Exporters dürfen als Projekt, Kontext oder Tag erkannt werden
+	var tokens []string
+	for _, word := range strings.Fields(line) {
+		if strings.HasPrefix(word, "+") || strings.HasPrefix(word, "@") ||
+			(strings.Contains(word, ":") && !strings.HasSuffix(word, ":")) {
+			tokens = append(tokens, word)
+		}
+	}
+	if strings.Join(tokens, " ") != "+g/p gl:4" {
+		t.Errorf("title leaked todo.txt tokens: %q", tokens)
+	}
+}
+
+func TestTodoTxtLine_ClosedWithoutDates(t *testing.T) {
+	exporter := NewExporter(&config.Config{})
+	line := exporter.todoTxtLine(todoistDomain.Issue{IID: "3", Title: "Old", State: "closed"})
+	if line != "x Old gl:3" {
+		t.Errorf("unexpected line %q", line)
+	}
+}
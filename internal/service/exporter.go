@@ -2,15 +2,19 @@ package service
 
 import (
 	"fmt"
-	"os"
+	"log/slog"
 	"strings"
+	"sync"
 	"time"
 
 	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
 	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/logging"
 	gitlabRepo "hufschlaeger.net/gitlab-tasks-exporter/internal/repository/gitlab"
+	historyRepo "hufschlaeger.net/gitlab-tasks-exporter/internal/repository/history"
+	stateRepo "hufschlaeger.net/gitlab-tasks-exporter/internal/repository/state"
 	todoistRepo "hufschlaeger.net/gitlab-tasks-exporter/internal/repository/todoist"
-	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
 )
 
 type Exporter struct {
@@ -18,37 +22,107 @@ type Exporter struct {
 	gitlabRepo  *gitlabRepo.Repository
 	todoistRepo *todoistRepo.Repository
 	mapper      *Mapper
+	tr          *i18n.Translator
+
+	// mu serialisiert Läufe, da Webhooks parallel eintreffen können
+	mu sync.Mutex
+
+	// Todoist-Metadaten, die zwischen mehreren Läufen (Watch-Modus) wiederverwendet werden
+	todoistProjectID string
+	todoistSections  map[string]string
+
+	// state verknüpft Todoist Tasks mit GitLab Issues (nil, wenn deaktiviert)
+	state *stateRepo.Store
+
+	// history speichert einen Snapshot pro Lauf (nil, wenn deaktiviert)
+	history *historyRepo.Store
+
+	// burnup enthält die Burnup-Daten aus GitLab je Milestone für den aktuellen Lauf
+	burnup map[string][]todoistDomain.BurnupPoint
+}
+
+// SyncStats fasst das Ergebnis eines Export-Laufs zusammen
+type SyncStats struct {
+	Issues  int
+	Created int
+	Updated int
+	Skipped int
+	Failed  int
 }
 
 func NewExporter(cfg *config.Config) *Exporter {
-	return &Exporter{
+	exporter := &Exporter{
 		config:      cfg,
 		gitlabRepo:  gitlabRepo.NewRepository(cfg),
 		todoistRepo: todoistRepo.NewRepository(cfg),
 		mapper:      NewMapper(cfg),
+		tr:          i18n.New(cfg.Lang),
 	}
+
+	if cfg.StateFile != "" {
+		exporter.state = stateRepo.NewStore(cfg.StateFile)
+		if err := exporter.state.Load(); err != nil {
+			slog.Warn("loading sync state failed", "file", cfg.StateFile, "error", err)
+		}
+	}
+
+	if cfg.HistoryDir != "" {
+		exporter.history = historyRepo.NewStore(cfg.HistoryDir)
+	}
+
+	return exporter
 }
 
 // Export startet den Hauptexport-Prozess
 func (e *Exporter) Export() error {
+	_, err := e.Run()
+	return err
+}
+
+// Run führt einen vollständigen Export-Lauf aus und liefert dessen Statistik
+func (e *Exporter) Run() (SyncStats, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.reloadState()
+
+	var stats SyncStats
+
 	// 1. Konfiguration validieren
 	if err := e.config.Validate(); err != nil {
-		return fmt.Errorf("konfiguration ungültig: %w", err)
+		return stats, e.tr.Errorf("err.invalid_config", err)
 	}
 
-	fmt.Printf("🔍 Lade Issues aus GitLab: %s\n", e.config.ProjectPath)
+	e.progress("progress.loading_issues", e.config.ProjectPath)
 
 	// 2. Issues von GitLab laden
 	issues, err := e.loadGitLabIssues()
 	if err != nil {
-		return fmt.Errorf("fehler beim Laden der GitLab Issues: %w", err)
+		return stats, e.tr.Errorf("err.loading_issues", err)
 	}
 
-	fmt.Printf("📊 Gefunden: %d Issues\n", len(issues))
+	e.progress("progress.found_issues", len(issues))
+	stats.Issues = len(issues)
+	e.loadIssueWeights(issues)
+	if e.config.MilestoneProgress {
+		e.loadProgressData()
+	}
+	if e.issueLinksEnabled() {
+		e.progress("progress.loading_links", len(issues))
+		e.loadIssueLinks(issues)
+	}
+	if e.config.Hierarchy {
+		e.progress("progress.loading_hierarchy")
+		e.loadHierarchy(issues)
+	}
+	if e.config.OnlyUnblocked {
+		issues = e.filterUnblocked(issues)
+	}
+	// Erst nach allen Filtern, der Snapshot soll den Export dieses Laufs abbilden
+	e.saveSnapshot(issues)
 
 	if len(issues) == 0 {
-		fmt.Println("ℹ️  Keine Issues gefunden")
-		return nil
+		e.progress("progress.no_issues")
+		return stats, nil
 	}
 
 	// 3. Export-Modus bestimmen
@@ -56,56 +130,148 @@ func (e *Exporter) Export() error {
 		return e.exportToTodoist(issues)
 	}
 
-	return e.exportToFile(issues)
+	return stats, e.exportToFile(issues)
+}
+
+// SyncIssueByIID lädt ein einzelnes Issue aus GitLab und synchronisiert es mit Todoist
+func (e *Exporter) SyncIssueByIID(projectPath string, iid string) (SyncStats, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.reloadState()
+
+	stats := SyncStats{Issues: 1}
+
+	issue, err := e.gitlabRepo.GetIssue(projectPath, iid)
+	if err != nil {
+		return stats, e.tr.Errorf("err.loading_issue", iid, err)
+	}
+	if issue == nil {
+		return stats, e.tr.Errorf("err.issue_not_found", iid, projectPath)
+	}
+
+	if !e.matchesMilestoneFilter(*issue) {
+		slog.Info("issue skipped, milestone does not match", "issue", iid, "milestone", *e.config.MilestoneTitle)
+		stats.Skipped++
+		return stats, nil
+	}
+
+	if e.issueLinksEnabled() {
+		single := []todoistDomain.Issue{*issue}
+		e.loadIssueLinks(single)
+		issue = &single[0]
+	}
+	if e.config.OnlyUnblocked && len(blockedBy(*issue)) > 0 {
+		slog.Info("issue skipped, blocked by other issues", "issue", iid)
+		stats.Skipped++
+		return stats, nil
+	}
+
+	projectID, sections, err := e.ensureTodoistSetup()
+	if err != nil {
+		return stats, err
+	}
+
+	existingTasks, err := e.loadExistingTasks(projectID)
+	if err != nil {
+		e.resetTodoistCache()
+		return stats, e.tr.Errorf("err.loading_tasks", err)
+	}
+
+	err = e.syncSingleIssue(*issue, projectID, sections, existingTasks, &stats)
+	e.saveState()
+	if err != nil {
+		stats.Failed++
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+// matchesMilestoneFilter prüft, ob ein Issue zum konfigurierten Milestone-Filter passt
+func (e *Exporter) matchesMilestoneFilter(issue todoistDomain.Issue) bool {
+	filter := e.config.MilestoneTitle
+	if filter == nil || *filter == "" || *filter == "*" {
+		return true
+	}
+	return issue.Milestone != nil && issue.Milestone.Title == *filter
 }
 
 func (e *Exporter) loadGitLabIssues() ([]todoistDomain.Issue, error) {
 	// Verbindung testen
 	if err := e.gitlabRepo.ValidateConnection(); err != nil {
-		return nil, fmt.Errorf("GitLab-Verbindung fehlgeschlagen: %w", err)
+		return nil, e.tr.Errorf("err.gitlab_connection", err)
 	}
 
 	// Issues laden (je nach Milestone-Filter)
 	if e.config.MilestoneTitle != nil && *e.config.MilestoneTitle != "*" {
-		fmt.Printf("🎯 Filter nach Milestone: %s\n", *e.config.MilestoneTitle)
+		e.progress("progress.milestone_filter", *e.config.MilestoneTitle)
 		return e.gitlabRepo.GetMilestoneIssues(e.config.ProjectPath, e.config.MilestoneTitle)
 	}
 
-	fmt.Println("📋 Lade alle Issues...")
+	e.progress("progress.loading_all")
 	return e.gitlabRepo.GetMilestoneIssues(e.config.ProjectPath, nil)
 }
 
 // exportToTodoist exportiert Issues zu Todoist
-func (e *Exporter) exportToTodoist(issues []todoistDomain.Issue) error {
-	fmt.Println("🚀 Exportiere zu Todoist...")
+func (e *Exporter) exportToTodoist(issues []todoistDomain.Issue) (SyncStats, error) {
+	e.progress("progress.exporting_todoist")
+
+	// 1.-3. Verbindung, Projekt und Sections (beim ersten Lauf)
+	projectID, sections, err := e.ensureTodoistSetup()
+	if err != nil {
+		return SyncStats{Issues: len(issues)}, err
+	}
+
+	// 4. Bestehende Tasks laden
+	existingTasks, err := e.loadExistingTasks(projectID)
+	if err != nil {
+		// Projekt könnte gelöscht worden sein - beim nächsten Lauf neu einrichten
+		e.resetTodoistCache()
+		return SyncStats{Issues: len(issues)}, e.tr.Errorf("err.loading_tasks", err)
+	}
+
+	e.progress("progress.existing_tasks", len(existingTasks))
+
+	// 5. Issues zu Tasks konvertieren und erstellen/aktualisieren
+	stats := e.syncIssuesToTasks(issues, projectID, sections, existingTasks)
+	e.saveState()
+
+	return stats, nil
+}
+
+// ensureTodoistSetup richtet Projekt und Sections ein und merkt sich das Ergebnis
+func (e *Exporter) ensureTodoistSetup() (string, map[string]string, error) {
+	if e.todoistProjectID != "" && e.todoistSections != nil {
+		return e.todoistProjectID, e.todoistSections, nil
+	}
 
 	// 1. Todoist-Verbindung testen
 	if err := e.todoistRepo.ValidateConnection(); err != nil {
-		return fmt.Errorf("Todoist-Verbindung fehlgeschlagen: %w", err)
+		return "", nil, e.tr.Errorf("err.todoist_connection", err)
 	}
 
 	// 2. Projekt einrichten
 	projectID, err := e.setupTodoistProject()
 	if err != nil {
-		return fmt.Errorf("projekt-Setup fehlgeschlagen: %w", err)
+		return "", nil, e.tr.Errorf("err.project_setup", err)
 	}
 
 	// 3. Sections einrichten
 	sections, err := e.setupTodoistSections(projectID)
 	if err != nil {
-		return fmt.Errorf("section-Setup fehlgeschlagen: %w", err)
+		return "", nil, e.tr.Errorf("err.section_setup", err)
 	}
 
-	// 4. Bestehende Tasks laden
-	existingTasks, err := e.loadExistingTasks(projectID)
-	if err != nil {
-		return fmt.Errorf("fehler beim Laden bestehender Tasks: %w", err)
-	}
+	e.todoistProjectID = projectID
+	e.todoistSections = sections
 
-	fmt.Printf("🔍 Gefunden: %d bestehende Tasks\n", len(existingTasks))
+	return projectID, sections, nil
+}
 
-	// 5. Issues zu Tasks konvertieren und erstellen/aktualisieren
-	return e.syncIssuesToTasks(issues, projectID, sections, existingTasks)
+// resetTodoistCache verwirft die zwischengespeicherten Todoist-Metadaten
+func (e *Exporter) resetTodoistCache() {
+	e.todoistProjectID = ""
+	e.todoistSections = nil
 }
 
 // setupTodoistProject richtet das Todoist-Projekt ein
@@ -119,13 +285,12 @@ func (e *Exporter) setupTodoistProject() (string, error) {
 	}
 
 	if existingProject != nil {
-		fmt.Printf("📋 Verwende bestehendes Projekt: %s (ID: %s)\n",
-			existingProject.Name, existingProject.ID)
+		slog.Info("using existing todoist project", "project", existingProject.Name, "project_id", existingProject.ID)
 		return existingProject.ID, nil
 	}
 
 	// Neues Projekt erstellen
-	fmt.Printf("📋 Erstelle neues Projekt: %s\n", projectName)
+	slog.Info("creating todoist project", "project", projectName)
 	newProject, err := e.todoistRepo.CreateProject(projectName)
 	if err != nil {
 		return "", err
@@ -138,18 +303,10 @@ func (e *Exporter) setupTodoistProject() (string, error) {
 func (e *Exporter) setupTodoistSections(projectID string) (map[string]string, error) {
 	sections := make(map[string]string)
 
-	requiredSections := []struct {
-		name  string
-		key   string
-		order int
-	}{
-		{"Offen", "open", 1},
-		{"Geschlossen", "closed", 2},
-	}
-
-	for _, reqSection := range requiredSections {
-		// Section suchen
-		existingSection, err := e.todoistRepo.FindSectionByName(projectID, reqSection.name)
+	for _, reqSection := range e.todoistSectionLayout() {
+		// Section suchen - auch unter dem Namen einer anderen Sprache, damit ein
+		// Sprachwechsel keine doppelten Sections anlegt
+		existingSection, err := e.todoistRepo.FindSectionByName(projectID, e.tr.Variants("section."+reqSection.key)...)
 		if err != nil {
 			return nil, err
 		}
@@ -162,18 +319,33 @@ func (e *Exporter) setupTodoistSections(projectID string) (map[string]string, er
 		// Section erstellen
 		newSection, err := e.todoistRepo.CreateSection(projectID, reqSection.name, reqSection.order)
 		if err != nil {
-			return nil, fmt.Errorf("fehler beim Erstellen der Section '%s': %w", reqSection.name, err)
+			return nil, e.tr.Errorf("err.section_create", reqSection.name, err)
 		}
 
 		sections[reqSection.key] = newSection.ID
 	}
 
-	fmt.Printf("📂 Sections eingerichtet: Offen (%s), Geschlossen (%s)\n",
-		sections["open"], sections["closed"])
+	slog.Debug("todoist sections ready", "open", sections["open"], "closed", sections["closed"])
 
 	return sections, nil
 }
 
+// todoistSection beschreibt eine Section des Todoist-Projekts
+type todoistSection struct {
+	name  string
+	key   string
+	order int
+}
+
+// todoistSectionLayout liefert die Sections in der Reihenfolge, in der sie angelegt werden.
+// Der Schlüssel entspricht Mapper.SectionKey.
+func (e *Exporter) todoistSectionLayout() []todoistSection {
+	return []todoistSection{
+		{e.tr.T("section.open"), "open", 1},
+		{e.tr.T("section.closed"), "closed", 2},
+	}
+}
+
 // loadExistingTasks lädt alle bestehenden Tasks des Projekts
 func (e *Exporter) loadExistingTasks(projectID string) (map[string]*todoistDomain.Task, error) {
 	tasks, err := e.todoistRepo.GetProjectTasks(projectID)
@@ -187,9 +359,11 @@ func (e *Exporter) loadExistingTasks(projectID string) (map[string]*todoistDomai
 	for i := range tasks {
 		task := &tasks[i]
 
-		// Issue-IID aus Task-Content extrahieren (Format: "#123 - Title")
+		// Issue-IID aus Task-Content extrahieren (Format: "#123 - Title"), Epics unter ihrer Referenz ("&5")
 		if issueIID := extractIssueIIDFromContent(task.Content); issueIID != "" {
 			taskMap[issueIID] = task
+		} else if epicReference := extractEpicReferenceFromContent(task.Content); epicReference != "" {
+			taskMap[epicReference] = task
 		}
 	}
 
@@ -197,29 +371,41 @@ func (e *Exporter) loadExistingTasks(projectID string) (map[string]*todoistDomai
 }
 
 // syncIssuesToTasks synchronisiert GitLab Issues mit Todoist Tasks
-func (e *Exporter) syncIssuesToTasks(issues []todoistDomain.Issue, projectID string, sections map[string]string, existingTasks map[string]*todoistDomain.Task) error {
-	stats := struct {
-		created, updated, skipped int
-	}{}
-
-	for _, issue := range issues {
-		if err := e.syncSingleIssue(issue, projectID, sections, existingTasks, &stats); err != nil {
-			fmt.Printf("⚠️  Fehler bei Issue #%s: %v\n", issue.IID, err)
-			continue
+func (e *Exporter) syncIssuesToTasks(issues []todoistDomain.Issue, projectID string, sections map[string]string, existingTasks map[string]*todoistDomain.Task) SyncStats {
+	stats := SyncStats{Issues: len(issues)}
+
+	if e.config.Hierarchy {
+		e.syncHierarchy(issues, projectID, sections, existingTasks, &stats)
+	} else {
+		for _, issue := range issues {
+			if err := e.syncSingleIssue(issue, projectID, sections, existingTasks, &stats); err != nil {
+				slog.Warn("syncing issue failed", "issue", issue.IID, "error", err)
+				stats.Failed++
+				continue
+			}
 		}
 	}
 
 	// Statistiken ausgeben
-	fmt.Printf("\n🎉 Synchronisation abgeschlossen:\n")
-	fmt.Printf("  ✅  Erstellt: %d\n", stats.created)
-	fmt.Printf("  🔄  Aktualisiert: %d\n", stats.updated)
-	fmt.Printf("  ⏭️  Übersprungen: %d\n", stats.skipped)
+	e.progress("progress.sync_done")
+	e.progress("progress.created", stats.Created)
+	e.progress("progress.updated", stats.Updated)
+	e.progress("progress.skipped", stats.Skipped)
+	slog.Info("todoist sync finished", "issues", stats.Issues, "created", stats.Created,
+		"updated", stats.Updated, "skipped", stats.Skipped, "failed", stats.Failed)
+
+	return stats
+}
 
-	return nil
+// syncSingleIssue synchronisiert ein einzelnes Issue, ohne seinen Parent-Task zu ändern
+func (e *Exporter) syncSingleIssue(issue todoistDomain.Issue, projectID string, sections map[string]string, existingTasks map[string]*todoistDomain.Task, stats *SyncStats) error {
+	_, err := e.syncIssueTask(issue, nil, projectID, sections, existingTasks, stats)
+	return err
 }
 
-// syncSingleIssue synchronisiert ein einzelnes Issue
-func (e *Exporter) syncSingleIssue(issue todoistDomain.Issue, projectID string, sections map[string]string, existingTasks map[string]*todoistDomain.Task, stats *struct{ created, updated, skipped int }) error {
+// syncIssueTask synchronisiert ein Issue und liefert die ID seines Tasks. parentID legt den
+// Parent-Task fest ("" = oberste Ebene); nil lässt ihn unverändert.
+func (e *Exporter) syncIssueTask(issue todoistDomain.Issue, parentID *string, projectID string, sections map[string]string, existingTasks map[string]*todoistDomain.Task, stats *SyncStats) (string, error) {
 	existingTask := existingTasks[issue.IID]
 
 	// Section für Issue bestimmen
@@ -227,34 +413,62 @@ func (e *Exporter) syncSingleIssue(issue todoistDomain.Issue, projectID string,
 
 	if existingTask == nil {
 		// Neuen Task erstellen
-		return e.createNewTask(issue, projectID, sectionID, stats)
+		parent := ""
+		if parentID != nil {
+			parent = *parentID
+		}
+		return e.createNewTask(issue, projectID, sectionID, parent, stats)
 	}
 
 	// Bestehenden Task aktualisieren (falls nötig)
-	return e.updateExistingTask(issue, existingTask, sectionID, stats)
+	e.rememberTask(existingTask.ID, issue)
+	return existingTask.ID, e.updateExistingTask(issue, existingTask, sectionID, parentID, stats)
 }
 
-// createNewTask erstellt einen neuen Todoist Task
-func (e *Exporter) createNewTask(issue todoistDomain.Issue, projectID string, sectionID string, stats *struct{ created, updated, skipped int }) error {
+// createNewTask erstellt einen neuen Todoist Task, mit parentID als Subtask
+func (e *Exporter) createNewTask(issue todoistDomain.Issue, projectID string, sectionID string, parentID string, stats *SyncStats) (string, error) {
 	taskRequest := e.mapper.GitLabToTodoistTask(issue, projectID, sectionID)
+	if parentID != "" {
+		// Subtasks liegen immer in der Section ihres Parents
+		taskRequest.ParentID, taskRequest.SectionID = parentID, ""
+	}
 
 	createdTask, err := e.todoistRepo.CreateTask(taskRequest)
 	if err != nil {
-		return fmt.Errorf("task-Erstellung fehlgeschlagen: %w", err)
+		return "", e.tr.Errorf("err.task_create", err)
 	}
 
-	fmt.Printf("✅ Task erstellt: #%s - %s (ID: %s)\n",
-		issue.IID, issue.Title, createdTask.ID)
+	slog.Info("task created", "issue", issue.IID, "title", issue.Title, "task_id", createdTask.ID)
+	e.rememberTask(createdTask.ID, issue)
 
-	stats.created++
-	return nil
+	stats.Created++
+	return createdTask.ID, nil
 }
 
 // updateExistingTask aktualisiert einen bestehenden Task falls nötig
-func (e *Exporter) updateExistingTask(issue todoistDomain.Issue, existingTask *todoistDomain.Task, sectionID string, stats *struct{ created, updated, skipped int }) error {
+func (e *Exporter) updateExistingTask(issue todoistDomain.Issue, existingTask *todoistDomain.Task, sectionID string, parentID *string, stats *SyncStats) error {
 	updates := make(map[string]interface{})
 	needsUpdate := false
 
+	// Parent prüfen (nur mit --hierarchy); die REST API kann ihn nicht ändern, daher vorab verschieben
+	moved := false
+	currentSectionID := existingTask.SectionID
+	if parentID != nil {
+		if existingTask.ParentID != *parentID {
+			if err := e.todoistRepo.MoveTask(existingTask.ID, *parentID, existingTask.ProjectID); err != nil {
+				return e.tr.Errorf("err.task_move", err)
+			}
+			slog.Info("task moved", "issue", issue.IID, "task_id", existingTask.ID, "parent_id", *parentID)
+			moved = true
+			// Auf der obersten Ebene liegt der Task danach in keiner Section
+			currentSectionID = ""
+		}
+		if *parentID != "" {
+			// Subtasks liegen immer in der Section ihres Parents
+			sectionID = ""
+		}
+	}
+
 	// Title prüfen
 	expectedTitle := fmt.Sprintf("#%s - %s", issue.IID, issue.Title)
 	if existingTask.Content != expectedTitle {
@@ -263,7 +477,7 @@ func (e *Exporter) updateExistingTask(issue todoistDomain.Issue, existingTask *t
 	}
 
 	// Section prüfen (State-Änderung)
-	if existingTask.SectionID != sectionID && sectionID != "" {
+	if currentSectionID != sectionID && sectionID != "" {
 		updates["section_id"] = sectionID
 		needsUpdate = true
 	}
@@ -275,142 +489,96 @@ func (e *Exporter) updateExistingTask(issue todoistDomain.Issue, existingTask *t
 		needsUpdate = true
 	}
 
+	// Dauer prüfen; ohne Schätzung bleibt eine in Todoist gesetzte Dauer unangetastet
+	if minutes := estimateMinutes(issue); minutes > 0 {
+		current := existingTask.Duration
+		if current == nil || current.Amount != minutes || current.Unit != todoistDurationUnit {
+			updates["duration"] = minutes
+			updates["duration_unit"] = todoistDurationUnit
+			needsUpdate = true
+		}
+	}
+
 	if !needsUpdate {
-		stats.skipped++
+		if moved {
+			stats.Updated++
+		} else {
+			stats.Skipped++
+		}
 		return nil
 	}
 
 	// Task aktualisieren
 	_, err := e.todoistRepo.UpdateTask(existingTask.ID, updates)
 	if err != nil {
-		return fmt.Errorf("task-Update fehlgeschlagen: %w", err)
+		return e.tr.Errorf("err.task_update", err)
 	}
 
-	fmt.Printf("🔄 Task aktualisiert: #%s - %s\n", issue.IID, issue.Title)
-	stats.updated++
+	slog.Info("task updated", "issue", issue.IID, "title", issue.Title, "task_id", existingTask.ID)
+	stats.Updated++
 
 	return nil
 }
 
-// exportToFile exportiert Issues in eine Markdown-Datei
-func (e *Exporter) exportToFile(issues []todoistDomain.Issue) error {
-	fmt.Println("📄 Exportiere zu Markdown-Datei...")
-
-	filename := e.generateFilename()
-	content := e.generateMarkdownContent(issues)
-
-	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
-		return fmt.Errorf("datei-Export fehlgeschlagen: %w", err)
+// rememberTask merkt sich die Verknüpfung von Task und Issue im Sync-Zustand
+func (e *Exporter) rememberTask(taskID string, issue todoistDomain.Issue) {
+	if e.state == nil || taskID == "" {
+		return
 	}
-
-	fmt.Printf("✅ Datei erstellt: %s (%d Issues)\n", filename, len(issues))
-	return nil
+	e.state.SetLink(taskID, e.config.ProjectPath, issue.IID)
 }
 
-// generateFilename erstellt einen Dateinamen
-func (e *Exporter) generateFilename() string {
-	if e.config.OutputFile != "" {
-		return e.config.OutputFile
+// saveState speichert den Sync-Zustand; Fehler brechen die Synchronisation nicht ab
+// reloadState übernimmt Verknüpfungen, die andere Prozesse (export, watch) seit dem Start
+// geschrieben haben; ein lang laufender serve-Prozess kennt sonst nur den Stand beim Start
+func (e *Exporter) reloadState() {
+	if e.state == nil {
+		return
 	}
-
-	// Standard-Format: project-milestone-YYYY-MM-DD.md
-	timestamp := time.Now().Format("2006-01-02")
-	projectName := strings.ReplaceAll(e.config.ProjectPath, "/", "-")
-
-	if e.config.MilestoneTitle != nil && *e.config.MilestoneTitle != "" {
-		milestone := strings.ReplaceAll(*e.config.MilestoneTitle, " ", "-")
-		return fmt.Sprintf("%s-%s-%s.md", projectName, milestone, timestamp)
+	if err := e.state.ReloadIfChanged(); err != nil {
+		slog.Warn("reloading sync state failed", "file", e.config.StateFile, "error", err)
 	}
-
-	return fmt.Sprintf("%s-%s.md", projectName, timestamp)
 }
 
-// generateMarkdownContent generiert Markdown-Content
-func (e *Exporter) generateMarkdownContent(issues []todoistDomain.Issue) string {
-	var content strings.Builder
-
-	// Header
-	content.WriteString(fmt.Sprintf("# GitLab Issues Export - %s\n\n", e.config.ProjectPath))
-	content.WriteString(fmt.Sprintf("**Export-Zeit:** %s  \n", time.Now().Format("02.01.2006 15:04:05")))
-	content.WriteString(fmt.Sprintf("**Anzahl Issues:** %d  \n\n", len(issues)))
-
-	if e.config.MilestoneTitle != nil && *e.config.MilestoneTitle != "" {
-		content.WriteString(fmt.Sprintf("**Milestone:** %s  \n\n", *e.config.MilestoneTitle))
-	}
-
-	// Offene Issues
-	openIssues := filterIssuesByState(issues, "opened")
-	if len(openIssues) > 0 {
-		content.WriteString("## 🟢 Offene Issues\n\n")
-		for _, issue := range openIssues {
-			content.WriteString(e.formatIssueAsMarkdown(issue))
-		}
+func (e *Exporter) saveState() {
+	if e.state == nil {
+		return
 	}
-
-	// Geschlossene Issues
-	closedIssues := filterIssuesByState(issues, "closed")
-	if len(closedIssues) > 0 {
-		content.WriteString("## ✅ Geschlossene Issues\n\n")
-		for _, issue := range closedIssues {
-			content.WriteString(e.formatIssueAsMarkdown(issue))
-		}
+	if err := e.state.Save(); err != nil {
+		slog.Warn("saving sync state failed", "file", e.config.StateFile, "error", err)
 	}
-
-	return content.String()
 }
 
-// formatIssueAsMarkdown formatiert ein Issue als Markdown
-func (e *Exporter) formatIssueAsMarkdown(issue todoistDomain.Issue) string {
-	var content strings.Builder
-
-	// Title mit Link
-	content.WriteString(fmt.Sprintf("### [#%s - %s](%s)\n\n",
-		issue.IID,
-		utils.EscapeMarkdown(issue.Title),
-		issue.WebURL))
-
-	// Metadata-Tabelle
-	content.WriteString("| Feld | Wert |\n")
-	content.WriteString("|------|------|\n")
-	content.WriteString(fmt.Sprintf("| **Status** | %s |\n", issue.State))
+// progress gibt eine übersetzte Fortschrittsmeldung aus
+func (e *Exporter) progress(key string, args ...interface{}) {
+	logging.Progressf("%s", e.tr.T(key, args...))
+}
 
-	// Due Date
-	if issue.DueDate != nil && *issue.DueDate != "" {
-		content.WriteString(fmt.Sprintf("| **Fällig** | %s |\n",
-			utils.FormatDateForDisplay(*issue.DueDate)))
+// generateFilename erstellt einen Dateinamen
+func (e *Exporter) generateFilename() string {
+	extension := ".md"
+	if format, err := e.outputFormat(); err == nil {
+		extension = format.extension
 	}
 
-	// Assignees
-	if len(issue.Assignees.Nodes) > 0 {
-		var assigneeNames []string
-		for _, assignee := range issue.Assignees.Nodes {
-			assigneeNames = append(assigneeNames, assignee.Name)
-		}
-		content.WriteString(fmt.Sprintf("| **Zugewiesen** | %s |\n",
-			strings.Join(assigneeNames, ", ")))
+	// Der Standard-Dateiname bekommt die Endung des Formats, eigene Namen bleiben unverändert
+	if e.config.OutputFile == config.DefaultOutputFile {
+		return withFormatExtension(e.config.OutputFile, extension)
 	}
-
-	// Labels
-	if len(issue.Labels.Nodes) > 0 {
-		var labelNames []string
-		for _, label := range issue.Labels.Nodes {
-			labelNames = append(labelNames, "`"+label.Title+"`")
-		}
-		content.WriteString(fmt.Sprintf("| **Labels** | %s |\n",
-			strings.Join(labelNames, " ")))
+	if e.config.OutputFile != "" {
+		return e.config.OutputFile
 	}
 
-	content.WriteString("\n")
+	// Standard-Format: project-milestone-YYYY-MM-DD.md
+	timestamp := time.Now().Format("2006-01-02")
+	projectName := strings.ReplaceAll(e.config.ProjectPath, "/", "-")
 
-	// Description
-	if issue.Description != "" {
-		content.WriteString("**Beschreibung:**\n\n")
-		content.WriteString(issue.Description)
-		content.WriteString("\n\n")
+	if e.config.MilestoneTitle != nil && *e.config.MilestoneTitle != "" {
+		milestone := strings.ReplaceAll(*e.config.MilestoneTitle, " ", "-")
+		return fmt.Sprintf("%s-%s-%s%s", projectName, milestone, timestamp, extension)
 	}
 
-	content.WriteString("---\n\n")
-	return content.String()
+	return fmt.Sprintf("%s-%s%s", projectName, timestamp, extension)
 }
 
 // Helper Functions
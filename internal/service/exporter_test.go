@@ -123,7 +123,10 @@ func TestFormatIssueAsMarkdown(t *testing.T) {
 		},
 	}
 
-	result := exporter.formatIssueAsMarkdown(issue)
+	result, err := exporter.formatIssueAsMarkdown(issue)
+	if err != nil {
+		t.Fatalf("formatIssueAsMarkdown() error = %v", err)
+	}
 
 	// ✅ KORREKTE Assertions basierend auf dem echten Code
 	tests := []struct {
@@ -190,7 +193,10 @@ func TestFormatIssueAsMarkdown_MinimalIssue(t *testing.T) {
 		WebURL: "https://gitlab.com/test/simple",
 	}
 
-	result := exporter.formatIssueAsMarkdown(issue)
+	result, err := exporter.formatIssueAsMarkdown(issue)
+	if err != nil {
+		t.Fatalf("formatIssueAsMarkdown() error = %v", err)
+	}
 
 	// Sollte grundlegende Struktur haben
 	expectedContains := []string{
@@ -242,7 +248,10 @@ func TestGenerateMarkdownContent(t *testing.T) {
 		},
 	}
 
-	content := exporter.generateMarkdownContent(issues)
+	content, err := exporter.generateMarkdownContent(issues)
+	if err != nil {
+		t.Fatalf("generateMarkdownContent() error = %v", err)
+	}
 
 	// Header-Checks
 	expectedInHeader := []string{
@@ -277,12 +286,50 @@ func TestGenerateMarkdownContent(t *testing.T) {
 	}
 }
 
+func TestGenerateMarkdownContent_English(t *testing.T) {
+	cfg := &config.Config{ProjectPath: "test/project", Lang: "en"}
+	exporter := NewExporter(cfg)
+
+	dueDate := "2024-02-15"
+	issues := []todoistDomain.Issue{
+		{IID: "1", Title: "Open Issue", State: "opened", DueDate: &dueDate, Description: "Text"},
+		{IID: "2", Title: "Closed Issue", State: "closed"},
+	}
+
+	content, err := exporter.generateMarkdownContent(issues)
+	if err != nil {
+		t.Fatalf("generateMarkdownContent() error = %v", err)
+	}
+
+	expected := []string{
+		"**Export time:**",
+		"**Issue count:** 2",
+		"## 🟢 Open issues",
+		"## ✅ Closed issues",
+		"| Field | Value |",
+		"| **Due** | 2024-02-15 |",
+		"**Description:**",
+	}
+	for _, e := range expected {
+		if !strings.Contains(content, e) {
+			t.Errorf("Englischer Export sollte enthalten: %s", e)
+		}
+	}
+
+	if strings.Contains(content, "Offene Issues") || strings.Contains(content, "Beschreibung") {
+		t.Errorf("Englischer Export enthält deutsche Texte:\n%s", content)
+	}
+}
+
 func TestGenerateMarkdownContent_EmptyIssues(t *testing.T) {
 	exporter := NewExporter(&config.Config{
 		ProjectPath: "empty/project",
 	})
 
-	content := exporter.generateMarkdownContent([]todoistDomain.Issue{})
+	content, err := exporter.generateMarkdownContent([]todoistDomain.Issue{})
+	if err != nil {
+		t.Fatalf("generateMarkdownContent() error = %v", err)
+	}
 
 	expectedContains := []string{
 		"# GitLab Issues Export - empty/project",
@@ -453,6 +500,28 @@ func TestExtractIssueIIDFromContent(t *testing.T) {
 	}
 }
 
+func TestMatchesMilestoneFilter(t *testing.T) {
+	inMilestone := todoistDomain.Issue{IID: "1", Milestone: &todoistDomain.Milestone{Title: "v1.0"}}
+	otherMilestone := todoistDomain.Issue{IID: "2", Milestone: &todoistDomain.Milestone{Title: "v2.0"}}
+	noMilestone := todoistDomain.Issue{IID: "3"}
+
+	all := NewExporter(&config.Config{})
+	star := NewExporter(&config.Config{MilestoneTitle: stringPtr("*")})
+	for _, issue := range []todoistDomain.Issue{inMilestone, otherMilestone, noMilestone} {
+		if !all.matchesMilestoneFilter(issue) || !star.matchesMilestoneFilter(issue) {
+			t.Errorf("issue #%s should match without filter", issue.IID)
+		}
+	}
+
+	filtered := NewExporter(&config.Config{MilestoneTitle: stringPtr("v1.0")})
+	if !filtered.matchesMilestoneFilter(inMilestone) {
+		t.Error("issue in milestone should match")
+	}
+	if filtered.matchesMilestoneFilter(otherMilestone) || filtered.matchesMilestoneFilter(noMilestone) {
+		t.Error("issues outside the milestone should not match")
+	}
+}
+
 // Helper Functions für Tests
 
 func stringPtr(s string) *string {
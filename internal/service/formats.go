@@ -0,0 +1,162 @@
+package service
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+// Unterstützte Dateiformate für den Export (--format)
+const (
+	FormatMarkdown    = "markdown"
+	FormatJSON        = "json"
+	FormatNDJSON      = "ndjson"
+	FormatCSV         = "csv"
+	FormatICS         = "ics"
+	FormatHTML        = "html"
+	FormatObsidian    = "obsidian"
+	FormatTaskwarrior = "taskwarrior"
+	FormatOrg         = "org"
+	FormatTodoTxt     = "todotxt"
+	FormatTodoistCSV  = "todoist-csv"
+	FormatDOT         = "dot"
+	FormatMermaid     = "mermaid"
+)
+
+// outputFormat beschreibt ein Dateiformat des Exports
+type outputFormat struct {
+	// name wird in Fortschrittsmeldungen angezeigt
+	name      string
+	extension string
+	write     func(e *Exporter, w io.Writer, issues []todoistDomain.Issue) error
+	// writeDir ersetzt write bei Formaten, die mehrere Dateien in ein Verzeichnis schreiben
+	writeDir func(e *Exporter, dir string, issues []todoistDomain.Issue) error
+}
+
+// outputFormats ist die Registry aller Dateiformate
+var outputFormats = map[string]outputFormat{
+	FormatMarkdown:    {name: "Markdown", extension: ".md", write: (*Exporter).writeMarkdown},
+	FormatJSON:        {name: "JSON", extension: ".json", write: (*Exporter).writeJSON},
+	FormatNDJSON:      {name: "NDJSON", extension: ".ndjson", write: (*Exporter).writeNDJSON},
+	FormatCSV:         {name: "CSV", extension: ".csv", write: (*Exporter).writeCSV},
+	FormatICS:         {name: "iCalendar", extension: ".ics", write: (*Exporter).writeICS},
+	FormatHTML:        {name: "HTML", extension: ".html", write: (*Exporter).writeHTML},
+	FormatObsidian:    {name: "Obsidian", writeDir: (*Exporter).writeObsidianVault},
+	FormatTaskwarrior: {name: "Taskwarrior", extension: ".json", write: (*Exporter).writeTaskwarrior},
+	FormatOrg:         {name: "Org", extension: ".org", write: (*Exporter).writeOrg},
+	FormatTodoTxt:     {name: "todo.txt", extension: ".txt", write: (*Exporter).writeTodoTxt},
+	FormatTodoistCSV:  {name: "Todoist-CSV", extension: ".csv", write: (*Exporter).writeTodoistCSV},
+	FormatDOT:         {name: "Graphviz", extension: ".dot", write: (*Exporter).writeDOT},
+	FormatMermaid:     {name: "Mermaid", extension: ".mmd", write: (*Exporter).writeMermaid},
+}
+
+// SupportedFormats liefert die Namen aller Dateiformate
+func SupportedFormats() []string {
+	names := make([]string, 0, len(outputFormats))
+	for name := range outputFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatName liefert den Namen des konfigurierten Formats (Standard: Markdown)
+func (e *Exporter) formatName() string {
+	if e.config.Format == "" {
+		return FormatMarkdown
+	}
+	return strings.ToLower(e.config.Format)
+}
+
+// outputFormat liefert das konfigurierte Dateiformat (Standard: Markdown)
+func (e *Exporter) outputFormat() (outputFormat, error) {
+	format, ok := outputFormats[e.formatName()]
+	if !ok {
+		return outputFormat{}, e.tr.Errorf("err.unknown_format", e.config.Format, strings.Join(SupportedFormats(), ", "))
+	}
+	return format, nil
+}
+
+// exportToFile exportiert Issues im konfigurierten Format in eine Datei oder nach stdout ("-")
+func (e *Exporter) exportToFile(issues []todoistDomain.Issue) error {
+	format, err := e.outputFormat()
+	if err != nil {
+		return err
+	}
+
+	e.progress("progress.exporting_file", format.name)
+
+	filename := e.generateFilename()
+	if format.writeDir != nil {
+		return e.exportToDir(format, filename, issues)
+	}
+	return e.writeOutput(filename, len(issues), func(w io.Writer) error {
+		return format.write(e, w, issues)
+	})
+}
+
+// writeOutput schreibt eine Ausgabe nach stdout ("-") oder in eine Datei
+func (e *Exporter) writeOutput(filename string, count int, write func(w io.Writer) error) error {
+	if filename == config.StdoutOutput {
+		if err := write(os.Stdout); err != nil {
+			return e.tr.Errorf("err.file_export", err)
+		}
+		e.progress("progress.stdout_written", count)
+		return nil
+	}
+
+	// Erst vollständig rendern, damit bei Fehlern keine halbe Datei entsteht
+	var content bytes.Buffer
+	if err := write(&content); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filename, content.Bytes(), 0644); err != nil {
+		return e.tr.Errorf("err.file_export", err)
+	}
+
+	e.progress("progress.file_written", filename, count)
+	return nil
+}
+
+// exportToDir exportiert Issues in ein Verzeichnis (z.B. Obsidian-Vault)
+func (e *Exporter) exportToDir(format outputFormat, dir string, issues []todoistDomain.Issue) error {
+	if dir == config.StdoutOutput {
+		return e.tr.Errorf("err.stdout_unsupported", format.name)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return e.tr.Errorf("err.file_export", err)
+	}
+	if err := format.writeDir(e, dir, issues); err != nil {
+		return e.tr.Errorf("err.file_export", err)
+	}
+
+	e.progress("progress.dir_written", dir, len(issues))
+	return nil
+}
+
+// withFormatExtension ersetzt die Endung des Standard-Dateinamens passend zum Format
+func withFormatExtension(filename string, extension string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + extension
+}
+
+// singleLine fasst Text für zeilenbasierte Formate auf eine Zeile zusammen
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// writeMarkdown schreibt den Markdown-Report
+func (e *Exporter) writeMarkdown(w io.Writer, issues []todoistDomain.Issue) error {
+	content, err := e.generateMarkdownContent(issues)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
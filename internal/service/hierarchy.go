@@ -0,0 +1,214 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+// EpicGroup ist ein Epic mit seinen Issues in der Gliederung (Epic nil: Issues ohne Epic)
+type EpicGroup struct {
+	Epic   *todoistDomain.Epic
+	Issues []todoistDomain.Issue
+}
+
+// loadHierarchy ergänzt Epics und untergeordnete Work Items. Epics gibt es nur mit Premium;
+// ohne Lizenz schlägt die Abfrage fehl (Debug-Log) und die Issues bleiben ohne Epic.
+func (e *Exporter) loadHierarchy(issues []todoistDomain.Issue) {
+	epics, err := e.gitlabRepo.GetIssueEpics(e.config.ProjectPath, e.config.MilestoneTitle)
+	if err != nil {
+		slog.Debug("issue epics unavailable", "error", err)
+	}
+
+	iids := make([]string, len(issues))
+	for i, issue := range issues {
+		iids[i] = issue.IID
+	}
+	children, err := e.gitlabRepo.GetWorkItemChildren(e.config.ProjectPath, iids)
+	if err != nil {
+		slog.Warn("loading child work items failed", "error", err)
+	}
+
+	for i := range issues {
+		if epic, ok := epics[issues[i].IID]; ok {
+			issues[i].Epic = &epic
+		}
+		issues[i].Children = children[issues[i].IID]
+	}
+}
+
+// issueHierarchy gruppiert die Issues nach Epic (nach IID sortiert, Issues ohne Epic zuletzt).
+// Issues, die selbst Kind eines anderen Issues sind, erscheinen nur unter diesem.
+func issueHierarchy(issues []todoistDomain.Issue) []EpicGroup {
+	sorted := make([]todoistDomain.Issue, len(issues))
+	copy(sorted, issues)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return iidLess(sorted[i].IID, sorted[j].IID)
+	})
+
+	isChild := make(map[string]bool)
+	for _, issue := range sorted {
+		for _, child := range issue.Children {
+			isChild[child.IID] = true
+		}
+	}
+
+	var groups []EpicGroup
+	index := make(map[string]int)
+	var withoutEpic []todoistDomain.Issue
+	for _, issue := range sorted {
+		if isChild[issue.IID] {
+			continue
+		}
+		if issue.Epic == nil {
+			withoutEpic = append(withoutEpic, issue)
+			continue
+		}
+		reference := epicReference(*issue.Epic)
+		if _, ok := index[reference]; !ok {
+			index[reference] = len(groups)
+			groups = append(groups, EpicGroup{Epic: issue.Epic})
+		}
+		groups[index[reference]].Issues = append(groups[index[reference]].Issues, issue)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return iidLess(groups[i].Epic.IID, groups[j].Epic.IID)
+	})
+	if len(withoutEpic) > 0 {
+		groups = append(groups, EpicGroup{Issues: withoutEpic})
+	}
+	return groups
+}
+
+// hasHierarchy gibt an, ob mindestens ein Issue ein Epic oder Kinder hat
+func hasHierarchy(issues []todoistDomain.Issue) bool {
+	for _, issue := range issues {
+		if issue.Epic != nil || len(issue.Children) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// epicReference liefert die Referenz eines Epics wie "&5"
+func epicReference(epic todoistDomain.Epic) string {
+	if epic.Reference != "" {
+		return epic.Reference
+	}
+	return "&" + epic.IID
+}
+
+// workItemIssue bildet ein untergeordnetes Work Item als Issue ab, damit es wie ein Issue gemappt wird
+func workItemIssue(item todoistDomain.WorkItem) todoistDomain.Issue {
+	return todoistDomain.Issue{IID: item.IID, Title: item.Title, State: item.State, WebURL: item.WebURL}
+}
+
+// childIssue liefert das exportierte Issue zu einem Child-Task. Nur Kinder, die nicht selbst
+// exportiert werden, laufen über workItemIssue; sonst gingen Labels, Fälligkeit und
+// Beschreibung verloren, und der Task wechselte zwischen flachem und --hierarchy-Sync hin und her.
+func childIssue(child todoistDomain.WorkItem, issuesByIID map[string]todoistDomain.Issue) todoistDomain.Issue {
+	if issue, ok := issuesByIID[child.IID]; ok {
+		return issue
+	}
+	return workItemIssue(child)
+}
+
+// syncHierarchy bildet Epics, Issues und Child-Tasks als verschachtelte Tasks ab (--hierarchy):
+// Epic als Parent, Issues als Subtasks, Child-Tasks als Sub-Subtasks.
+func (e *Exporter) syncHierarchy(issues []todoistDomain.Issue, projectID string, sections map[string]string, existingTasks map[string]*todoistDomain.Task, stats *SyncStats) {
+	issuesByIID := make(map[string]todoistDomain.Issue, len(issues))
+	for _, issue := range issues {
+		issuesByIID[issue.IID] = issue
+	}
+
+	for _, group := range issueHierarchy(issues) {
+		// Issues ohne Epic gehören auf die oberste Ebene; schlägt das Epic fehl, bleibt der Parent unverändert
+		root := ""
+		parentID := &root
+		if group.Epic != nil {
+			epicTaskID, err := e.syncEpicTask(*group.Epic, projectID, sections, existingTasks, stats)
+			if err != nil {
+				slog.Warn("syncing epic failed", "epic", epicReference(*group.Epic), "error", err)
+				stats.Failed++
+				parentID = nil
+			} else {
+				parentID = &epicTaskID
+			}
+		}
+
+		for _, issue := range group.Issues {
+			taskID, err := e.syncIssueTask(issue, parentID, projectID, sections, existingTasks, stats)
+			if err != nil {
+				slog.Warn("syncing issue failed", "issue", issue.IID, "error", err)
+				stats.Failed++
+				continue
+			}
+
+			for _, child := range issue.Children {
+				if _, err := e.syncIssueTask(childIssue(child, issuesByIID), &taskID, projectID, sections, existingTasks, stats); err != nil {
+					slog.Warn("syncing child task failed", "issue", issue.IID, "child", child.IID, "error", err)
+					stats.Failed++
+				}
+			}
+		}
+	}
+}
+
+// syncEpicTask legt den Task eines Epics an oder aktualisiert ihn und liefert seine ID.
+// Epic-Tasks werden nicht im Sync-Zustand vermerkt, damit Todoist-Webhooks sie nicht für Issues halten.
+func (e *Exporter) syncEpicTask(epic todoistDomain.Epic, projectID string, sections map[string]string, existingTasks map[string]*todoistDomain.Task, stats *SyncStats) (string, error) {
+	reference := epicReference(epic)
+	request := e.mapper.EpicToTodoistTask(epic, projectID, sections)
+
+	existingTask := existingTasks[reference]
+	if existingTask == nil {
+		createdTask, err := e.todoistRepo.CreateTask(request)
+		if err != nil {
+			return "", e.tr.Errorf("err.task_create", err)
+		}
+		slog.Info("epic task created", "epic", reference, "title", epic.Title, "task_id", createdTask.ID)
+		existingTasks[reference] = createdTask
+		stats.Created++
+		return createdTask.ID, nil
+	}
+
+	updates := make(map[string]interface{})
+	if existingTask.Content != request.Content {
+		updates["content"] = request.Content
+	}
+	if existingTask.Description != request.Description {
+		updates["description"] = request.Description
+	}
+	if existingTask.SectionID != request.SectionID && request.SectionID != "" {
+		updates["section_id"] = request.SectionID
+	}
+	if len(updates) == 0 {
+		stats.Skipped++
+		return existingTask.ID, nil
+	}
+
+	if _, err := e.todoistRepo.UpdateTask(existingTask.ID, updates); err != nil {
+		return "", e.tr.Errorf("err.task_update", err)
+	}
+	slog.Info("epic task updated", "epic", reference, "title", epic.Title, "task_id", existingTask.ID)
+	stats.Updated++
+	return existingTask.ID, nil
+}
+
+// epicTaskContent liefert den Task-Titel eines Epics, z.B. "&5 - Login"
+func epicTaskContent(epic todoistDomain.Epic) string {
+	return fmt.Sprintf("%s - %s", epicReference(epic), epic.Title)
+}
+
+// extractEpicReferenceFromContent liefert die Epic-Referenz aus dem Task-Content (Format: "&5 - Title")
+func extractEpicReferenceFromContent(content string) string {
+	reference, _, found := strings.Cut(content, " - ")
+	if !found || !strings.Contains(reference, "&") || strings.ContainsAny(reference, " #") {
+		return ""
+	}
+	return reference
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	domain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+func hierarchyTestIssues() []domain.Issue {
+	login := &domain.Epic{IID: "5", Title: "Login", State: "opened", WebURL: "e5", Reference: "&5"}
+	billing := &domain.Epic{IID: "2", Title: "Billing", State: "closed", WebURL: "e2", Reference: "&2"}
+	return []domain.Issue{
+		{IID: "12", Title: "SSO", State: "opened", WebURL: "u12", Epic: login, Children: []domain.WorkItem{
+			{IID: "30", Title: "Tests", State: "closed", WebURL: "u30", Type: "Task"},
+			{IID: "31", Title: "Docs", State: "opened", WebURL: "u31", Type: "Task"},
+		}},
+		{IID: "3", Title: "Invoices", State: "closed", WebURL: "u3", Epic: billing},
+		{IID: "30", Title: "Tests", State: "closed", WebURL: "u30"},
+		{IID: "4", Title: "Password reset", State: "opened", WebURL: "u4", Epic: login},
+		{IID: "7", Title: "Typo", State: "opened", WebURL: "u7"},
+	}
+}
+
+func TestIssueHierarchy_GroupsByEpic(t *testing.T) {
+	groups := issueHierarchy(hierarchyTestIssues())
+
+	var got []string
+	for _, group := range groups {
+		name := "-"
+		if group.Epic != nil {
+			name = group.Epic.Reference
+		}
+		var iids []string
+		for _, issue := range group.Issues {
+			iids = append(iids, issue.IID)
+		}
+		got = append(got, name+":"+strings.Join(iids, ","))
+	}
+	// #30 ist ein Child-Task von #12 und erscheint nur dort
+	if strings.Join(got, " ") != "&2:3 &5:4,12 -:7" {
+		t.Errorf("unexpected hierarchy: %v", got)
+	}
+
+	if hasHierarchy([]domain.Issue{{IID: "1"}}) || !hasHierarchy(hierarchyTestIssues()) {
+		t.Error("hasHierarchy should detect epics and children")
+	}
+}
+
+func TestChildIssue_PrefersExportedIssue(t *testing.T) {
+	issues := hierarchyTestIssues()
+	issues[2].Labels = domain.Labels{Nodes: []domain.Label{{Title: "test"}}}
+	due := "2024-03-01"
+	issues[2].DueDate = &due
+
+	byIID := make(map[string]domain.Issue)
+	for _, issue := range issues {
+		byIID[issue.IID] = issue
+	}
+
+	// #30 wird auch exportiert: der Child-Task behält Labels und Fälligkeit
+	tests := issues[0].Children[0]
+	if got := childIssue(tests, byIID); got.DueDate == nil || *got.DueDate != due || len(got.Labels.Nodes) != 1 {
+		t.Errorf("exported child should keep its fields: %+v", got)
+	}
+
+	docs := issues[0].Children[1]
+	if got := childIssue(docs, byIID); got.IID != "31" || got.Title != "Docs" || got.WebURL != "u31" {
+		t.Errorf("child outside the export should be mapped from the work item: %+v", got)
+	}
+}
+
+func TestLoadHierarchy_WithoutPremium(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if !strings.Contains(body["query"], "WorkItemWidgetHierarchy") {
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Field 'epic' doesn't exist on type 'Issue'"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"project":{"work_items":{"nodes":[{"iid":"1","widgets":[{"children":{"nodes":[` +
+			`{"iid":"9","title":"Tests","state":"OPEN","web_url":"u9","work_item_type":{"name":"Task"}}]}}]}]}}}}`))
+	}))
+	defer srv.Close()
+
+	exporter := NewExporter(&config.Config{GitLabToken: "t", GitLabURL: srv.URL, ProjectPath: "g/p"})
+	issues := []domain.Issue{{IID: "1"}, {IID: "2"}}
+	exporter.loadHierarchy(issues)
+
+	if issues[0].Epic != nil || len(issues[0].Children) != 1 || issues[0].Children[0].IID != "9" || issues[1].Children != nil {
+		t.Errorf("children should be loaded even without epics: %+v", issues)
+	}
+}
+
+func TestDefaultTemplate_HierarchyOutline(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: "en", Hierarchy: true})
+	content, err := exporter.generateMarkdownContent(hierarchyTestIssues())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "## 🗂️ Hierarchy\n\n" +
+		"- 🗂️ **[&2 Billing](e2)** ✅\n" +
+		"  - [x] [#3 - Invoices](u3)\n" +
+		"- 🗂️ **[&5 Login](e5)**\n" +
+		"  - [ ] [#4 - Password reset](u4)\n" +
+		"  - [ ] [#12 - SSO](u12)\n" +
+		"    - [x] [#30 - Tests](u30)\n" +
+		"    - [ ] [#31 - Docs](u31)\n" +
+		"- No epic\n" +
+		"  - [ ] [#7 - Typo](u7)\n\n## 🟢 Open issues"
+	if !strings.Contains(content, want) {
+		t.Errorf("markdown should contain the outline:\n%s", content)
+	}
+
+	exporter.config.Hierarchy = false
+	content, _ = exporter.generateMarkdownContent(hierarchyTestIssues())
+	if strings.Contains(content, "Hierarchy") {
+		t.Errorf("outline should only be rendered with --hierarchy:\n%s", content)
+	}
+}
+
+func TestEpicTask_ContentAndMapping(t *testing.T) {
+	epic := domain.Epic{IID: "5", Title: "Login", State: "closed", WebURL: "e5"}
+	task := NewMapper(&config.Config{}).EpicToTodoistTask(epic, "p1", map[string]string{"open": "s1", "closed": "s2"})
+
+	if task.Content != "&5 - Login" || task.SectionID != "s2" || task.ProjectID != "p1" ||
+		task.Description != "🗂️ [GitLab Epic &5](e5)" || len(task.Labels) != 1 || task.Labels[0] != "epic" {
+		t.Errorf("unexpected epic task: %+v", task)
+	}
+
+	tests := map[string]string{
+		"&5 - Login":           "&5",
+		"group&12 - Billing":   "group&12",
+		"#12 - Fix & improve":  "",
+		"Buy milk & eggs":      "",
+		"Tom & Jerry - Review": "",
+	}
+	for content, want := range tests {
+		if got := extractEpicReferenceFromContent(content); got != want {
+			t.Errorf("extractEpicReferenceFromContent(%q) = %q, want %q", content, got, want)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	historyRepo "hufschlaeger.net/gitlab-tasks-exporter/internal/repository/history"
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+// saveSnapshot legt die normalisierten Issues eines Laufs in der Historie ab.
+// Fehler brechen den Export nicht ab, da die Historie nur für Auswertungen dient.
+func (e *Exporter) saveSnapshot(issues []todoistDomain.Issue) {
+	if e.history == nil {
+		return
+	}
+
+	document := todoistDomain.ExportDocument{
+		SchemaVersion: todoistDomain.ExportSchemaVersion,
+		Metadata:      e.exportMetadata(issues),
+		Issues:        make([]todoistDomain.NormalizedIssue, 0, len(issues)),
+	}
+	for _, issue := range issues {
+		document.Issues = append(document.Issues, normalizeIssue(issue))
+	}
+
+	path, saved, err := e.history.Save(document)
+	if err != nil {
+		slog.Warn("saving snapshot failed", "dir", e.config.HistoryDir, "error", err)
+		return
+	}
+	slog.Debug("snapshot", "path", path, "saved", saved)
+}
+
+// Diff vergleicht zwei Snapshots der Historie und schreibt die Änderungen nach stdout
+func (e *Exporter) Diff() error {
+	return e.writeDiff(os.Stdout)
+}
+
+func (e *Exporter) writeDiff(w io.Writer) error {
+	format := strings.ToLower(e.config.Format)
+	if format == "" {
+		format = FormatMarkdown
+	}
+	if format != FormatMarkdown && format != FormatJSON {
+		return e.tr.Errorf("err.unknown_format", e.config.Format, FormatJSON+", "+FormatMarkdown)
+	}
+
+	fromPath, toPath, err := e.diffPaths()
+	if err != nil {
+		return err
+	}
+
+	e.progress("progress.diff_compare", fromPath, toPath)
+
+	from, err := historyRepo.Load(fromPath)
+	if err != nil {
+		return e.tr.Errorf("err.snapshot_load", err)
+	}
+	to, err := historyRepo.Load(toPath)
+	if err != nil {
+		return e.tr.Errorf("err.snapshot_load", err)
+	}
+
+	diff := diffSnapshots(from, to)
+	if format == FormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diff)
+	}
+	_, err = io.WriteString(w, e.renderDiffMarkdown(diff))
+	return err
+}
+
+// diffPaths bestimmt die zu vergleichenden Snapshots. Ohne Angaben werden die beiden neuesten
+// verglichen; --from/--to akzeptieren einen Dateipfad oder ein Datum (letzter Snapshot bis zu diesem Tag).
+func (e *Exporter) diffPaths() (string, string, error) {
+	var snapshots []historyRepo.Snapshot
+	if e.history != nil && e.config.ProjectPath != "" {
+		var err error
+		if snapshots, err = e.history.List(e.config.ProjectPath, e.exportFilters()); err != nil {
+			return "", "", e.tr.Errorf("err.snapshot_load", err)
+		}
+	}
+
+	toPath, err := e.resolveSnapshot(e.config.DiffTo, snapshots, len(snapshots))
+	if err != nil {
+		return "", "", err
+	}
+
+	// Standard für --from: der Snapshot vor --to
+	limit := len(snapshots)
+	for i, snapshot := range snapshots {
+		if snapshot.Path == toPath {
+			limit = i
+		}
+	}
+	fromPath, err := e.resolveSnapshot(e.config.DiffFrom, snapshots, limit)
+	if err != nil {
+		return "", "", err
+	}
+	return fromPath, toPath, nil
+}
+
+// resolveSnapshot löst eine Angabe von --from/--to auf; ohne Angabe gilt der neueste Snapshot vor limit
+func (e *Exporter) resolveSnapshot(ref string, snapshots []historyRepo.Snapshot, limit int) (string, error) {
+	if ref == "" {
+		if limit == 0 {
+			return "", e.tr.Errorf("err.history_not_enough", e.config.ProjectPath, e.config.HistoryDir)
+		}
+		return snapshots[limit-1].Path, nil
+	}
+
+	if _, err := os.Stat(ref); err == nil {
+		return ref, nil
+	}
+
+	day, err := time.ParseInLocation(releaseDateLayout, ref, time.Local)
+	if err != nil {
+		return "", e.tr.Errorf("err.snapshot_not_found", ref)
+	}
+	end := day.AddDate(0, 0, 1)
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if snapshots[i].TakenAt.Before(end) {
+			return snapshots[i].Path, nil
+		}
+	}
+	return "", e.tr.Errorf("err.snapshot_not_found", ref)
+}
+
+// diffSnapshots ermittelt neue, geschlossene, wieder geöffnete und geänderte Issues
+func diffSnapshots(from, to todoistDomain.ExportDocument) todoistDomain.SnapshotDiff {
+	diff := todoistDomain.SnapshotDiff{
+		SchemaVersion: todoistDomain.ExportSchemaVersion,
+		From:          from.Metadata,
+		To:            to.Metadata,
+		New:           []todoistDomain.IssueRef{},
+		Closed:        []todoistDomain.IssueRef{},
+		Reopened:      []todoistDomain.IssueRef{},
+		Retitled:      []todoistDomain.IssueChange{},
+		Relabelled:    []todoistDomain.ListChange{},
+		Reassigned:    []todoistDomain.ListChange{},
+		DueChanged:    []todoistDomain.IssueChange{},
+		Removed:       []todoistDomain.IssueRef{},
+	}
+
+	previous := make(map[string]todoistDomain.NormalizedIssue, len(from.Issues))
+	for _, issue := range from.Issues {
+		previous[issue.IID] = issue
+	}
+	current := make(map[string]bool, len(to.Issues))
+
+	for _, issue := range sortedNormalizedIssues(to.Issues) {
+		current[issue.IID] = true
+		ref := issueRef(issue)
+
+		old, ok := previous[issue.IID]
+		if !ok {
+			diff.New = append(diff.New, ref)
+			continue
+		}
+
+		switch {
+		case old.State != "closed" && issue.State == "closed":
+			diff.Closed = append(diff.Closed, ref)
+		case old.State == "closed" && issue.State != "closed":
+			diff.Reopened = append(diff.Reopened, ref)
+		}
+		if old.Title != issue.Title {
+			diff.Retitled = append(diff.Retitled, todoistDomain.IssueChange{IssueRef: ref, Before: old.Title, After: issue.Title})
+		}
+		if added, removed := listChanges(old.Labels, issue.Labels); len(added)+len(removed) > 0 {
+			diff.Relabelled = append(diff.Relabelled, todoistDomain.ListChange{IssueRef: ref, Added: added, Removed: removed})
+		}
+		if added, removed := listChanges(old.Assignees, issue.Assignees); len(added)+len(removed) > 0 {
+			diff.Reassigned = append(diff.Reassigned, todoistDomain.ListChange{IssueRef: ref, Added: added, Removed: removed})
+		}
+		if old.DueDate != issue.DueDate {
+			diff.DueChanged = append(diff.DueChanged, todoistDomain.IssueChange{IssueRef: ref, Before: old.DueDate, After: issue.DueDate})
+		}
+	}
+
+	// Nicht mehr enthalten, z.B. gelöscht, verschoben oder aus dem Milestone entfernt
+	for _, issue := range sortedNormalizedIssues(from.Issues) {
+		if !current[issue.IID] {
+			diff.Removed = append(diff.Removed, issueRef(issue))
+		}
+	}
+
+	return diff
+}
+
+// renderDiffMarkdown erzeugt einen Bericht für z.B. das wöchentliche Sync-Meeting
+func (e *Exporter) renderDiffMarkdown(diff todoistDomain.SnapshotDiff) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", e.tr.T("diff.title", diff.To.Project))
+	fmt.Fprintf(&b, "**%s:** %s  \n", e.tr.T("diff.from"), diff.From.ExportedAt.Local().Format(e.tr.T("datetime.layout")))
+	fmt.Fprintf(&b, "**%s:** %s  \n", e.tr.T("diff.to"), diff.To.ExportedAt.Local().Format(e.tr.T("datetime.layout")))
+
+	if diff.Empty() {
+		fmt.Fprintf(&b, "\n%s\n", e.tr.T("diff.no_changes"))
+		return b.String()
+	}
+
+	refs := func(key string, issues []todoistDomain.IssueRef) {
+		if len(issues) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", e.tr.T(key), len(issues))
+		for _, issue := range issues {
+			fmt.Fprintf(&b, "- %s\n", diffIssueLink(issue))
+		}
+	}
+	changes := func(key string, issues []todoistDomain.IssueChange, format func(string) string) {
+		if len(issues) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", e.tr.T(key), len(issues))
+		for _, change := range issues {
+			fmt.Fprintf(&b, "- %s: %s → %s\n", diffIssueLink(change.IssueRef), format(change.Before), format(change.After))
+		}
+	}
+	lists := func(key string, issues []todoistDomain.ListChange) {
+		if len(issues) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", e.tr.T(key), len(issues))
+		for _, change := range issues {
+			var parts []string
+			for _, value := range change.Added {
+				parts = append(parts, "+`"+value+"`")
+			}
+			for _, value := range change.Removed {
+				parts = append(parts, "−`"+value+"`")
+			}
+			fmt.Fprintf(&b, "- %s: %s\n", diffIssueLink(change.IssueRef), strings.Join(parts, " "))
+		}
+	}
+
+	refs("diff.new", diff.New)
+	refs("diff.closed", diff.Closed)
+	refs("diff.reopened", diff.Reopened)
+	changes("diff.retitled", diff.Retitled, func(title string) string { return utils.EscapeMarkdown(singleLine(title)) })
+	lists("diff.relabelled", diff.Relabelled)
+	lists("diff.reassigned", diff.Reassigned)
+	changes("diff.due_changed", diff.DueChanged, e.tr.FormatDate)
+	refs("diff.removed", diff.Removed)
+
+	return b.String()
+}
+
+// diffIssueLink erzeugt z.B. "[#12 - Login schlägt fehl](https://...)"
+func diffIssueLink(issue todoistDomain.IssueRef) string {
+	return fmt.Sprintf("[#%s - %s](%s)", issue.IID, utils.EscapeMarkdown(singleLine(issue.Title)), issue.WebURL)
+}
+
+func issueRef(issue todoistDomain.NormalizedIssue) todoistDomain.IssueRef {
+	return todoistDomain.IssueRef{IID: issue.IID, Title: issue.Title, WebURL: issue.WebURL}
+}
+
+// listChanges liefert hinzugekommene und entfallene Werte in der Reihenfolge der jeweiligen Liste
+func listChanges(before, after []string) (added, removed []string) {
+	added, removed = []string{}, []string{}
+	contains := func(values []string, value string) bool {
+		for _, v := range values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, value := range after {
+		if !contains(before, value) {
+			added = append(added, value)
+		}
+	}
+	for _, value := range before {
+		if !contains(after, value) {
+			removed = append(removed, value)
+		}
+	}
+	return added, removed
+}
+
+func sortedNormalizedIssues(issues []todoistDomain.NormalizedIssue) []todoistDomain.NormalizedIssue {
+	sorted := make([]todoistDomain.NormalizedIssue, len(issues))
+	copy(sorted, issues)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return iidLess(sorted[i].IID, sorted[j].IID)
+	})
+	return sorted
+}
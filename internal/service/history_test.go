@@ -0,0 +1,175 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	historyRepo "hufschlaeger.net/gitlab-tasks-exporter/internal/repository/history"
+)
+
+func diffTestDocuments() (todoistDomain.ExportDocument, todoistDomain.ExportDocument) {
+	from := todoistDomain.ExportDocument{
+		Metadata: todoistDomain.ExportMetadata{Project: "g/p", ExportedAt: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)},
+		Issues: []todoistDomain.NormalizedIssue{
+			{IID: "1", Title: "Login", State: "opened", WebURL: "u1", Labels: []string{"bug", "wip"}, Assignees: []string{"A"}, DueDate: "2024-03-05"},
+			{IID: "2", Title: "Export", State: "closed", WebURL: "u2", Labels: []string{}, Assignees: []string{}},
+			{IID: "3", Title: "Gone", State: "opened", WebURL: "u3", Labels: []string{}, Assignees: []string{}},
+		},
+	}
+	to := todoistDomain.ExportDocument{
+		Metadata: todoistDomain.ExportMetadata{Project: "g/p", ExportedAt: time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)},
+		Issues: []todoistDomain.NormalizedIssue{
+			{IID: "10", Title: "New *one*", State: "opened", WebURL: "u10", Labels: []string{}, Assignees: []string{}},
+			{IID: "2", Title: "Export", State: "opened", WebURL: "u2", Labels: []string{}, Assignees: []string{}},
+			{IID: "1", Title: "Login fails", State: "closed", WebURL: "u1", Labels: []string{"bug", "review"}, Assignees: []string{"B"}, DueDate: "2024-03-12"},
+		},
+	}
+	return from, to
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	diff := diffSnapshots(diffTestDocuments())
+
+	check := func(name string, got interface{}, want string) {
+		t.Helper()
+		encoded, _ := json.Marshal(got)
+		if string(encoded) != want {
+			t.Errorf("%s = %s, want %s", name, encoded, want)
+		}
+	}
+
+	check("new", diff.New, `[{"iid":"10","title":"New *one*","web_url":"u10"}]`)
+	check("closed", diff.Closed, `[{"iid":"1","title":"Login fails","web_url":"u1"}]`)
+	check("reopened", diff.Reopened, `[{"iid":"2","title":"Export","web_url":"u2"}]`)
+	check("retitled", diff.Retitled, `[{"iid":"1","title":"Login fails","web_url":"u1","before":"Login","after":"Login fails"}]`)
+	check("relabelled", diff.Relabelled, `[{"iid":"1","title":"Login fails","web_url":"u1","added":["review"],"removed":["wip"]}]`)
+	check("reassigned", diff.Reassigned, `[{"iid":"1","title":"Login fails","web_url":"u1","added":["B"],"removed":["A"]}]`)
+	check("due_changed", diff.DueChanged, `[{"iid":"1","title":"Login fails","web_url":"u1","before":"2024-03-05","after":"2024-03-12"}]`)
+	check("removed", diff.Removed, `[{"iid":"3","title":"Gone","web_url":"u3"}]`)
+
+	from, _ := diffTestDocuments()
+	if unchanged := diffSnapshots(from, from); !unchanged.Empty() {
+		t.Errorf("identical snapshots should not differ: %+v", unchanged)
+	}
+}
+
+func TestRenderDiffMarkdown(t *testing.T) {
+	exporter := NewExporter(&config.Config{Lang: "en"})
+	got := exporter.renderDiffMarkdown(diffSnapshots(diffTestDocuments()))
+
+	for _, want := range []string{
+		"# Changes in g/p\n",
+		"## 🆕 New (1)\n\n- [#10 - New \\*one\\*](u10)\n",
+		"## ✏️ Retitled (1)\n\n- [#1 - Login fails](u1): Login → Login fails\n",
+		"## 🏷️ Labels changed (1)\n\n- [#1 - Login fails](u1): +`review` −`wip`\n",
+		"## 📅 Due date changed (1)\n\n- [#1 - Login fails](u1): 2024-03-05 → 2024-03-12\n",
+		"## 🗑️ No longer included (1)\n\n- [#3 - Gone](u3)\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown should contain %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestDiff_UsesHistory(t *testing.T) {
+	dir := t.TempDir()
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", HistoryDir: dir, Format: FormatJSON})
+
+	var buf bytes.Buffer
+	if err := exporter.writeDiff(&buf); err == nil || !strings.Contains(err.Error(), "g/p") {
+		t.Fatalf("expected error without snapshots, got %v", err)
+	}
+
+	from, to := diffTestDocuments()
+	for _, document := range []todoistDomain.ExportDocument{from, to} {
+		if _, _, err := exporter.history.Save(document); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := exporter.writeDiff(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var diff todoistDomain.SnapshotDiff
+	if err := json.Unmarshal(buf.Bytes(), &diff); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(diff.New) != 1 || len(diff.Removed) != 1 || !diff.From.ExportedAt.Equal(from.Metadata.ExportedAt) {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+
+	// Ein Datum wählt den letzten Snapshot bis zu diesem Tag
+	exporter.config.DiffTo = "2024-03-07"
+	if _, _, err := exporter.diffPaths(); err == nil {
+		t.Error("only one snapshot before --to, --from should be missing")
+	}
+	exporter.config.DiffFrom = "2024-02-01"
+	if _, _, err := exporter.diffPaths(); err == nil || !strings.Contains(err.Error(), "2024-02-01") {
+		t.Errorf("expected snapshot_not_found, got %v", err)
+	}
+}
+
+func TestSaveSnapshot_SkipsUnchanged(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", HistoryDir: t.TempDir()})
+
+	exporter.saveSnapshot(templateTestIssues())
+	exporter.saveSnapshot(templateTestIssues())
+
+	snapshots, err := exporter.history.List("g/p", todoistDomain.ExportFilters{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 {
+		t.Errorf("expected one snapshot, got %d", len(snapshots))
+	}
+}
+
+func TestRun_SnapshotPerFilterAfterFiltering(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/graphql":
+			_, _ = w.Write([]byte(`{"data":{"project":{"issues":{"nodes":[` +
+				`{"iid":"1","title":"Blocked","state":"opened"},{"iid":"2","title":"Free","state":"opened"}]}}}}`))
+		case r.URL.EscapedPath() == "/api/v4/projects/g%2Fp/issues/1/links":
+			_, _ = w.Write([]byte(`[{"iid":3,"title":"API","state":"opened","web_url":"u3","references":{"full":"g/p#3"},"link_type":"is_blocked_by"}]`))
+		case strings.HasSuffix(r.URL.Path, "/links"):
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	cfg := &config.Config{
+		GitLabToken: "t", GitLabURL: srv.URL, ProjectPath: "g/p", HistoryDir: filepath.Join(dir, "history"),
+		Format: FormatJSON, OutputFile: filepath.Join(dir, "issues.json"), OnlyUnblocked: true,
+	}
+	if _, err := NewExporter(cfg).Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", HistoryDir: cfg.HistoryDir})
+	if unfiltered, err := exporter.history.List("g/p", todoistDomain.ExportFilters{}); err != nil || len(unfiltered) != 0 {
+		t.Fatalf("filtered run must not write into the unfiltered history: %v, %v", unfiltered, err)
+	}
+	snapshots, err := exporter.history.List("g/p", todoistDomain.ExportFilters{OnlyUnblocked: true})
+	if err != nil || len(snapshots) != 1 {
+		t.Fatalf("expected one snapshot for --only-unblocked, got %v, %v", snapshots, err)
+	}
+	document, err := historyRepo.Load(snapshots[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(document.Issues) != 1 || document.Issues[0].IID != "2" || !document.Metadata.Filters.OnlyUnblocked {
+		t.Errorf("snapshot should hold the filtered issues, got %+v", document)
+	}
+}
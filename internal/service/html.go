@@ -0,0 +1,110 @@
+package service
+
+import (
+	_ "embed"
+	"hash/fnv"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+//go:embed templates/report.html.tmpl
+var htmlReportTemplate string
+
+//go:embed templates/report.css
+var htmlReportCSS string
+
+//go:embed templates/report.js
+var htmlReportJS string
+
+// labelChipColors ist die Anzahl der Farben für Label-Chips (.chip-0 bis .chip-7 in report.css)
+const labelChipColors = 8
+
+// HTMLReportData ist das Datenmodell des HTML-Reports. Es erweitert das Markdown-Datenmodell
+// um die Gruppen des Reports sowie eingebettetes CSS und JavaScript.
+type HTMLReportData struct {
+	MarkdownData
+	Groups []IssueGroup
+	CSS    template.CSS
+	JS     template.JS
+}
+
+// writeHTML schreibt einen eigenständigen HTML-Report mit Filter, Suche und sortierbaren Tabellen
+func (e *Exporter) writeHTML(w io.Writer, issues []todoistDomain.Issue) error {
+	tmpl, err := template.New("report").Funcs(e.htmlTemplateFuncs()).Parse(htmlReportTemplate)
+	if err != nil {
+		return e.tr.Errorf("err.html_template", err)
+	}
+
+	data := HTMLReportData{
+		MarkdownData: e.buildMarkdownData(issues),
+		Groups:       e.reportGroups(issues),
+		CSS:          template.CSS(htmlReportCSS),
+		JS:           template.JS(htmlReportJS),
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return e.tr.Errorf("err.template_render", err)
+	}
+	return nil
+}
+
+// reportGroups gruppiert wie der Markdown-Report: erst offene, dann geschlossene Issues
+func (e *Exporter) reportGroups(issues []todoistDomain.Issue) []IssueGroup {
+	var groups []IssueGroup
+	if open := filterIssuesByState(issues, "opened"); len(open) > 0 {
+		groups = append(groups, IssueGroup{Name: e.tr.T("md.open_issues"), Issues: open})
+	}
+	if closed := filterIssuesByState(issues, "closed"); len(closed) > 0 {
+		groups = append(groups, IssueGroup{Name: e.tr.T("md.closed_issues"), Issues: closed})
+	}
+	return groups
+}
+
+// htmlTemplateFuncs liefert die Hilfsfunktionen des HTML-Templates
+func (e *Exporter) htmlTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"t": e.tr.T,
+		"date": func(date string) string {
+			return e.tr.FormatDate(date)
+		},
+		"datetime": func(t time.Time) string {
+			return t.Format(e.tr.T("datetime.layout"))
+		},
+		"join": func(sep string, items []string) string {
+			return strings.Join(items, sep)
+		},
+		"due":       issueDueDate,
+		"duration":  formatTimeTracking,
+		"assignees": assigneeNames,
+		"labels":    labelTitles,
+		// Die Beschreibung wird von MarkdownToHTML vollständig escaped und ist daher sicher
+		"markdown": func(text string) template.HTML {
+			return template.HTML(utils.MarkdownToHTML(text))
+		},
+		"chipColor":  labelChipColor,
+		"searchText": issueSearchText,
+	}
+}
+
+// labelChipColor ordnet einem Label stabil eine der Chip-Farben zu
+func labelChipColor(label string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(label))
+	return int(h.Sum32() % labelChipColors)
+}
+
+// issueSearchText enthält alle Felder, die die Suche im Report durchsucht
+func issueSearchText(issue todoistDomain.Issue) string {
+	parts := []string{"#" + issue.IID, issue.Title}
+	parts = append(parts, labelTitles(issue)...)
+	parts = append(parts, assigneeNames(issue)...)
+	if issue.Milestone != nil {
+		parts = append(parts, issue.Milestone.Title)
+	}
+	return strings.ToLower(strings.Join(parts, " "))
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+)
+
+func TestWriteHTML_SelfContainedReport(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", MilestoneTitle: stringPtr("v1")})
+
+	issues := templateTestIssues()
+	issues[0].Title = `<script>alert("title")</script>`
+	issues[0].Description = "**Wichtig**\n\n<img src=x onerror=alert(1)>"
+
+	var buf bytes.Buffer
+	if err := exporter.writeHTML(&buf, issues); err != nil {
+		t.Fatal(err)
+	}
+	content := buf.String()
+
+	for _, want := range []string{
+		`<html lang="de">`,
+		"<title>GitLab Issues Export - g/p</title>",
+		"<dd>v1</dd>",
+		"🟢 Offene Issues",
+		"✅ Geschlossene Issues",
+		`<tr data-state="opened"`,
+		`<tr data-state="closed"`,
+		`<td data-value="2024-02-15">15.02.2024</td>`,
+		`<td>A, B</td>`,
+		`data-label="bug">bug</span>`,
+		"<strong>Wichtig</strong>",
+		"table.issues th",
+		"function applyFilter",
+		`id="search"`,
+		`id="state-filter"`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("missing %q in report", want)
+		}
+	}
+
+	for _, forbidden := range []string{"<script>alert", "<img", "http://", "https://"} {
+		if strings.Contains(content, forbidden) {
+			t.Errorf("report must not contain %q", forbidden)
+		}
+	}
+
+	// Geschlossene Issues folgen auf offene, wie im Markdown-Report
+	if strings.Index(content, "Offene Issues") > strings.Index(content, "Geschlossene Issues") {
+		t.Error("open issues should be listed before closed issues")
+	}
+}
+
+func TestLabelChipColor_IsStable(t *testing.T) {
+	if labelChipColor("bug") != labelChipColor("bug") {
+		t.Error("chip colour must be deterministic")
+	}
+	for _, label := range []string{"bug", "feature", "high", "Ä"} {
+		if c := labelChipColor(label); c < 0 || c >= labelChipColors {
+			t.Errorf("colour %d out of range for %q", c, label)
+		}
+	}
+}
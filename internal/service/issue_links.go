@@ -0,0 +1,69 @@
+package service
+
+import (
+	"log/slog"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+// issueLinksEnabled gibt an, ob die Verknüpfungen der Issues geladen werden müssen
+// (--issue-links, --only-unblocked oder ein Graph-Format)
+func (e *Exporter) issueLinksEnabled() bool {
+	if e.config.IssueLinks || e.config.OnlyUnblocked {
+		return true
+	}
+	if e.config.TodoistAPI {
+		return false
+	}
+	format := e.formatName()
+	return format == FormatDOT || format == FormatMermaid
+}
+
+// loadIssueLinks ergänzt die verknüpften Issues. GitLab liefert sie nur einzeln je Issue;
+// schlägt ein Abruf fehl, bleibt das Issue ohne Verknüpfungen.
+func (e *Exporter) loadIssueLinks(issues []todoistDomain.Issue) {
+	for i := range issues {
+		links, err := e.gitlabRepo.GetIssueLinks(e.config.ProjectPath, issues[i].IID)
+		if err != nil {
+			slog.Warn("loading issue links failed", "iid", issues[i].IID, "error", err)
+			continue
+		}
+		issues[i].Links = links
+	}
+}
+
+// blockedBy liefert die offenen Issues, die ein offenes Issue blockieren
+func blockedBy(issue todoistDomain.Issue) []todoistDomain.IssueLink {
+	if issue.State == "closed" {
+		return nil
+	}
+	var blockers []todoistDomain.IssueLink
+	for _, link := range issue.Links {
+		if link.LinkType == todoistDomain.LinkIsBlockedBy && link.State != "closed" {
+			blockers = append(blockers, link)
+		}
+	}
+	return blockers
+}
+
+// filterUnblocked entfernt blockierte Issues (--only-unblocked)
+func (e *Exporter) filterUnblocked(issues []todoistDomain.Issue) []todoistDomain.Issue {
+	var unblocked []todoistDomain.Issue
+	for _, issue := range issues {
+		if len(blockedBy(issue)) == 0 {
+			unblocked = append(unblocked, issue)
+		}
+	}
+	if skipped := len(issues) - len(unblocked); skipped > 0 {
+		e.progress("progress.blocked_skipped", skipped)
+	}
+	return unblocked
+}
+
+// linkReference liefert die Referenz eines verknüpften Issues, im eigenen Projekt kurz als "#12"
+func linkReference(projectPath string, link todoistDomain.IssueLink) string {
+	if link.Reference == "" || link.Reference == projectPath+"#"+link.IID {
+		return "#" + link.IID
+	}
+	return link.Reference
+}
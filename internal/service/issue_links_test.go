@@ -0,0 +1,88 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	domain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+func TestLoadIssueLinks_AndFilterUnblocked(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/g%2Fp/issues/1/links":
+			_, _ = w.Write([]byte(`[{"iid":2,"title":"API","state":"opened","web_url":"u2","references":{"full":"g/p#2"},"link_type":"is_blocked_by"}]`))
+		case "/api/v4/projects/g%2Fp/issues/3/links":
+			_, _ = w.Write([]byte(`[{"iid":4,"title":"Old","state":"closed","web_url":"u4","references":{"full":"g/p#4"},"link_type":"is_blocked_by"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	exporter := NewExporter(&config.Config{GitLabToken: "t", GitLabURL: srv.URL, ProjectPath: "g/p", OnlyUnblocked: true})
+	issues := []domain.Issue{{IID: "1", State: "opened"}, {IID: "2", State: "opened"}, {IID: "3", State: "opened"}}
+	exporter.loadIssueLinks(issues)
+
+	if len(issues[0].Links) != 1 || issues[0].Links[0].IID != "2" || issues[1].Links != nil {
+		t.Fatalf("links should be loaded, failures ignored: %+v", issues)
+	}
+
+	var kept []string
+	for _, issue := range exporter.filterUnblocked(issues) {
+		kept = append(kept, issue.IID)
+	}
+	if got := strings.Join(kept, ","); got != "2,3" {
+		t.Errorf("only #1 is blocked by an open issue, kept %s", got)
+	}
+}
+
+func TestIssueLinksEnabled(t *testing.T) {
+	tests := []struct {
+		cfg  config.Config
+		want bool
+	}{
+		{config.Config{}, false},
+		{config.Config{IssueLinks: true}, true},
+		{config.Config{OnlyUnblocked: true, TodoistAPI: true}, true},
+		{config.Config{Format: "Mermaid"}, true},
+		{config.Config{Format: FormatDOT, TodoistAPI: true}, false},
+	}
+	for _, tt := range tests {
+		cfg := tt.cfg
+		if got := NewExporter(&cfg).issueLinksEnabled(); got != tt.want {
+			t.Errorf("issueLinksEnabled(%+v) = %v, want %v", tt.cfg, got, tt.want)
+		}
+	}
+}
+
+func TestBlockedIssues_MarkedInMarkdownAndTodoist(t *testing.T) {
+	cfg := &config.Config{ProjectPath: "g/p", Lang: "en"}
+	issue := domain.Issue{IID: "1", Title: "Login", State: "opened", WebURL: "u1", Links: []domain.IssueLink{
+		{IID: "2", State: "opened", WebURL: "u2", Reference: "g/p#2", LinkType: domain.LinkIsBlockedBy},
+		{IID: "7", State: "opened", WebURL: "o7", Reference: "other/api#7", LinkType: domain.LinkIsBlockedBy},
+		{IID: "3", State: "closed", WebURL: "u3", Reference: "g/p#3", LinkType: domain.LinkIsBlockedBy},
+		{IID: "4", State: "opened", WebURL: "u4", Reference: "g/p#4", LinkType: domain.LinkBlocks},
+	}}
+
+	const want = "⛔ **Blocked by:** [#2](u2), [other/api#7](o7)"
+	if desc := NewMapper(cfg).buildTaskDescription(issue); !strings.Contains(desc, want) {
+		t.Errorf("task description should list open blockers:\n%s", desc)
+	}
+
+	content, err := NewExporter(cfg).generateMarkdownContent([]domain.Issue{issue})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(content, "| **Blocked by** | ⛔ [#2](u2), [other/api#7](o7) |\n") {
+		t.Errorf("markdown should flag the blocked issue:\n%s", content)
+	}
+
+	issue.State = "closed"
+	if len(blockedBy(issue)) != 0 {
+		t.Error("closed issues are never blocked")
+	}
+}
@@ -6,15 +6,17 @@ import (
 
 	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
 	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
 	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
 )
 
 type Mapper struct {
 	config *config.Config
+	tr     *i18n.Translator
 }
 
 func NewMapper(cfg *config.Config) *Mapper {
-	return &Mapper{config: cfg}
+	return &Mapper{config: cfg, tr: i18n.New(cfg.Lang)}
 }
 
 // GitLabToTodoistTask konvertiert GitLab Issue zu Todoist Task
@@ -37,7 +39,7 @@ func (m *Mapper) GitLabToTodoistTask(issue todoistDomain.Issue, projectID string
 		dueDate = utils.ConvertToTodoistDate(*issue.DueDate)
 	}
 
-	return todoistDomain.CreateTaskRequest{
+	task := todoistDomain.CreateTaskRequest{
 		Content:     title,
 		Description: description,
 		ProjectID:   projectID,
@@ -46,6 +48,39 @@ func (m *Mapper) GitLabToTodoistTask(issue todoistDomain.Issue, projectID string
 		Priority:    priority,
 		DueDate:     dueDate,
 	}
+
+	// Schätzung als Dauer des Tasks
+	if minutes := estimateMinutes(issue); minutes > 0 {
+		task.Duration, task.DurationUnit = minutes, todoistDurationUnit
+	}
+
+	return task
+}
+
+// EpicToTodoistTask konvertiert ein Epic zu einem Todoist Task (Parent der Issues mit --hierarchy)
+func (m *Mapper) EpicToTodoistTask(epic todoistDomain.Epic, projectID string, sections map[string]string) todoistDomain.CreateTaskRequest {
+	sectionKey := "open"
+	if epic.State == "closed" {
+		sectionKey = "closed"
+	}
+	return todoistDomain.CreateTaskRequest{
+		Content:     epicTaskContent(epic),
+		Description: fmt.Sprintf("🗂️ [GitLab Epic %s](%s)", epicReference(epic), epic.WebURL),
+		ProjectID:   projectID,
+		SectionID:   sections[sectionKey],
+		Labels:      []string{"epic"},
+	}
+}
+
+// todoistDurationUnit ist die Einheit der Task-Dauer; GitLab-Schätzungen werden in Minuten übertragen
+const todoistDurationUnit = "minute"
+
+// estimateMinutes liefert die Schätzung eines Issues in ganzen Minuten (aufgerundet, 0 ohne Schätzung)
+func estimateMinutes(issue todoistDomain.Issue) int {
+	if issue.TimeEstimate <= 0 {
+		return 0
+	}
+	return (issue.TimeEstimate + 59) / 60
 }
 
 // buildTaskDescription erstellt eine strukturierte Task-Beschreibung
@@ -61,7 +96,7 @@ func (m *Mapper) buildTaskDescription(issue todoistDomain.Issue) string {
 		for _, assignee := range issue.Assignees.Nodes {
 			assigneeNames = append(assigneeNames, assignee.Name)
 		}
-		parts = append(parts, fmt.Sprintf("👤 **Assignees:** %s", strings.Join(assigneeNames, ", ")))
+		parts = append(parts, fmt.Sprintf("👤 **%s:** %s", m.tr.T("task.assignees"), strings.Join(assigneeNames, ", ")))
 	}
 
 	// Labels
@@ -70,19 +105,28 @@ func (m *Mapper) buildTaskDescription(issue todoistDomain.Issue) string {
 		for _, label := range issue.Labels.Nodes {
 			labelNames = append(labelNames, "`"+label.Title+"`")
 		}
-		parts = append(parts, fmt.Sprintf("🏷️ **Labels:** %s", strings.Join(labelNames, " ")))
+		parts = append(parts, fmt.Sprintf("🏷️ **%s:** %s", m.tr.T("task.labels"), strings.Join(labelNames, " ")))
 	}
 
 	// Due Date
 	if issue.DueDate != nil && *issue.DueDate != "" {
-		formattedDate := utils.FormatDateForDisplay(utils.ConvertToTodoistDate(*issue.DueDate))
-		parts = append(parts, fmt.Sprintf("📅 **Due Date:** %s", formattedDate))
+		formattedDate := m.tr.FormatDate(utils.ConvertToTodoistDate(*issue.DueDate))
+		parts = append(parts, fmt.Sprintf("📅 **%s:** %s", m.tr.T("task.due"), formattedDate))
+	}
+
+	// Blockierende Issues (nur mit geladenen Verknüpfungen)
+	if blockers := blockedBy(issue); len(blockers) > 0 {
+		var references []string
+		for _, link := range blockers {
+			references = append(references, fmt.Sprintf("[%s](%s)", linkReference(m.config.ProjectPath, link), link.WebURL))
+		}
+		parts = append(parts, fmt.Sprintf("⛔ **%s:** %s", m.tr.T("task.blocked_by"), strings.Join(references, ", ")))
 	}
 
 	// Original Description (gekürzt)
 	if issue.Description != "" {
 		truncatedDesc := utils.TruncateText(issue.Description, 300)
-		parts = append(parts, "", "**Beschreibung:**", truncatedDesc)
+		parts = append(parts, "", fmt.Sprintf("**%s:**", m.tr.T("task.description")), truncatedDesc)
 	}
 
 	return strings.Join(parts, "\n")
@@ -93,9 +137,7 @@ func (m *Mapper) extractLabels(issue todoistDomain.Issue) []string {
 	var labels []string
 
 	for _, label := range issue.Labels.Nodes {
-		// Label normalisieren (keine Leerzeichen, lowercase)
-		normalizedLabel := strings.ToLower(strings.ReplaceAll(label.Title, " ", "_"))
-		labels = append(labels, normalizedLabel)
+		labels = append(labels, normalizeLabel(label.Title))
 	}
 
 	// Issue State als Label hinzufügen
@@ -109,6 +151,11 @@ func (m *Mapper) extractLabels(issue todoistDomain.Issue) []string {
 	return labels
 }
 
+// normalizeLabel normalisiert ein GitLab Label für Todoist und Taskwarrior (keine Leerzeichen, lowercase)
+func normalizeLabel(title string) string {
+	return strings.ToLower(strings.ReplaceAll(title, " ", "_"))
+}
+
 // determinePriority bestimmt Todoist Priority basierend auf GitLab Labels
 func (m *Mapper) determinePriority(issue todoistDomain.Issue) int {
 	for _, label := range issue.Labels.Nodes {
@@ -131,6 +178,15 @@ func (m *Mapper) determinePriority(issue todoistDomain.Issue) int {
 	return 1
 }
 
+// priorityLetters übersetzt die Todoist-Priorität in Buchstaben (A höchste, D niedrigste),
+// wie sie Org-mode und todo.txt verwenden
+var priorityLetters = map[int]string{
+	4: "A",
+	3: "B",
+	2: "C",
+	1: "D",
+}
+
 // BuildProjectName erstellt einen Todoist-Projektnamen
 func (m *Mapper) BuildProjectName(projectPath string, milestoneTitle *string) string {
 	if m.config.TodoistProject != "" {
@@ -146,12 +202,18 @@ func (m *Mapper) BuildProjectName(projectPath string, milestoneTitle *string) st
 	return projectName
 }
 
+// SectionKey liefert den Schlüssel der Section ("open" oder "closed") für ein Issue
+func (m *Mapper) SectionKey(issue todoistDomain.Issue) string {
+	if issue.State == "closed" {
+		return "closed"
+	}
+	return "open"
+}
+
 // DetermineSectionID bestimmt die richtige Section basierend auf Issue State
 func (m *Mapper) DetermineSectionID(issue todoistDomain.Issue, sections map[string]string) string {
-	if issue.State == "closed" {
-		if sectionID, exists := sections["closed"]; exists {
-			return sectionID
-		}
+	if sectionID, exists := sections[m.SectionKey(issue)]; exists {
+		return sectionID
 	}
 
 	// Default: Open Section
@@ -161,3 +223,24 @@ func (m *Mapper) DetermineSectionID(issue todoistDomain.Issue, sections map[stri
 
 	return "" // Keine Section
 }
+
+// TodoistMapping berechnet, wie ein Issue in Todoist abgebildet wird (ohne API-Aufruf)
+func (m *Mapper) TodoistMapping(issue todoistDomain.Issue) *todoistDomain.TodoistMapping {
+	task := m.GitLabToTodoistTask(issue, "", "")
+
+	labels := task.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+
+	return &todoistDomain.TodoistMapping{
+		Content:  task.Content,
+		Priority: task.Priority,
+		Labels:   labels,
+		Section:  m.tr.T("section." + m.SectionKey(issue)),
+		DueDate:  task.DueDate,
+
+		Duration:     task.Duration,
+		DurationUnit: task.DurationUnit,
+	}
+}
@@ -56,6 +56,24 @@ func TestGitLabToTodoistTask_BasicMapping(t *testing.T) {
 	}
 }
 
+func TestGitLabToTodoistTask_DurationFromEstimate(t *testing.T) {
+	m := NewMapper(&config.Config{})
+	issue := testIssue("Estimated")
+
+	if req := m.GitLabToTodoistTask(issue, "p1", ""); req.Duration != 0 || req.DurationUnit != "" {
+		t.Fatalf("issue without estimate should have no duration: %+v", req)
+	}
+
+	issue.TimeEstimate = 90*60 + 20
+	req := m.GitLabToTodoistTask(issue, "p1", "")
+	if req.Duration != 91 || req.DurationUnit != "minute" {
+		t.Fatalf("estimate should become a duration in minutes (rounded up): %d %q", req.Duration, req.DurationUnit)
+	}
+	if mapping := m.TodoistMapping(issue); mapping.Duration != 91 || mapping.DurationUnit != "minute" {
+		t.Errorf("mapping should include the duration: %+v", mapping)
+	}
+}
+
 func TestBuildTaskDescription_ContainsExpectedBlocks(t *testing.T) {
 	m := NewMapper(&config.Config{})
 	issue := testIssue("Title")
@@ -0,0 +1,275 @@
+package service
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+//go:embed templates/markdown.md.tmpl
+var defaultMarkdownTemplate string
+
+// customTemplateName ist der Name, unter dem ein eigenes Template (--template) geparst wird
+const customTemplateName = "custom"
+
+// MarkdownData ist das Datenmodell der Markdown-Templates.
+//
+// Verfügbare Hilfsfunktionen:
+//
+//	t "key" args...        übersetzter Text aus dem Katalog (siehe internal/i18n)
+//	date "2024-02-15"      Datum im Format der Sprache (auch *string)
+//	datetime .ExportedAt   Zeitpunkt im Format der Sprache
+//	escape .Title          Markdown-Sonderzeichen escapen
+//	truncate 100 .Text     Text kürzen
+//	join ", " list         Liste verbinden
+//	formatLabels list      Liste als `code`-Tags
+//	due issue              Fälligkeitsdatum oder ""
+//	duration 5400          Sekunden der Zeiterfassung als "1h 30m"
+//	assignees issue        Namen der Zugewiesenen
+//	labels issue           Label-Titel
+//	blockedBy issue        offene blockierende Issues als Links (nur mit --issue-links)
+//	byState "opened" list  Issues eines Status
+//	groupBy "label" list   Issues gruppiert nach state, label, assignee oder milestone
+//
+// Progress enthält den Fortschritt je Milestone (leer mit --milestone-progress=false),
+// Hierarchy die Issues nach Epic gruppiert mit ihren Child-Tasks (nur mit --hierarchy).
+// Das Standard-Template definiert die Blöcke "header", "progress", "hierarchy" und "issue", die eigene
+// Templates mit {{ template "issue" . }} wiederverwenden können.
+type MarkdownData struct {
+	Project    string
+	Milestone  string
+	ExportedAt time.Time
+	Lang       string
+	Issues     []todoistDomain.Issue
+	Stats      MarkdownStats
+	Progress   []MilestoneProgress
+	Hierarchy  []EpicGroup
+}
+
+// MarkdownStats enthält Kennzahlen für den Kopf des Reports
+type MarkdownStats struct {
+	Total  int
+	Open   int
+	Closed int
+}
+
+// IssueGroup ist eine benannte Gruppe von Issues (siehe groupBy)
+type IssueGroup struct {
+	Name   string
+	Issues []todoistDomain.Issue
+}
+
+// markdownTemplate lädt das Standard-Template und optional das eigene Template aus der Konfiguration
+func (e *Exporter) markdownTemplate() (*template.Template, string, error) {
+	tmpl, err := template.New("markdown").Funcs(e.templateFuncs()).Parse(defaultMarkdownTemplate)
+	if err != nil {
+		return nil, "", e.tr.Errorf("err.template_default", err)
+	}
+
+	if e.config.MarkdownTemplate == "" {
+		return tmpl, tmpl.Name(), nil
+	}
+
+	text, err := os.ReadFile(e.config.MarkdownTemplate)
+	if err != nil {
+		return nil, "", e.tr.Errorf("err.template_read", e.config.MarkdownTemplate, err)
+	}
+	if _, err = tmpl.New(customTemplateName).Parse(string(text)); err != nil {
+		return nil, "", e.tr.Errorf("err.template_parse", e.config.MarkdownTemplate, err)
+	}
+
+	return tmpl, customTemplateName, nil
+}
+
+// templateFuncs liefert die Hilfsfunktionen für Templates
+func (e *Exporter) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"t": e.tr.T,
+		"date": func(value interface{}) string {
+			switch v := value.(type) {
+			case *string:
+				if v == nil {
+					return e.tr.FormatDate("")
+				}
+				return e.tr.FormatDate(*v)
+			case string:
+				return e.tr.FormatDate(v)
+			default:
+				return fmt.Sprint(value)
+			}
+		},
+		"datetime": func(t time.Time) string {
+			return t.Format(e.tr.T("datetime.layout"))
+		},
+		"escape": utils.EscapeMarkdown,
+		"truncate": func(maxLength int, text string) string {
+			return utils.TruncateText(text, maxLength)
+		},
+		"join": func(sep string, items []string) string {
+			return strings.Join(items, sep)
+		},
+		"formatLabels": utils.FormatLabels,
+		"due":          issueDueDate,
+		"duration":     formatTimeTracking,
+		"assignees":    assigneeNames,
+		"labels":       labelTitles,
+		"blockedBy": func(issue todoistDomain.Issue) []string {
+			var references []string
+			for _, link := range blockedBy(issue) {
+				references = append(references, fmt.Sprintf("[%s](%s)", linkReference(e.config.ProjectPath, link), link.WebURL))
+			}
+			return references
+		},
+		"byState": func(state string, issues []todoistDomain.Issue) []todoistDomain.Issue {
+			return filterIssuesByState(issues, state)
+		},
+		"groupBy": func(field string, issues []todoistDomain.Issue) ([]IssueGroup, error) {
+			return e.groupIssues(issues, field)
+		},
+	}
+}
+
+// formatTimeTracking formatiert Sekunden der Zeiterfassung wie GitLab, aber ohne Tage, z.B. "1h 30m"
+func formatTimeTracking(seconds int) string {
+	minutes := (seconds + 59) / 60
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	default:
+		return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+	}
+}
+
+// buildMarkdownData erstellt das Datenmodell für die Templates
+func (e *Exporter) buildMarkdownData(issues []todoistDomain.Issue) MarkdownData {
+	data := MarkdownData{
+		Project:    e.config.ProjectPath,
+		ExportedAt: time.Now(),
+		Lang:       e.tr.Lang(),
+		Issues:     issues,
+		Stats: MarkdownStats{
+			Total:  len(issues),
+			Open:   len(filterIssuesByState(issues, "opened")),
+			Closed: len(filterIssuesByState(issues, "closed")),
+		},
+		Progress: e.milestoneProgress(issues),
+	}
+	if e.config.Hierarchy && hasHierarchy(issues) {
+		data.Hierarchy = issueHierarchy(issues)
+	}
+	if e.config.MilestoneTitle != nil {
+		data.Milestone = *e.config.MilestoneTitle
+	}
+	return data
+}
+
+// generateMarkdownContent rendert den Markdown-Report mit dem konfigurierten Template
+func (e *Exporter) generateMarkdownContent(issues []todoistDomain.Issue) (string, error) {
+	tmpl, name, err := e.markdownTemplate()
+	if err != nil {
+		return "", err
+	}
+
+	var content strings.Builder
+	if err := tmpl.ExecuteTemplate(&content, name, e.buildMarkdownData(issues)); err != nil {
+		return "", e.tr.Errorf("err.template_render", err)
+	}
+	return content.String(), nil
+}
+
+// formatIssueAsMarkdown formatiert ein Issue mit dem Block "issue" des Templates
+func (e *Exporter) formatIssueAsMarkdown(issue todoistDomain.Issue) (string, error) {
+	tmpl, _, err := e.markdownTemplate()
+	if err != nil {
+		return "", err
+	}
+
+	var content strings.Builder
+	if err := tmpl.ExecuteTemplate(&content, "issue", issue); err != nil {
+		return "", e.tr.Errorf("err.template_render", err)
+	}
+	return content.String(), nil
+}
+
+func issueDueDate(issue todoistDomain.Issue) string {
+	if issue.DueDate == nil {
+		return ""
+	}
+	return *issue.DueDate
+}
+
+func assigneeNames(issue todoistDomain.Issue) []string {
+	var names []string
+	for _, assignee := range issue.Assignees.Nodes {
+		names = append(names, assignee.Name)
+	}
+	return names
+}
+
+func labelTitles(issue todoistDomain.Issue) []string {
+	var titles []string
+	for _, label := range issue.Labels.Nodes {
+		titles = append(titles, label.Title)
+	}
+	return titles
+}
+
+// groupIssues gruppiert Issues nach einem Feld; Issues ohne Wert landen in der Gruppe "md.ungrouped".
+// Bei Labels und Zugewiesenen kann ein Issue in mehreren Gruppen vorkommen.
+func (e *Exporter) groupIssues(issues []todoistDomain.Issue, field string) ([]IssueGroup, error) {
+	var keysOf func(todoistDomain.Issue) []string
+	switch field {
+	case "state":
+		keysOf = func(issue todoistDomain.Issue) []string { return []string{issue.State} }
+	case "label":
+		keysOf = labelTitles
+	case "assignee":
+		keysOf = assigneeNames
+	case "milestone":
+		keysOf = func(issue todoistDomain.Issue) []string {
+			if issue.Milestone == nil {
+				return nil
+			}
+			return []string{issue.Milestone.Title}
+		}
+	default:
+		return nil, e.tr.Errorf("err.group_field", field)
+	}
+
+	grouped := make(map[string][]todoistDomain.Issue)
+	var rest []todoistDomain.Issue
+	for _, issue := range issues {
+		keys := keysOf(issue)
+		if len(keys) == 0 {
+			rest = append(rest, issue)
+			continue
+		}
+		for _, key := range keys {
+			grouped[key] = append(grouped[key], issue)
+		}
+	}
+
+	names := make([]string, 0, len(grouped))
+	for name := range grouped {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	groups := make([]IssueGroup, 0, len(names)+1)
+	for _, name := range names {
+		groups = append(groups, IssueGroup{Name: name, Issues: grouped[name]})
+	}
+	if len(rest) > 0 {
+		groups = append(groups, IssueGroup{Name: e.tr.T("md.ungrouped"), Issues: rest})
+	}
+	return groups, nil
+}
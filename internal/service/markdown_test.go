@@ -0,0 +1,163 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
+)
+
+func templateTestIssues() []todoistDomain.Issue {
+	due := "2024-02-15"
+	return []todoistDomain.Issue{
+		{
+			IID: "1", Title: "Fix *bold*", State: "opened", WebURL: "u1", DueDate: &due, Description: "Line1\nLine2",
+			Labels:    todoistDomain.Labels{Nodes: []todoistDomain.Label{{Title: "bug"}, {Title: "high"}}},
+			Assignees: todoistDomain.Assignees{Nodes: []todoistDomain.Assignee{{Name: "A"}, {Name: "B"}}},
+			Milestone: &todoistDomain.Milestone{Title: "v1"},
+		},
+		{IID: "2", Title: "Done", State: "closed", WebURL: "u2",
+			Labels: todoistDomain.Labels{Nodes: []todoistDomain.Label{{Title: "bug"}}}},
+	}
+}
+
+func TestDefaultTemplate_MatchesClassicLayout(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", MilestoneTitle: stringPtr("v1")})
+
+	content, err := exporter.generateMarkdownContent(templateTestIssues())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Der Zeitstempel ändert sich mit jedem Lauf
+	content = regexp.MustCompile(`\*\*Export-Zeit:\*\* [0-9.: ]+  \n`).ReplaceAllString(content, "**Export-Zeit:** <now>  \n")
+
+	expected := "# GitLab Issues Export - g/p\n\n" +
+		"**Export-Zeit:** <now>  \n" +
+		"**Anzahl Issues:** 2  \n\n" +
+		"**Milestone:** v1  \n\n" +
+		"## 🟢 Offene Issues\n\n" +
+		"### [#1 - Fix \\*bold\\*](u1)\n\n" +
+		"| Feld | Wert |\n|------|------|\n" +
+		"| **Status** | opened |\n" +
+		"| **Fällig** | 15.02.2024 |\n" +
+		"| **Zugewiesen** | A, B |\n" +
+		"| **Labels** | `bug` `high` |\n\n" +
+		"**Beschreibung:**\n\nLine1\nLine2\n\n---\n\n" +
+		"## ✅ Geschlossene Issues\n\n" +
+		"### [#2 - Done](u2)\n\n" +
+		"| Feld | Wert |\n|------|------|\n" +
+		"| **Status** | closed |\n" +
+		"| **Labels** | `bug` |\n\n---\n\n"
+
+	if content != expected {
+		t.Fatalf("unexpected default layout:\n%q\nwant:\n%q", content, expected)
+	}
+}
+
+func TestDefaultTemplate_WeightAndTimeTracking(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: "en"})
+
+	issues := templateTestIssues()
+	issues[0].Weight, issues[0].TimeEstimate, issues[0].TotalTimeSpent = intPtr(3), 5400, 600
+	content, err := exporter.generateMarkdownContent(issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(content, "| **Labels** | `bug` `high` |\n| **Weight** | 3 |\n| **Estimate** | 1h 30m |\n| **Time spent** | 10m |\n\n") {
+		t.Errorf("issue table should show weight and time tracking:\n%s", content)
+	}
+	if strings.Contains(content, "| **Weight** | 0 |") || strings.Count(content, "**Estimate**") != 1 {
+		t.Errorf("issues without values should not show the rows:\n%s", content)
+	}
+}
+
+func TestFormatTimeTracking(t *testing.T) {
+	for seconds, want := range map[int]string{0: "0m", 30: "1m", 45 * 60: "45m", 3600: "1h", 5400: "1h 30m", 26 * 3600: "26h"} {
+		if got := formatTimeTracking(seconds); got != want {
+			t.Errorf("formatTimeTracking(%d) = %q, want %q", seconds, got, want)
+		}
+	}
+}
+
+func TestCustomTemplate_GroupingAndIssueBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.md.tmpl")
+	tmpl := `# {{ .Project }} ({{ .Stats.Open }}/{{ .Stats.Total }} offen)
+{{ range groupBy "label" .Issues }}
+## {{ .Name }}
+{{ range .Issues }}- #{{ .IID }} {{ escape .Title }}{{ with due . }} ({{ date . }}){{ end }}
+{{ end }}{{ end }}
+{{- range byState "closed" .Issues }}{{ template "issue" . }}{{ end }}`
+	if err := os.WriteFile(path, []byte(tmpl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", MarkdownTemplate: path, Lang: "en"})
+	content, err := exporter.generateMarkdownContent(templateTestIssues())
+	if err != nil {
+		t.Fatalf("generateMarkdownContent() error = %v", err)
+	}
+
+	expected := []string{
+		"# g/p (1/2 offen)",
+		"## bug\n- #1 Fix \\*bold\\* (2024-02-15)\n- #2 Done\n",
+		"## high\n- #1 Fix \\*bold\\* (2024-02-15)\n",
+		"### [#2 - Done](u2)",
+		"| Field | Value |",
+	}
+	for _, e := range expected {
+		if !strings.Contains(content, e) {
+			t.Errorf("output should contain %q, got:\n%s", e, content)
+		}
+	}
+}
+
+func TestCustomTemplate_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", MarkdownTemplate: filepath.Join(dir, "missing.tmpl")})
+	if _, err := exporter.generateMarkdownContent(nil); err == nil {
+		t.Error("expected error for missing template file")
+	}
+
+	broken := filepath.Join(dir, "broken.tmpl")
+	if err := os.WriteFile(broken, []byte("{{ range }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	exporter = NewExporter(&config.Config{ProjectPath: "g/p", MarkdownTemplate: broken})
+	if _, err := exporter.generateMarkdownContent(nil); err == nil || !strings.Contains(err.Error(), "broken.tmpl") {
+		t.Errorf("expected parse error mentioning the file, got %v", err)
+	}
+
+	unknownGroup := filepath.Join(dir, "group.tmpl")
+	if err := os.WriteFile(unknownGroup, []byte(`{{ range groupBy "color" .Issues }}{{ end }}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	exporter = NewExporter(&config.Config{ProjectPath: "g/p", MarkdownTemplate: unknownGroup})
+	if _, err := exporter.generateMarkdownContent(templateTestIssues()); err == nil {
+		t.Error("expected error for unknown group field")
+	}
+
+	// Mit --lang en ist auch der Fehler aus dem Template englisch
+	exporter = NewExporter(&config.Config{ProjectPath: "g/p", MarkdownTemplate: unknownGroup, Lang: i18n.EN})
+	if _, err := exporter.generateMarkdownContent(templateTestIssues()); err == nil || !strings.Contains(err.Error(), `unknown group field "color"`) {
+		t.Errorf("expected English group field error, got %v", err)
+	}
+}
+
+func TestGroupIssues_MilestoneAndUngrouped(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: i18n.EN})
+	groups, err := exporter.groupIssues(templateTestIssues(), "milestone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 || groups[0].Name != "v1" || groups[1].Name != "Not set" || groups[1].Issues[0].IID != "2" {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	historyRepo "hufschlaeger.net/gitlab-tasks-exporter/internal/repository/history"
+)
+
+// maxBurnupDays begrenzt, wie viele Tage der Historie für ein Chart gelesen werden
+const maxBurnupDays = 90
+
+// Quellen der Burndown-Daten
+const (
+	burnupSourceGitLab  = "gitlab"
+	burnupSourceHistory = "history"
+)
+
+// MilestoneProgress ist der Fortschritt eines Milestones im Markdown- und HTML-Report
+type MilestoneProgress struct {
+	Title        string
+	Open         int
+	Closed       int
+	Total        int
+	Weight       int
+	ClosedWeight int
+	// HasWeight ist false, wenn GitLab keine Gewichte liefert (z.B. ohne Premium)
+	HasWeight bool
+	// TimeEstimate und TimeSpent summieren die Zeiterfassung der Issues in Sekunden;
+	// HasTime ist false, wenn kein Issue geschätzt oder Zeit gebucht hat
+	TimeEstimate int
+	TimeSpent    int
+	HasTime      bool
+	// Percent ist der Anteil geschlossener Issues (0-100)
+	Percent int
+	Burnup  []todoistDomain.BurnupPoint
+	// Source ist "gitlab" (Timebox-Report) oder "history" (Snapshots), leer ohne Chart
+	Source string
+	// Mermaid ist ein xychart für Markdown, SVG die Grafik für den HTML-Report (leer ohne Chart)
+	Mermaid string
+	SVG     template.HTML
+}
+
+// loadIssueWeights ergänzt die Gewichte der Issues. Sie sind optional: ohne Premium
+// liefert GitLab einen Fehler und die Gewichte bleiben leer.
+func (e *Exporter) loadIssueWeights(issues []todoistDomain.Issue) {
+	weights, err := e.gitlabRepo.GetIssueWeights(e.config.ProjectPath, e.config.MilestoneTitle)
+	if err != nil {
+		slog.Debug("issue weights unavailable", "error", err)
+	}
+	for i := range issues {
+		if weight, ok := weights[issues[i].IID]; ok {
+			issues[i].Weight = &weight
+		}
+	}
+}
+
+// loadProgressData lädt bei gesetztem Milestone-Filter den Timebox-Report aus GitLab.
+// Er ist optional: ohne Premium bleibt es bei Anzahlen und Snapshots aus der Historie.
+func (e *Exporter) loadProgressData() {
+	e.burnup = make(map[string][]todoistDomain.BurnupPoint)
+
+	milestone := e.config.MilestoneTitle
+	if milestone == nil || *milestone == "" || *milestone == "*" {
+		return
+	}
+	points, err := e.gitlabRepo.GetMilestoneBurnup(e.config.ProjectPath, *milestone)
+	if err != nil {
+		slog.Debug("milestone report unavailable", "milestone", *milestone, "error", err)
+		return
+	}
+	e.burnup[*milestone] = points
+}
+
+// milestoneProgress berechnet den Fortschritt je Milestone (nach Titel sortiert)
+func (e *Exporter) milestoneProgress(issues []todoistDomain.Issue) []MilestoneProgress {
+	if !e.config.MilestoneProgress {
+		return nil
+	}
+
+	byTitle := make(map[string]*MilestoneProgress)
+	for _, issue := range issues {
+		if issue.Milestone == nil {
+			continue
+		}
+		progress, ok := byTitle[issue.Milestone.Title]
+		if !ok {
+			progress = &MilestoneProgress{Title: issue.Milestone.Title}
+			byTitle[issue.Milestone.Title] = progress
+		}
+
+		progress.Total++
+		closed := issue.State == "closed"
+		if closed {
+			progress.Closed++
+		} else {
+			progress.Open++
+		}
+		if issue.Weight != nil {
+			progress.HasWeight = true
+			progress.Weight += *issue.Weight
+			if closed {
+				progress.ClosedWeight += *issue.Weight
+			}
+		}
+		if issue.TimeEstimate > 0 || issue.TotalTimeSpent > 0 {
+			progress.HasTime = true
+			progress.TimeEstimate += issue.TimeEstimate
+			progress.TimeSpent += issue.TotalTimeSpent
+		}
+	}
+	if len(byTitle) == 0 {
+		return nil
+	}
+
+	history := e.historyBurnup()
+
+	result := make([]MilestoneProgress, 0, len(byTitle))
+	for title, progress := range byTitle {
+		progress.Percent = progress.Closed * 100 / progress.Total
+
+		switch {
+		case len(e.burnup[title]) >= 2:
+			progress.Burnup, progress.Source = e.burnup[title], burnupSourceGitLab
+		case len(history[title]) >= 2:
+			progress.Burnup, progress.Source = history[title], burnupSourceHistory
+		}
+		if progress.Source != "" {
+			progress.Mermaid = e.burnupMermaid(*progress)
+			progress.SVG = e.burnupSVG(*progress)
+		}
+		result = append(result, *progress)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Title < result[j].Title
+	})
+	return result
+}
+
+// historyBurnup leitet Burnup-Zeitreihen aus den Snapshots ab (letzter Snapshot pro Tag)
+func (e *Exporter) historyBurnup() map[string][]todoistDomain.BurnupPoint {
+	if e.history == nil {
+		return nil
+	}
+	snapshots, err := e.history.List(e.config.ProjectPath, e.exportFilters())
+	if err != nil {
+		slog.Warn("reading snapshot history failed", "error", err)
+		return nil
+	}
+
+	var days []historyRepo.Snapshot
+	for _, snapshot := range snapshots {
+		if n := len(days); n > 0 && sameDay(days[n-1].TakenAt, snapshot.TakenAt) {
+			days[n-1] = snapshot
+			continue
+		}
+		days = append(days, snapshot)
+	}
+	if len(days) > maxBurnupDays {
+		days = days[len(days)-maxBurnupDays:]
+	}
+
+	series := make(map[string][]todoistDomain.BurnupPoint)
+	for _, day := range days {
+		document, err := historyRepo.Load(day.Path)
+		if err != nil {
+			slog.Warn("skipping unreadable snapshot", "error", err)
+			continue
+		}
+
+		points := make(map[string]*todoistDomain.BurnupPoint)
+		for _, issue := range document.Issues {
+			if issue.Milestone == "" {
+				continue
+			}
+			point, ok := points[issue.Milestone]
+			if !ok {
+				point = &todoistDomain.BurnupPoint{Date: day.TakenAt.Local().Format(releaseDateLayout)}
+				points[issue.Milestone] = point
+			}
+			weight := 0
+			if issue.Weight != nil {
+				weight = *issue.Weight
+			}
+			point.ScopeCount++
+			point.ScopeWeight += weight
+			if issue.State == "closed" {
+				point.CompletedCount++
+				point.CompletedWeight += weight
+			}
+		}
+		for title, point := range points {
+			series[title] = append(series[title], *point)
+		}
+	}
+	return series
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Local().Format(releaseDateLayout) == b.Local().Format(releaseDateLayout)
+}
+
+// burnupMermaid erzeugt ein Mermaid-xychart mit offenen Issues (Burndown) und Gesamtumfang
+func (e *Exporter) burnupMermaid(progress MilestoneProgress) string {
+	labels := make([]string, 0, len(progress.Burnup))
+	remaining := make([]string, 0, len(progress.Burnup))
+	scope := make([]string, 0, len(progress.Burnup))
+	for _, point := range progress.Burnup {
+		labels = append(labels, `"`+mermaidText(e.tr.FormatDate(point.Date))+`"`)
+		remaining = append(remaining, fmt.Sprint(point.ScopeCount-point.CompletedCount))
+		scope = append(scope, fmt.Sprint(point.ScopeCount))
+	}
+
+	var b strings.Builder
+	b.WriteString("xychart-beta\n")
+	fmt.Fprintf(&b, "    title \"%s\"\n", mermaidText(e.tr.T("md.burndown", progress.Title)))
+	fmt.Fprintf(&b, "    x-axis [%s]\n", strings.Join(labels, ", "))
+	fmt.Fprintf(&b, "    y-axis \"%s\" 0 --> %d\n", mermaidText(e.tr.T("md.chart_issues")), burnupMax(progress.Burnup))
+	fmt.Fprintf(&b, "    line [%s]\n", strings.Join(remaining, ", "))
+	fmt.Fprintf(&b, "    line [%s]\n", strings.Join(scope, ", "))
+	return b.String()
+}
+
+// mermaidText entfernt Zeichen, die Mermaid in Texten in Anführungszeichen nicht verträgt
+func mermaidText(text string) string {
+	return strings.NewReplacer(`"`, "'", "\n", " ").Replace(text)
+}
+
+// burnupSVG zeichnet dasselbe Chart als eigenständiges SVG für den HTML-Report
+func (e *Exporter) burnupSVG(progress MilestoneProgress) template.HTML {
+	const (
+		width, height = 640, 240
+		left, right   = 40, 10
+		top, bottom   = 30, 30
+	)
+	plotWidth, plotHeight := float64(width-left-right), float64(height-top-bottom)
+	maxValue := float64(burnupMax(progress.Burnup))
+	step := plotWidth / float64(len(progress.Burnup)-1)
+
+	polyline := func(value func(todoistDomain.BurnupPoint) int) string {
+		coords := make([]string, 0, len(progress.Burnup))
+		for i, point := range progress.Burnup {
+			x := float64(left) + float64(i)*step
+			y := float64(top) + plotHeight - float64(value(point))/maxValue*plotHeight
+			coords = append(coords, fmt.Sprintf("%.1f,%.1f", x, y))
+		}
+		return strings.Join(coords, " ")
+	}
+
+	first, last := progress.Burnup[0], progress.Burnup[len(progress.Burnup)-1]
+	title := e.tr.T("md.burndown", progress.Title)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg class="burndown" viewBox="0 0 %d %d" role="img" aria-label="%s">`, width, height, html.EscapeString(title))
+	fmt.Fprintf(&b, `<text class="title" x="%d" y="18">%s</text>`, left, html.EscapeString(title))
+	fmt.Fprintf(&b, `<line class="axis" x1="%d" y1="%d" x2="%d" y2="%d"/>`, left, top, left, height-bottom)
+	fmt.Fprintf(&b, `<line class="axis" x1="%d" y1="%d" x2="%d" y2="%d"/>`, left, height-bottom, width-right, height-bottom)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%d</text>`, left-4, top+4, int(maxValue))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">0</text>`, left-4, height-bottom)
+	fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`, left, height-10, html.EscapeString(e.tr.FormatDate(first.Date)))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%s</text>`, width-right, height-10, html.EscapeString(e.tr.FormatDate(last.Date)))
+	fmt.Fprintf(&b, `<polyline class="scope" points="%s"><title>%s</title></polyline>`,
+		polyline(func(p todoistDomain.BurnupPoint) int { return p.ScopeCount }), html.EscapeString(e.tr.T("md.chart_scope")))
+	fmt.Fprintf(&b, `<polyline class="remaining" points="%s"><title>%s</title></polyline>`,
+		polyline(func(p todoistDomain.BurnupPoint) int { return p.ScopeCount - p.CompletedCount }), html.EscapeString(e.tr.T("md.chart_remaining")))
+	b.WriteString(`</svg>`)
+
+	// Alle Werte sind Zahlen oder escapte Texte
+	return template.HTML(b.String())
+}
+
+// burnupMax liefert das Maximum der y-Achse (mindestens 1)
+func burnupMax(points []todoistDomain.BurnupPoint) int {
+	maxValue := 1
+	for _, point := range points {
+		if point.ScopeCount > maxValue {
+			maxValue = point.ScopeCount
+		}
+	}
+	return maxValue
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func progressTestIssues() []todoistDomain.Issue {
+	v1 := &todoistDomain.Milestone{Title: "v1"}
+	return []todoistDomain.Issue{
+		{IID: "1", State: "opened", Milestone: v1, Weight: intPtr(3)},
+		{IID: "2", State: "closed", Milestone: v1, Weight: intPtr(5)},
+		{IID: "3", State: "closed", Milestone: v1},
+		{IID: "4", State: "opened", Milestone: &todoistDomain.Milestone{Title: "v0"}},
+		{IID: "5", State: "opened"},
+	}
+}
+
+func TestMilestoneProgress_CountsAndWeights(t *testing.T) {
+	exporter := NewExporter(&config.Config{MilestoneProgress: true})
+
+	progress := exporter.milestoneProgress(progressTestIssues())
+	if len(progress) != 2 || progress[0].Title != "v0" || progress[1].Title != "v1" {
+		t.Fatalf("unexpected milestones: %+v", progress)
+	}
+
+	v1 := progress[1]
+	if v1.Open != 1 || v1.Closed != 2 || v1.Total != 3 || v1.Percent != 66 {
+		t.Errorf("unexpected counts: %+v", v1)
+	}
+	if !v1.HasWeight || v1.Weight != 8 || v1.ClosedWeight != 5 {
+		t.Errorf("unexpected weights: %+v", v1)
+	}
+	if progress[0].HasWeight || v1.Source != "" || v1.Mermaid != "" {
+		t.Errorf("no weights and no chart expected without data: %+v", progress[0])
+	}
+
+	exporter.config.MilestoneProgress = false
+	if got := exporter.milestoneProgress(progressTestIssues()); got != nil {
+		t.Errorf("progress should be disabled: %+v", got)
+	}
+}
+
+func TestMilestoneProgress_BurndownFromHistory(t *testing.T) {
+	exporter := NewExporter(&config.Config{Lang: "en", ProjectPath: "g/p", HistoryDir: t.TempDir(), MilestoneProgress: true})
+
+	issues := progressTestIssues()[:3]
+	states := [][]string{{"opened", "opened", "opened"}, {"opened", "closed", "opened"}, {"opened", "closed", "closed"}}
+	for day, dayStates := range states {
+		document := todoistDomain.ExportDocument{Metadata: todoistDomain.ExportMetadata{
+			Project: "g/p", ExportedAt: time.Date(2024, 3, 1+day, 12, 0, 0, 0, time.UTC),
+		}}
+		for i, state := range dayStates {
+			issue := normalizeIssue(issues[i])
+			issue.State = state
+			document.Issues = append(document.Issues, issue)
+		}
+		if _, _, err := exporter.history.Save(document); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	progress := exporter.milestoneProgress(issues)
+	if len(progress) != 1 || progress[0].Source != burnupSourceHistory || len(progress[0].Burnup) != 3 {
+		t.Fatalf("expected a chart from the history: %+v", progress)
+	}
+
+	expected := "xychart-beta\n" +
+		"    title \"Burndown v1\"\n" +
+		"    x-axis [\"2024-03-01\", \"2024-03-02\", \"2024-03-03\"]\n" +
+		"    y-axis \"Issues\" 0 --> 3\n" +
+		"    line [3, 2, 1]\n" +
+		"    line [3, 3, 3]\n"
+	if progress[0].Mermaid != expected {
+		t.Errorf("unexpected mermaid chart:\n%s", progress[0].Mermaid)
+	}
+
+	content, err := exporter.generateMarkdownContent(issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"## 📈 Milestone progress\n\n| Milestone | Open | Closed | Weight | Time (spent / estimated) | Done |\n|------|------|------|------|------|------|\n| v1 | 1 | 2 | 5/8 | – | 66 % |\n\n",
+		"```mermaid\n" + expected + "```\n\n_Lines: open issues (burndown) and total scope, source: snapshot history_\n\n## 🟢 Open issues",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("markdown should contain %q:\n%s", want, content)
+		}
+	}
+
+	var report bytes.Buffer
+	if err := exporter.writeHTML(&report, issues); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`<progress value="66" max="100">`, `<svg class="burndown"`, `<polyline class="remaining" points="40.0,30.0 335.0,90.0 630.0,150.0">`} {
+		if !strings.Contains(report.String(), want) {
+			t.Errorf("HTML report should contain %q", want)
+		}
+	}
+}
+
+func TestMilestoneProgress_TimeTracking(t *testing.T) {
+	exporter := NewExporter(&config.Config{Lang: "en", ProjectPath: "g/p", MilestoneProgress: true})
+
+	issues := progressTestIssues()
+	issues[0].TimeEstimate, issues[0].TotalTimeSpent = 4*3600, 3600
+	issues[1].TimeEstimate, issues[1].TotalTimeSpent = 2*3600, 2*3600+30*60
+
+	progress := exporter.milestoneProgress(issues)
+	if v1 := progress[1]; !v1.HasTime || v1.TimeEstimate != 6*3600 || v1.TimeSpent != 3*3600+30*60 {
+		t.Errorf("unexpected time tracking: %+v", v1)
+	}
+	if progress[0].HasTime {
+		t.Errorf("milestone without time tracking: %+v", progress[0])
+	}
+
+	content, err := exporter.generateMarkdownContent(issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(content, "| v0 | 1 | 0 | – | – | 0 % |\n| v1 | 1 | 2 | 5/8 | 3h 30m / 6h | 66 % |\n") {
+		t.Errorf("progress table should show spent and estimated time:\n%s", content)
+	}
+}
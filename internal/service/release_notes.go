@@ -0,0 +1,342 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+// releaseDateLayout ist das Datumsformat von --since/--until und der Überschriften in Keep a Changelog
+const releaseDateLayout = "2006-01-02"
+
+// unreleasedVersion wird verwendet, wenn weder Version noch Milestone gesetzt sind
+const unreleasedVersion = "Unreleased"
+
+// releaseCategory ordnet Labels einer Kategorie zu (z.B. "Fixed" ← bug, fix)
+type releaseCategory struct {
+	name   string
+	labels []string
+	// catchAll nimmt alle Einträge auf, die keiner anderen Kategorie zugeordnet wurden
+	catchAll bool
+}
+
+// releaseEntry ist eine Zeile der Release Notes (Issue oder Merge Request)
+type releaseEntry struct {
+	title  string
+	ref    string
+	url    string
+	labels []string
+}
+
+// ReleaseNotes erzeugt einen Abschnitt im Stil von Keep a Changelog aus geschlossenen Issues
+// und gemergten Merge Requests und schreibt ihn nach stdout oder an den Anfang des CHANGELOG.md
+func (e *Exporter) ReleaseNotes() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.config.Validate(); err != nil {
+		return e.tr.Errorf("err.invalid_config", err)
+	}
+
+	categories, err := e.parseReleaseCategories()
+	if err != nil {
+		return err
+	}
+
+	since, until, err := e.releaseRange()
+	if err != nil {
+		return err
+	}
+	milestone := e.config.MilestoneTitle
+	if (milestone == nil || *milestone == "" || *milestone == "*") && since == nil && until == nil {
+		return e.tr.Errorf("err.release_scope")
+	}
+
+	entries, err := e.loadReleaseEntries(milestone, since, until)
+	if err != nil {
+		return err
+	}
+
+	section := renderReleaseNotes(e.releaseVersion(), e.releaseDate(until), categories, entries)
+
+	if e.config.ReleaseChangelog == "" {
+		if _, err := os.Stdout.WriteString(section); err != nil {
+			return e.tr.Errorf("err.file_export", err)
+		}
+		e.progress("progress.release_notes_written", len(entries))
+		return nil
+	}
+
+	if err := e.prependChangelog(e.config.ReleaseChangelog, section); err != nil {
+		return e.tr.Errorf("err.changelog", e.config.ReleaseChangelog, err)
+	}
+	e.progress("progress.changelog_written", e.config.ReleaseChangelog, len(entries))
+	return nil
+}
+
+// loadReleaseEntries lädt geschlossene Issues und, falls verfügbar, gemergte Merge Requests
+func (e *Exporter) loadReleaseEntries(milestone *string, since, until *time.Time) ([]releaseEntry, error) {
+	if err := e.gitlabRepo.ValidateConnection(); err != nil {
+		return nil, e.tr.Errorf("err.gitlab_connection", err)
+	}
+
+	e.progress("progress.loading_release", e.config.ProjectPath)
+
+	// until ist inklusive, GitLab filtert mit closedBefore exklusiv
+	var before *time.Time
+	if until != nil {
+		next := until.AddDate(0, 0, 1)
+		before = &next
+	}
+
+	issues, err := e.gitlabRepo.GetClosedIssues(e.config.ProjectPath, milestone, since, before)
+	if err != nil {
+		return nil, e.tr.Errorf("err.loading_issues", err)
+	}
+
+	entries := releaseEntriesFromIssues(issues)
+	sortReleaseEntries(entries)
+
+	if !e.config.ReleaseMergeRequests {
+		return entries, nil
+	}
+
+	// Merge Requests sind optional: ohne Berechtigung oder bei älteren Instanzen reichen die Issues
+	mergeRequests, err := e.gitlabRepo.GetMergedMergeRequests(e.config.ProjectPath, milestone, since, before)
+	if err != nil {
+		slog.Warn("loading merge requests failed, continuing with issues only", "error", err)
+		return entries, nil
+	}
+
+	mrEntries := make([]releaseEntry, 0, len(mergeRequests))
+	for _, mr := range mergeRequests {
+		var labels []string
+		for _, label := range mr.Labels.Nodes {
+			labels = append(labels, label.Title)
+		}
+		mrEntries = append(mrEntries, releaseEntry{title: mr.Title, ref: "!" + mr.IID, url: mr.WebURL, labels: labels})
+	}
+	sortReleaseEntries(mrEntries)
+
+	return append(entries, mrEntries...), nil
+}
+
+// parseReleaseCategories liest z.B. "Added=feature,enhancement;Changed=*;Fixed=bug"
+func (e *Exporter) parseReleaseCategories() ([]releaseCategory, error) {
+	var categories []releaseCategory
+	for _, part := range strings.Split(e.config.ReleaseCategories, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, labels, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, e.tr.Errorf("err.release_category", part)
+		}
+
+		category := releaseCategory{name: name}
+		for _, label := range strings.Split(labels, ",") {
+			label = strings.TrimSpace(label)
+			switch label {
+			case "":
+			case "*":
+				category.catchAll = true
+			default:
+				category.labels = append(category.labels, label)
+			}
+		}
+		categories = append(categories, category)
+	}
+
+	if len(categories) == 0 {
+		return nil, e.tr.Errorf("err.release_category", e.config.ReleaseCategories)
+	}
+	return categories, nil
+}
+
+// releaseRange liest --since und --until (jeweils inklusive, YYYY-MM-DD)
+func (e *Exporter) releaseRange() (*time.Time, *time.Time, error) {
+	since, err := e.parseReleaseDate("since", e.config.ReleaseSince)
+	if err != nil {
+		return nil, nil, err
+	}
+	until, err := e.parseReleaseDate("until", e.config.ReleaseUntil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return since, until, nil
+}
+
+func (e *Exporter) parseReleaseDate(name string, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(releaseDateLayout, value)
+	if err != nil {
+		return nil, e.tr.Errorf("err.release_date", value, name)
+	}
+	return &t, nil
+}
+
+// releaseVersion liefert die Version der Überschrift: --release-version, sonst der Milestone
+func (e *Exporter) releaseVersion() string {
+	if e.config.ReleaseVersion != "" {
+		return e.config.ReleaseVersion
+	}
+	if milestone := e.config.MilestoneTitle; milestone != nil && *milestone != "" && *milestone != "*" {
+		return *milestone
+	}
+	return unreleasedVersion
+}
+
+// releaseDate liefert das Datum der Überschrift: das Ende des Zeitraums, sonst heute
+func (e *Exporter) releaseDate(until *time.Time) string {
+	if until != nil {
+		return until.Format(releaseDateLayout)
+	}
+	return time.Now().Format(releaseDateLayout)
+}
+
+// renderReleaseNotes erzeugt einen Abschnitt wie
+//
+//	## [1.2.0] - 2024-03-01
+//
+//	### Fixed
+//
+//	- Login schlägt fehl ([#12](https://...))
+func renderReleaseNotes(version string, date string, categories []releaseCategory, entries []releaseEntry) string {
+	grouped := make(map[int][]releaseEntry)
+	for _, entry := range entries {
+		if index := releaseCategoryIndex(categories, entry.labels); index >= 0 {
+			grouped[index] = append(grouped[index], entry)
+		}
+	}
+
+	var b strings.Builder
+	if version == unreleasedVersion {
+		fmt.Fprintf(&b, "## [%s]\n", version)
+	} else {
+		fmt.Fprintf(&b, "## [%s] - %s\n", version, date)
+	}
+
+	for i, category := range categories {
+		if len(grouped[i]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", category.name)
+		for _, entry := range grouped[i] {
+			fmt.Fprintf(&b, "- %s ([%s](%s))\n", utils.EscapeMarkdown(singleLine(entry.title)), entry.ref, entry.url)
+		}
+	}
+
+	return b.String()
+}
+
+// releaseCategoryIndex liefert die erste Kategorie, deren Labels passen. Scoped Labels
+// wie "type::bug" passen auch über ihren Wert. Ohne Treffer greift die Auffang-Kategorie (-1, wenn keine).
+func releaseCategoryIndex(categories []releaseCategory, labels []string) int {
+	for i, category := range categories {
+		for _, want := range category.labels {
+			for _, label := range labels {
+				if labelMatches(label, want) {
+					return i
+				}
+			}
+		}
+	}
+
+	for i, category := range categories {
+		if category.catchAll {
+			return i
+		}
+	}
+	return -1
+}
+
+// labelMatches vergleicht ein Label ohne Groß-/Kleinschreibung, Scoped Labels auch über ihren Wert
+func labelMatches(label string, want string) bool {
+	_, value, scoped := strings.Cut(label, "::")
+	return strings.EqualFold(label, want) || (scoped && strings.EqualFold(value, want))
+}
+
+// sortReleaseEntries sortiert Einträge nach IID, damit wiederholte Läufe identische Abschnitte erzeugen
+func sortReleaseEntries(entries []releaseEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return iidLess(entries[i].ref[1:], entries[j].ref[1:])
+	})
+}
+
+// prependChangelog fügt den Abschnitt oberhalb der bisherigen Versionen ein. Ein vorhandener
+// Abschnitt derselben Version wird ersetzt, eine fehlende Datei mit Kopfzeilen angelegt.
+func (e *Exporter) prependChangelog(path string, section string) error {
+	existing, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		existing = []byte(e.tr.T("changelog.header"))
+	} else if err != nil {
+		return err
+	}
+
+	content := insertChangelogSection(string(existing), section)
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+// insertChangelogSection ersetzt den Abschnitt mit gleicher Überschrift oder fügt ihn vor der
+// ersten Version ein ("## [Unreleased]" bleibt oben). Ohne Versionen wird er angehängt.
+func insertChangelogSection(changelog string, section string) string {
+	heading, _, _ := strings.Cut(section, "\n")
+	version, _, _ := strings.Cut(heading, "]")
+	section = strings.TrimRight(section, "\n") + "\n\n"
+
+	lines := strings.SplitAfter(changelog, "\n")
+
+	start, end := -1, len(lines)
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "## ") {
+			continue
+		}
+		if start >= 0 {
+			end = i
+			break
+		}
+		if strings.HasPrefix(line, version+"]") {
+			start = i
+		}
+	}
+	if start < 0 {
+		start, end = len(lines), len(lines)
+		for i, line := range lines {
+			if strings.HasPrefix(line, "## ") && !strings.HasPrefix(line, "## ["+unreleasedVersion+"]") {
+				start, end = i, i
+				break
+			}
+		}
+	}
+
+	var b strings.Builder
+	if before := strings.TrimRight(strings.Join(lines[:start], ""), "\n"); before != "" {
+		b.WriteString(before + "\n\n")
+	}
+	b.WriteString(section)
+	b.WriteString(strings.Join(lines[end:], ""))
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// releaseEntriesFromIssues bildet geschlossene Issues auf Einträge ab
+func releaseEntriesFromIssues(issues []todoistDomain.Issue) []releaseEntry {
+	entries := make([]releaseEntry, 0, len(issues))
+	for _, issue := range issues {
+		entries = append(entries, releaseEntry{title: issue.Title, ref: "#" + issue.IID, url: issue.WebURL, labels: labelTitles(issue)})
+	}
+	return entries
+}
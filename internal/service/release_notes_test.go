@@ -0,0 +1,127 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+)
+
+func TestRenderReleaseNotes_GroupsByCategory(t *testing.T) {
+	exporter := NewExporter(&config.Config{ReleaseCategories: config.DefaultReleaseCategories})
+	categories, err := exporter.parseReleaseCategories()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := []releaseEntry{
+		{title: "Login *broken*", ref: "#12", url: "u12", labels: []string{"Bug"}},
+		{title: "Dark mode", ref: "#3", url: "u3", labels: []string{"type::feature", "bug"}},
+		{title: "Refactoring", ref: "#5", url: "u5"},
+		{title: "Patch CVE", ref: "!34", url: "m34", labels: []string{"security"}},
+	}
+
+	got := renderReleaseNotes("1.2.0", "2024-03-31", categories, entries)
+	expected := "## [1.2.0] - 2024-03-31\n\n" +
+		"### Added\n\n- Dark mode ([#3](u3))\n\n" +
+		"### Changed\n\n- Refactoring ([#5](u5))\n\n" +
+		"### Fixed\n\n- Login \\*broken\\* ([#12](u12))\n\n" +
+		"### Security\n\n- Patch CVE ([!34](m34))\n"
+	if got != expected {
+		t.Errorf("unexpected release notes:\n%s\nwant:\n%s", got, expected)
+	}
+
+	if got := renderReleaseNotes(unreleasedVersion, "2024-03-31", categories, nil); got != "## [Unreleased]\n" {
+		t.Errorf("unreleased section should have no date: %q", got)
+	}
+}
+
+func TestParseReleaseCategories_Invalid(t *testing.T) {
+	exporter := NewExporter(&config.Config{ReleaseCategories: "Added=feature;bug"})
+	if _, err := exporter.parseReleaseCategories(); err == nil {
+		t.Error("category without name should be rejected")
+	}
+}
+
+func TestInsertChangelogSection(t *testing.T) {
+	header := "# Changelog\n\nIntro.\n"
+	existing := header + "\n## [Unreleased]\n\n### Added\n\n- WIP\n\n## [1.1.0] - 2024-01-01\n\n### Fixed\n\n- Old\n"
+
+	got := insertChangelogSection(existing, "## [1.2.0] - 2024-03-31\n\n### Fixed\n\n- New\n")
+	expected := header + "\n## [Unreleased]\n\n### Added\n\n- WIP\n\n" +
+		"## [1.2.0] - 2024-03-31\n\n### Fixed\n\n- New\n\n" +
+		"## [1.1.0] - 2024-01-01\n\n### Fixed\n\n- Old\n"
+	if got != expected {
+		t.Errorf("unexpected changelog:\n%s", got)
+	}
+
+	// Ein erneuter Lauf ersetzt den Abschnitt, statt ihn zu verdoppeln
+	again := insertChangelogSection(got, "## [1.2.0] - 2024-04-01\n\n### Fixed\n\n- Newer\n")
+	if strings.Count(again, "## [1.2.0]") != 1 || !strings.Contains(again, "- Newer\n\n## [1.1.0]") {
+		t.Errorf("section should be replaced:\n%s", again)
+	}
+
+	if got := insertChangelogSection(header, "## [1.0.0] - 2024-01-01\n"); got != header+"\n## [1.0.0] - 2024-01-01\n" {
+		t.Errorf("section should be appended to a changelog without versions: %q", got)
+	}
+}
+
+func TestReleaseNotes_PrependsChangelog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v4/user" {
+			_, _ = w.Write([]byte(`{"id": 1}`))
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if strings.Contains(body["query"], "mergeRequests") {
+			// Merge Requests sind optional, Fehler dürfen den Lauf nicht abbrechen
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if !strings.Contains(body["query"], `closedBefore: "2024-04-01T00:00:00Z"`) {
+			t.Errorf("until should be inclusive: %s", body["query"])
+		}
+		_, _ = w.Write([]byte(`{"data":{"project":{"issues":{"nodes":[` +
+			`{"iid":"12","title":"Crash","web_url":"u12","labels":{"nodes":[{"title":"bug"}]}},` +
+			`{"iid":"3","title":"Export","web_url":"u3","labels":{"nodes":[{"title":"feature"}]}}]}}}}`))
+	}))
+	defer srv.Close()
+
+	changelog := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	exporter := NewExporter(&config.Config{
+		GitLabToken: "t", GitLabURL: srv.URL, ProjectPath: "g/p", Lang: "en",
+		ReleaseVersion: "1.2.0", ReleaseUntil: "2024-03-31", ReleaseCategories: config.DefaultReleaseCategories,
+		ReleaseChangelog: changelog, ReleaseMergeRequests: true,
+	})
+
+	if err := exporter.ReleaseNotes(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(changelog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), "# Changelog\n") ||
+		!strings.HasSuffix(string(content), "## [1.2.0] - 2024-03-31\n\n### Added\n\n- Export ([#3](u3))\n\n### Fixed\n\n- Crash ([#12](u12))\n") {
+		t.Errorf("unexpected changelog:\n%s", content)
+	}
+}
+
+func TestReleaseNotes_RequiresScope(t *testing.T) {
+	exporter := NewExporter(&config.Config{GitLabToken: "t", ProjectPath: "g/p", ReleaseCategories: config.DefaultReleaseCategories})
+	if err := exporter.ReleaseNotes(); err == nil || !strings.Contains(err.Error(), "--since") {
+		t.Errorf("expected scope error, got %v", err)
+	}
+
+	exporter.config.ReleaseSince = "01.01.2024"
+	if err := exporter.ReleaseNotes(); err == nil || !strings.Contains(err.Error(), "YYYY-MM-DD") {
+		t.Errorf("expected date error, got %v", err)
+	}
+}
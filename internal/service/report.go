@@ -0,0 +1,540 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+// defaultReportFile ersetzt beim report-Befehl den Standard-Dateinamen des Exports
+const defaultReportFile = "gitlab_report.md"
+
+// reportTaskPriority ist die höchste Todoist-Priorität (p1)
+const reportTaskPriority = 4
+
+// reportTaskPrefix kennzeichnet Aufgaben aus dem Report, z.B. "⚠️ #12 - Titel"
+const reportTaskPrefix = "⚠️ "
+
+// reportSyncStats ergänzt die Statistik um Aufgaben, deren Issue nicht mehr auffällig ist
+type reportSyncStats struct {
+	SyncStats
+	// Closed zählt geschlossene Aufgaben, deren Issue nicht mehr im Report steht
+	Closed int
+}
+
+// reportTaskUpdate ist eine bestehende Aufgabe, die an den aktuellen Report angepasst wird
+type reportTaskUpdate struct {
+	task    todoistDomain.Task
+	request todoistDomain.CreateTaskRequest
+}
+
+// reportTaskPlan beschreibt, wie die Aufgaben der Section an den Report angeglichen werden
+type reportTaskPlan struct {
+	create []todoistDomain.CreateTaskRequest
+	update []reportTaskUpdate
+	skip   int
+	close  []todoistDomain.Task
+}
+
+// Report ermittelt offene Issues, die überfällig, bald fällig, lange unverändert oder nicht
+// zugewiesen sind, schreibt sie im konfigurierten Format und legt optional Todoist-Aufgaben an
+func (e *Exporter) Report() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.config.Validate(); err != nil {
+		return e.tr.Errorf("err.invalid_config", err)
+	}
+	if e.config.ReportTodoist && e.config.TodoistToken == "" {
+		return e.tr.Errorf("err.invalid_config", e.tr.Errorf("config.missing_todoist_token"))
+	}
+	if e.config.ReportDueSoonDays < 0 || e.config.ReportStaleDays < 0 {
+		return e.tr.Errorf("err.report_days")
+	}
+
+	format, err := e.outputFormat()
+	if err != nil {
+		return err
+	}
+
+	e.progress("progress.loading_issues", e.config.ProjectPath)
+
+	issues, err := e.loadGitLabIssues()
+	if err != nil {
+		return e.tr.Errorf("err.loading_issues", err)
+	}
+	e.progress("progress.found_issues", len(issues))
+
+	report := e.attentionReport(issues, time.Now())
+	flagged := attentionIssues(issues, report)
+	e.progress("progress.report_found", len(report.Overdue), len(report.DueSoon), len(report.Stale), len(report.Unassigned))
+
+	e.progress("progress.exporting_file", format.name)
+
+	filename := e.reportFilename()
+	switch {
+	case e.formatName() == FormatMarkdown:
+		err = e.writeOutput(filename, len(flagged), func(w io.Writer) error {
+			_, err := io.WriteString(w, e.renderReportMarkdown(report))
+			return err
+		})
+	case e.formatName() == FormatJSON:
+		err = e.writeOutput(filename, len(flagged), func(w io.Writer) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(report)
+		})
+	case format.writeDir != nil:
+		err = e.exportToDir(format, filename, flagged)
+	default:
+		// Alle übrigen Formate erhalten die betroffenen Issues wie beim normalen Export
+		err = e.writeOutput(filename, len(flagged), func(w io.Writer) error {
+			return format.write(e, w, flagged)
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if !e.config.ReportTodoist {
+		return nil
+	}
+
+	e.progress("progress.exporting_todoist")
+	stats, err := e.pushReportToTodoist(report)
+	if err != nil {
+		return err
+	}
+	e.progress("progress.created", stats.Created)
+	e.progress("progress.updated", stats.Updated)
+	e.progress("progress.skipped", stats.Skipped)
+	e.progress("progress.report_closed", stats.Closed)
+	return nil
+}
+
+// reportFilename verwendet statt des Standard-Dateinamens des Exports einen eigenen,
+// damit der Report den letzten Export nicht überschreibt
+func (e *Exporter) reportFilename() string {
+	filename := e.generateFilename()
+	if e.config.OutputFile == config.DefaultOutputFile {
+		return withFormatExtension(defaultReportFile, filepath.Ext(filename))
+	}
+	return filename
+}
+
+// attentionReport ordnet offene Issues den Kategorien zu; ein Issue kann in mehreren vorkommen
+func (e *Exporter) attentionReport(issues []todoistDomain.Issue, now time.Time) todoistDomain.AttentionReport {
+	now = now.Local()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	report := todoistDomain.AttentionReport{
+		SchemaVersion: todoistDomain.ExportSchemaVersion,
+		Metadata:      e.exportMetadata(issues),
+		DueSoonDays:   e.config.ReportDueSoonDays,
+		StaleDays:     e.config.ReportStaleDays,
+		Overdue:       []todoistDomain.AttentionIssue{},
+		DueSoon:       []todoistDomain.AttentionIssue{},
+		Stale:         []todoistDomain.AttentionIssue{},
+		Unassigned:    []todoistDomain.AttentionIssue{},
+		Assignees:     []todoistDomain.AssigneeAttention{},
+	}
+
+	byAssignee := make(map[string]*todoistDomain.AssigneeAttention)
+	count := func(issue todoistDomain.Issue, field func(*todoistDomain.AssigneeAttention) *int) {
+		for _, name := range assigneeNames(issue) {
+			entry, ok := byAssignee[name]
+			if !ok {
+				entry = &todoistDomain.AssigneeAttention{Assignee: name}
+				byAssignee[name] = entry
+			}
+			*field(entry)++
+		}
+	}
+
+	for _, issue := range issues {
+		if issue.State != "opened" {
+			continue
+		}
+		entry := func(days int) todoistDomain.AttentionIssue {
+			assignees := assigneeNames(issue)
+			if assignees == nil {
+				assignees = []string{}
+			}
+			return todoistDomain.AttentionIssue{
+				IssueRef:  todoistDomain.IssueRef{IID: issue.IID, Title: issue.Title, WebURL: issue.WebURL},
+				Assignees: assignees,
+				DueDate:   issueDueDate(issue),
+				UpdatedAt: issue.UpdatedAt,
+				Days:      days,
+			}
+		}
+
+		if due, err := time.ParseInLocation(releaseDateLayout, issueDueDate(issue), time.Local); err == nil {
+			days := calendarDays(today, due)
+			switch {
+			case days < 0:
+				report.Overdue = append(report.Overdue, entry(-days))
+				count(issue, func(a *todoistDomain.AssigneeAttention) *int { return &a.Overdue })
+			case days <= e.config.ReportDueSoonDays:
+				report.DueSoon = append(report.DueSoon, entry(days))
+				count(issue, func(a *todoistDomain.AssigneeAttention) *int { return &a.DueSoon })
+			}
+		}
+
+		if e.config.ReportStaleDays > 0 {
+			if days := int(now.Sub(issue.UpdatedAt).Hours() / 24); days >= e.config.ReportStaleDays {
+				report.Stale = append(report.Stale, entry(days))
+				count(issue, func(a *todoistDomain.AssigneeAttention) *int { return &a.Stale })
+			}
+		}
+
+		if len(issue.Assignees.Nodes) == 0 {
+			report.Unassigned = append(report.Unassigned, entry(int(now.Sub(issue.CreatedAt).Hours()/24)))
+		}
+	}
+
+	// Dringendes zuerst: am längsten überfällig, am frühesten fällig, am längsten unverändert
+	sortAttention(report.Overdue, true)
+	sortAttention(report.DueSoon, false)
+	sortAttention(report.Stale, true)
+	sortAttention(report.Unassigned, true)
+
+	for _, entry := range byAssignee {
+		report.Assignees = append(report.Assignees, *entry)
+	}
+	sort.Slice(report.Assignees, func(i, j int) bool {
+		a, b := report.Assignees[i], report.Assignees[j]
+		if a.Overdue != b.Overdue {
+			return a.Overdue > b.Overdue
+		}
+		if a.DueSoon != b.DueSoon {
+			return a.DueSoon > b.DueSoon
+		}
+		if a.Stale != b.Stale {
+			return a.Stale > b.Stale
+		}
+		return a.Assignee < b.Assignee
+	})
+
+	return report
+}
+
+// calendarDays liefert die Anzahl Kalendertage von from bis to (negativ, wenn to davor liegt)
+func calendarDays(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+func sortAttention(issues []todoistDomain.AttentionIssue, descending bool) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Days != issues[j].Days {
+			return (issues[i].Days > issues[j].Days) == descending
+		}
+		return iidLess(issues[i].IID, issues[j].IID)
+	})
+}
+
+// attentionIssues liefert die Issues, die in mindestens einer Kategorie vorkommen (nach IID sortiert)
+func attentionIssues(issues []todoistDomain.Issue, report todoistDomain.AttentionReport) []todoistDomain.Issue {
+	flagged := make(map[string]bool)
+	for _, category := range [][]todoistDomain.AttentionIssue{report.Overdue, report.DueSoon, report.Stale, report.Unassigned} {
+		for _, issue := range category {
+			flagged[issue.IID] = true
+		}
+	}
+
+	var result []todoistDomain.Issue
+	for _, issue := range issues {
+		if flagged[issue.IID] {
+			result = append(result, issue)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return iidLess(result[i].IID, result[j].IID)
+	})
+	return result
+}
+
+// renderReportMarkdown erzeugt je Kategorie eine Tabelle und eine Übersicht pro Person
+func (e *Exporter) renderReportMarkdown(report todoistDomain.AttentionReport) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", e.tr.T("report.title", report.Metadata.Project))
+	fmt.Fprintf(&b, "**%s:** %s  \n", e.tr.T("md.export_time"), report.Metadata.ExportedAt.Local().Format(e.tr.T("datetime.layout")))
+	if milestone := report.Metadata.Filters.Milestone; milestone != "" {
+		fmt.Fprintf(&b, "**%s:** %s  \n", e.tr.T("md.milestone"), utils.EscapeMarkdown(milestone))
+	}
+
+	if report.Empty() {
+		fmt.Fprintf(&b, "\n%s\n", e.tr.T("report.none"))
+		return b.String()
+	}
+
+	section := func(title string, daysHeader string, issues []todoistDomain.AttentionIssue) {
+		if len(issues) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", title, len(issues))
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n|---|---|---|---:|\n",
+			e.tr.T("report.issue"), e.tr.T("md.due"), e.tr.T("md.assigned"), daysHeader)
+		for _, issue := range issues {
+			due, assignees := "–", "–"
+			if issue.DueDate != "" {
+				due = e.tr.FormatDate(issue.DueDate)
+			}
+			if len(issue.Assignees) > 0 {
+				assignees = utils.EscapeMarkdown(strings.Join(issue.Assignees, ", "))
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %d |\n", diffIssueLink(issue.IssueRef), due, assignees, issue.Days)
+		}
+	}
+
+	section(e.tr.T("report.overdue"), e.tr.T("report.days_overdue"), report.Overdue)
+	section(e.tr.T("report.due_soon", report.DueSoonDays), e.tr.T("report.days_until"), report.DueSoon)
+	section(e.tr.T("report.stale", report.StaleDays), e.tr.T("report.days_stale"), report.Stale)
+	section(e.tr.T("report.unassigned"), e.tr.T("report.days_open"), report.Unassigned)
+
+	if len(report.Assignees) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", e.tr.T("report.by_assignee"))
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n|---|---:|---:|---:|\n", e.tr.T("analytics.assignee"),
+			e.tr.T("report.col_overdue"), e.tr.T("report.col_due_soon"), e.tr.T("report.col_stale"))
+		for _, entry := range report.Assignees {
+			fmt.Fprintf(&b, "| %s | %d | %d | %d |\n", utils.EscapeMarkdown(entry.Assignee), entry.Overdue, entry.DueSoon, entry.Stale)
+		}
+	}
+
+	return b.String()
+}
+
+// pushReportToTodoist legt je betroffenem Issue eine Aufgabe mit höchster Priorität für die
+// zugewiesenen Personen an. Bestehende Aufgaben werden aktualisiert statt verdoppelt, Aufgaben
+// nicht mehr auffälliger Issues geschlossen.
+func (e *Exporter) pushReportToTodoist(report todoistDomain.AttentionReport) (reportSyncStats, error) {
+	var stats reportSyncStats
+
+	projectID, _, err := e.ensureTodoistSetup()
+	if err != nil {
+		return stats, err
+	}
+	sectionID, err := e.attentionSection(projectID)
+	if err != nil {
+		return stats, e.tr.Errorf("err.section_setup", err)
+	}
+
+	// Zuweisen geht nur in geteilten Projekten; ohne Mitglieder bleiben die Aufgaben unzugewiesen
+	collaborators, err := e.todoistRepo.GetProjectCollaborators(projectID)
+	if err != nil {
+		slog.Debug("todoist collaborators unavailable", "project_id", projectID, "error", err)
+	}
+
+	tasks, err := e.todoistRepo.GetProjectTasks(projectID)
+	if err != nil {
+		e.resetTodoistCache()
+		return stats, e.tr.Errorf("err.loading_tasks", err)
+	}
+	plan := planReportTasks(e.attentionTasks(report, projectID, sectionID, collaborators), tasks, sectionID)
+	stats.Skipped = plan.skip
+
+	for _, request := range plan.create {
+		if _, err := e.todoistRepo.CreateTask(request); err != nil {
+			slog.Warn("creating report task failed", "task", request.Content, "error", err)
+			stats.Failed++
+			continue
+		}
+		stats.Created++
+	}
+
+	for _, update := range plan.update {
+		updates := map[string]interface{}{
+			"content":     update.request.Content,
+			"description": update.request.Description,
+			"priority":    update.request.Priority,
+		}
+		if _, err := e.todoistRepo.UpdateTask(update.task.ID, updates); err != nil {
+			slog.Warn("updating report task failed", "task_id", update.task.ID, "error", err)
+			stats.Failed++
+			continue
+		}
+		stats.Updated++
+	}
+
+	for _, task := range plan.close {
+		if err := e.todoistRepo.CloseTask(task.ID); err != nil {
+			slog.Warn("closing report task failed", "task_id", task.ID, "error", err)
+			stats.Failed++
+			continue
+		}
+		stats.Closed++
+	}
+
+	return stats, nil
+}
+
+// planReportTasks gleicht die Aufgaben des Reports mit den Aufgaben der Section ab. Zugeordnet
+// wird über IID und zugewiesene Person, damit ein umbenanntes Issue keine zweite Aufgabe erzeugt.
+// Aufgaben der Section, die zu keinem auffälligen Issue mehr gehören, werden geschlossen.
+func planReportTasks(requests []todoistDomain.CreateTaskRequest, tasks []todoistDomain.Task, sectionID string) reportTaskPlan {
+	var plan reportTaskPlan
+
+	existing := make(map[string]todoistDomain.Task)
+	var reportTasks []todoistDomain.Task
+	for _, task := range tasks {
+		key := reportTaskKey(task.Content, task.AssigneeID)
+		if task.SectionID != sectionID || key == "" {
+			continue
+		}
+		reportTasks = append(reportTasks, task)
+		if _, ok := existing[key]; !ok {
+			existing[key] = task
+		}
+	}
+
+	matched := make(map[string]bool)
+	for _, request := range requests {
+		task, ok := existing[reportTaskKey(request.Content, request.AssigneeID)]
+		if !ok {
+			plan.create = append(plan.create, request)
+			continue
+		}
+		matched[task.ID] = true
+
+		if task.Content == request.Content && task.Description == request.Description && task.Priority == request.Priority {
+			plan.skip++
+			continue
+		}
+		plan.update = append(plan.update, reportTaskUpdate{task: task, request: request})
+	}
+
+	// Nicht mehr auffällige Issues und Duplikate früherer Läufe
+	for _, task := range reportTasks {
+		if !matched[task.ID] {
+			plan.close = append(plan.close, task)
+		}
+	}
+
+	return plan
+}
+
+// reportTaskKey liefert "IID\x00Person" für Aufgaben aus dem Report, sonst ""
+func reportTaskKey(content string, assigneeID string) string {
+	if !strings.HasPrefix(content, reportTaskPrefix) {
+		return ""
+	}
+	iid := extractIssueIIDFromContent(strings.TrimPrefix(content, reportTaskPrefix))
+	if iid == "" {
+		return ""
+	}
+	return iid + "\x00" + assigneeID
+}
+
+// attentionSection sucht oder erstellt die Section für Aufgaben aus dem Report
+func (e *Exporter) attentionSection(projectID string) (string, error) {
+	name := e.tr.T("section.attention")
+	section, err := e.todoistRepo.FindSectionByName(projectID, e.tr.Variants("section.attention")...)
+	if err != nil {
+		return "", err
+	}
+	if section != nil {
+		return section.ID, nil
+	}
+
+	section, err = e.todoistRepo.CreateSection(projectID, name, len(e.todoistSectionLayout())+1)
+	if err != nil {
+		return "", e.tr.Errorf("err.section_create", name, err)
+	}
+	return section.ID, nil
+}
+
+// attentionTasks bildet den Report auf Todoist-Aufgaben ab: eine Aufgabe pro Issue und
+// zugewiesener Person mit allen Gründen in der Beschreibung
+func (e *Exporter) attentionTasks(report todoistDomain.AttentionReport, projectID string, sectionID string, collaborators []todoistDomain.Collaborator) []todoistDomain.CreateTaskRequest {
+	type flaggedIssue struct {
+		issue   todoistDomain.AttentionIssue
+		reasons []string
+	}
+	var order []string
+	flagged := make(map[string]*flaggedIssue)
+	add := func(issues []todoistDomain.AttentionIssue, reason func(todoistDomain.AttentionIssue) string) {
+		for _, issue := range issues {
+			entry, ok := flagged[issue.IID]
+			if !ok {
+				entry = &flaggedIssue{issue: issue}
+				flagged[issue.IID] = entry
+				order = append(order, issue.IID)
+			}
+			entry.reasons = append(entry.reasons, reason(issue))
+		}
+	}
+
+	add(report.Overdue, func(issue todoistDomain.AttentionIssue) string {
+		return e.tr.T("report.reason_overdue", e.tr.FormatDate(issue.DueDate))
+	})
+	add(report.DueSoon, func(issue todoistDomain.AttentionIssue) string {
+		return e.tr.T("report.reason_due_soon", e.tr.FormatDate(issue.DueDate))
+	})
+	add(report.Stale, func(issue todoistDomain.AttentionIssue) string {
+		return e.tr.T("report.reason_stale", issue.UpdatedAt.Local().Format(e.tr.T("date.layout")))
+	})
+	add(report.Unassigned, func(todoistDomain.AttentionIssue) string {
+		return e.tr.T("report.reason_unassigned")
+	})
+
+	var requests []todoistDomain.CreateTaskRequest
+	for _, iid := range order {
+		entry := flagged[iid]
+		issue := entry.issue
+
+		description := []string{fmt.Sprintf("🔗 [GitLab Issue #%s](%s)", issue.IID, issue.WebURL), ""}
+		for _, reason := range entry.reasons {
+			description = append(description, "- "+reason)
+		}
+		if len(issue.Assignees) > 0 {
+			description = append(description, "", fmt.Sprintf("👤 **%s:** %s", e.tr.T("task.assignees"), strings.Join(issue.Assignees, ", ")))
+		}
+
+		request := todoistDomain.CreateTaskRequest{
+			Content:     fmt.Sprintf("%s#%s - %s", reportTaskPrefix, issue.IID, issue.Title),
+			Description: strings.Join(description, "\n"),
+			ProjectID:   projectID,
+			SectionID:   sectionID,
+			Priority:    reportTaskPriority,
+		}
+		if issue.DueDate != "" {
+			request.DueDate = utils.ConvertToTodoistDate(issue.DueDate)
+		}
+
+		assigneeIDs := collaboratorIDs(issue.Assignees, collaborators)
+		if len(assigneeIDs) == 0 {
+			requests = append(requests, request)
+			continue
+		}
+		for _, id := range assigneeIDs {
+			request.AssigneeID = id
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// collaboratorIDs ordnet GitLab-Namen den Todoist-Mitgliedern zu (Groß-/Kleinschreibung egal)
+func collaboratorIDs(names []string, collaborators []todoistDomain.Collaborator) []string {
+	var ids []string
+	for _, name := range names {
+		for _, collaborator := range collaborators {
+			if strings.EqualFold(strings.TrimSpace(collaborator.Name), strings.TrimSpace(name)) {
+				ids = append(ids, collaborator.ID)
+				break
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+// reportTestNow ist ein fester Zeitpunkt, damit Fälligkeiten und Inaktivität stabil bleiben
+var reportTestNow = time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+
+func reportTestIssues() []todoistDomain.Issue {
+	due := func(date string) *string { return &date }
+	anna := todoistDomain.Assignees{Nodes: []todoistDomain.Assignee{{Name: "Anna"}}}
+	recent := reportTestNow.AddDate(0, 0, -1)
+	return []todoistDomain.Issue{
+		{IID: "1", Title: "Login", State: "opened", WebURL: "u1", DueDate: due("2024-03-07"), Assignees: anna, UpdatedAt: recent},
+		{IID: "2", Title: "Export", State: "opened", WebURL: "u2", DueDate: due("2024-03-12"), Assignees: anna, UpdatedAt: reportTestNow.AddDate(0, 0, -20)},
+		{IID: "3", Title: "Idea", State: "opened", WebURL: "u3", CreatedAt: reportTestNow.AddDate(0, 0, -5), UpdatedAt: recent},
+		{IID: "4", Title: "Later", State: "opened", WebURL: "u4", DueDate: due("2024-04-30"), Assignees: anna, UpdatedAt: recent},
+		{IID: "5", Title: "Done", State: "closed", WebURL: "u5", DueDate: due("2024-03-01"), UpdatedAt: reportTestNow.AddDate(0, -1, 0)},
+	}
+}
+
+func TestAttentionReport_Classifies(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", ReportDueSoonDays: 7, ReportStaleDays: 14})
+	report := exporter.attentionReport(reportTestIssues(), reportTestNow)
+
+	check := func(name string, got interface{}, want string) {
+		t.Helper()
+		encoded, _ := json.Marshal(got)
+		if string(encoded) != want {
+			t.Errorf("%s = %s, want %s", name, encoded, want)
+		}
+	}
+
+	days := func(issues []todoistDomain.AttentionIssue) []string {
+		var result []string
+		for _, issue := range issues {
+			result = append(result, fmt.Sprintf("%s:%d", issue.IID, issue.Days))
+		}
+		return result
+	}
+	check("overdue", days(report.Overdue), `["1:3"]`)
+	check("due_soon", days(report.DueSoon), `["2:2"]`)
+	check("stale", days(report.Stale), `["2:20"]`)
+	check("unassigned", days(report.Unassigned), `["3:5"]`)
+	check("assignees", report.Assignees, `[{"assignee":"Anna","overdue":1,"due_soon":1,"stale":1}]`)
+
+	flagged := attentionIssues(reportTestIssues(), report)
+	if len(flagged) != 3 || flagged[0].IID != "1" || flagged[2].IID != "3" {
+		t.Errorf("unexpected flagged issues: %+v", flagged)
+	}
+
+	// Ohne Stale-Schwelle entfällt die Kategorie
+	exporter.config.ReportStaleDays = 0
+	if report := exporter.attentionReport(reportTestIssues(), reportTestNow); len(report.Stale) != 0 {
+		t.Errorf("stale detection should be disabled: %+v", report.Stale)
+	}
+}
+
+func TestRenderReportMarkdown(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: "en", ReportDueSoonDays: 7, ReportStaleDays: 14})
+	got := exporter.renderReportMarkdown(exporter.attentionReport(reportTestIssues(), reportTestNow))
+
+	for _, want := range []string{
+		"# Needs attention – g/p\n",
+		"## 🔥 Overdue (1)\n\n| Issue | Due | Assignees | Days overdue |\n|---|---|---|---:|\n| [#1 - Login](u1) | 2024-03-07 | Anna | 3 |\n",
+		"## ⏰ Due within the next 7 days (1)\n",
+		"## 💤 Unchanged for at least 14 days (1)\n",
+		"| [#3 - Idea](u3) | – | – | 5 |\n",
+		"## 👥 Per assignee\n\n| Assignee | Overdue | Due soon | Stale |\n|---|---:|---:|---:|\n| Anna | 1 | 1 | 1 |\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown should contain %q:\n%s", want, got)
+		}
+	}
+
+	empty := exporter.renderReportMarkdown(exporter.attentionReport(nil, reportTestNow))
+	if !strings.Contains(empty, "No issues need attention.") {
+		t.Errorf("empty report should say so:\n%s", empty)
+	}
+}
+
+func TestAttentionTasks(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: "en", ReportDueSoonDays: 7, ReportStaleDays: 14})
+	report := exporter.attentionReport(reportTestIssues(), reportTestNow)
+
+	tasks := exporter.attentionTasks(report, "p1", "s9", []todoistDomain.Collaborator{{ID: "c1", Name: "anna"}})
+	if len(tasks) != 3 {
+		t.Fatalf("expected one task per issue, got %+v", tasks)
+	}
+
+	export := tasks[1]
+	if export.Content != "⚠️ #2 - Export" || export.Priority != 4 || export.AssigneeID != "c1" ||
+		export.SectionID != "s9" || export.DueDate != "2024-03-12" {
+		t.Errorf("unexpected task: %+v", export)
+	}
+	if !strings.Contains(export.Description, "- ⏰ Due on 2024-03-12\n- 💤 Unchanged since 2024-02-19") {
+		t.Errorf("description should list all reasons:\n%s", export.Description)
+	}
+
+	if idea := tasks[2]; idea.AssigneeID != "" || !strings.Contains(idea.Description, "Not assigned to anyone") {
+		t.Errorf("unassigned issue should create an unassigned task: %+v", idea)
+	}
+}
+
+func TestPlanReportTasks(t *testing.T) {
+	requests := []todoistDomain.CreateTaskRequest{
+		{Content: "⚠️ #1 - Login", Description: "d1", Priority: 4, AssigneeID: "c1"},
+		{Content: "⚠️ #2 - Export renamed", Description: "d2", Priority: 4},
+		{Content: "⚠️ #3 - New", Description: "d3", Priority: 4},
+	}
+	tasks := []todoistDomain.Task{
+		{ID: "t1", Content: "⚠️ #1 - Login", Description: "d1", Priority: 4, AssigneeID: "c1", SectionID: "s9"},
+		{ID: "t2", Content: "⚠️ #2 - Export", Description: "d2", Priority: 4, SectionID: "s9"},
+		{ID: "t2b", Content: "⚠️ #2 - Export", Description: "d2", Priority: 4, SectionID: "s9"},
+		{ID: "t4", Content: "⚠️ #4 - Back on track", Priority: 4, SectionID: "s9"},
+		{ID: "t5", Content: "⚠️ #1 - Login", Priority: 4, AssigneeID: "c2", SectionID: "s9"},
+		{ID: "own", Content: "Call the customer", SectionID: "s9"},
+		{ID: "open", Content: "⚠️ #7 - Elsewhere", SectionID: "s1"},
+	}
+
+	plan := planReportTasks(requests, tasks, "s9")
+
+	if plan.skip != 1 {
+		t.Errorf("unchanged task t1 should be skipped, got %d", plan.skip)
+	}
+	if len(plan.update) != 1 || plan.update[0].task.ID != "t2" || plan.update[0].request.Content != "⚠️ #2 - Export renamed" {
+		t.Errorf("renamed issue should update its task instead of creating one: %+v", plan.update)
+	}
+	if len(plan.create) != 1 || plan.create[0].Content != "⚠️ #3 - New" {
+		t.Errorf("only the new issue should be created: %+v", plan.create)
+	}
+
+	var closed []string
+	for _, task := range plan.close {
+		closed = append(closed, task.ID)
+	}
+	if strings.Join(closed, ",") != "t2b,t4,t5" {
+		t.Errorf("duplicates, resolved issues and former assignees should be closed, got %v", closed)
+	}
+}
+
+func TestReport_WritesFlaggedIssuesInFormat(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v4/user" {
+			_, _ = w.Write([]byte(`{"id": 1}`))
+			return
+		}
+		now := time.Now().UTC()
+		_, _ = w.Write([]byte(`{"data":{"project":{"issues":{"nodes":[` +
+			`{"iid":"1","title":"Late","state":"opened","due_date":"2000-01-01","updated_at":"` + now.Format(time.RFC3339) + `","assignees":{"nodes":[{"name":"Anna"}]}},` +
+			`{"iid":"2","title":"Fine","state":"opened","updated_at":"` + now.Format(time.RFC3339) + `","assignees":{"nodes":[{"name":"Anna"}]}}]}}}}`))
+	}))
+	defer srv.Close()
+
+	output := filepath.Join(t.TempDir(), "report.csv")
+	exporter := NewExporter(&config.Config{
+		GitLabToken: "t", GitLabURL: srv.URL, ProjectPath: "g/p", Lang: "en",
+		Format: FormatCSV, CSVColumns: "iid,title", OutputFile: output, ReportDueSoonDays: 7, ReportStaleDays: 14,
+	})
+
+	if err := exporter.Report(); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "iid,title\r\n1,Late\r\n" {
+		t.Errorf("only flagged issues should be exported:\n%q", content)
+	}
+}
+
+func TestReport_Validation(t *testing.T) {
+	exporter := NewExporter(&config.Config{GitLabToken: "t", ProjectPath: "g/p", Lang: "en", ReportStaleDays: -1})
+	if err := exporter.Report(); err == nil || !strings.Contains(err.Error(), "negative") {
+		t.Errorf("expected error for negative days, got %v", err)
+	}
+
+	exporter = NewExporter(&config.Config{GitLabToken: "t", ProjectPath: "g/p", Lang: "en", ReportTodoist: true})
+	if err := exporter.Report(); err == nil || !strings.Contains(err.Error(), "TODOIST_TOKEN") {
+		t.Errorf("expected missing Todoist token, got %v", err)
+	}
+}
+
+func TestReportFilename(t *testing.T) {
+	exporter := NewExporter(&config.Config{OutputFile: config.DefaultOutputFile, Format: FormatHTML})
+	if got := exporter.reportFilename(); got != "gitlab_report.html" {
+		t.Errorf("default report filename = %q", got)
+	}
+	exporter.config.OutputFile = "-"
+	if got := exporter.reportFilename(); got != "-" {
+		t.Errorf("custom output should be kept, got %q", got)
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"log/slog"
+	"strings"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
+	stateRepo "hufschlaeger.net/gitlab-tasks-exporter/internal/repository/state"
+)
+
+// GitLab-Aktionen, die durch Todoist-Events ausgelöst werden können
+const (
+	ActionClose   = "close"
+	ActionReopen  = "reopen"
+	ActionUpdate  = "update"
+	ActionComment = "comment"
+	ActionIgnore  = "ignore"
+
+	// ActionTodoDone markiert ein GitLab-To-Do als erledigt (Tasks aus dem todos-Befehl, nicht konfigurierbar)
+	ActionTodoDone = "todo_done"
+)
+
+// TodoistEvent ist ein normalisiertes Todoist-Webhook-Event
+type TodoistEvent struct {
+	Name        string
+	TaskID      string
+	TaskContent string
+	// ProjectID ist das Todoist-Projekt des Tasks (Webhooks kommen für alle Projekte des Kontos)
+	ProjectID string
+	DueDate   string
+	// DueChanged ist gesetzt, wenn ein item:updated-Event das Fälligkeitsdatum geändert hat
+	DueChanged  bool
+	NoteContent string
+}
+
+// ReverseAction beschreibt die auf GitLab angewendete (oder im Dry-Run geplante) Aktion
+type ReverseAction struct {
+	Event       string                 `json:"event"`
+	Action      string                 `json:"action"`
+	ProjectPath string                 `json:"project_path,omitempty"`
+	IssueIID    string                 `json:"issue_iid,omitempty"`
+	TodoID      string                 `json:"todo_id,omitempty"`
+	Fields      map[string]interface{} `json:"fields,omitempty"`
+	Note        string                 `json:"note,omitempty"`
+	DryRun      bool                   `json:"dry_run,omitempty"`
+	Reason      string                 `json:"reason,omitempty"`
+}
+
+// ParseTodoistActions parst eine Zuordnung wie "item:completed=close,note:added=comment";
+// Fehler werden in der Sprache von tr gemeldet
+func ParseTodoistActions(spec string, tr *i18n.Translator) (map[string]string, error) {
+	actions := make(map[string]string)
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		event, action, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, tr.Errorf("err.webhook_mapping", entry)
+		}
+
+		event = strings.TrimSpace(event)
+		action = strings.TrimSpace(action)
+
+		switch action {
+		case ActionClose, ActionReopen, ActionUpdate, ActionComment, ActionIgnore:
+		default:
+			return nil, tr.Errorf("err.webhook_action", action, event)
+		}
+
+		actions[event] = action
+	}
+
+	return actions, nil
+}
+
+// ApplyTodoistEvent wendet ein Todoist-Event gemäß der Aktionszuordnung auf das verknüpfte GitLab Issue an
+func (e *Exporter) ApplyTodoistEvent(event TodoistEvent) (*ReverseAction, error) {
+	actions, err := ParseTodoistActions(e.config.TodoistWebhookActions, e.tr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialisiert mit Sync-Läufen, die Tasks anlegen und das Todoist-Projekt ermitteln
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.reloadState()
+
+	// Tasks der GitLab-To-Do-Liste gehören zu keinem Issue
+	if e.state != nil && event.TaskID != "" {
+		if link, ok := e.state.TodoLinkByTaskID(event.TaskID); ok {
+			return e.applyTodoEvent(event, link)
+		}
+	}
+
+	result := &ReverseAction{Event: event.Name, Action: actions[event.Name], DryRun: e.config.WebhookDryRun}
+	if result.Action == "" || result.Action == ActionIgnore {
+		result.Action = ActionIgnore
+		result.Reason = "no action configured for event"
+		return result, nil
+	}
+
+	// Ist das synchronisierte Projekt bekannt, werden Events anderer Projekte verworfen
+	if event.ProjectID != "" && e.todoistProjectID != "" && event.ProjectID != e.todoistProjectID {
+		result.Action = ActionIgnore
+		result.Reason = "task is not in the synced Todoist project"
+		return result, nil
+	}
+
+	result.ProjectPath, result.IssueIID = e.resolveLinkedIssue(event)
+	if result.IssueIID == "" {
+		result.Action = ActionIgnore
+		result.Reason = "task is not linked to a GitLab issue"
+		return result, nil
+	}
+
+	switch result.Action {
+	case ActionClose:
+		result.Fields = map[string]interface{}{"state_event": "close"}
+	case ActionReopen:
+		result.Fields = map[string]interface{}{"state_event": "reopen"}
+	case ActionUpdate:
+		result.Fields = buildIssueUpdateFields(event)
+	case ActionComment:
+		if strings.TrimSpace(event.NoteContent) == "" {
+			result.Action = ActionIgnore
+			result.Reason = "empty comment"
+			return result, nil
+		}
+		result.Note = e.tr.T("webhook.todoist_comment", event.NoteContent)
+	}
+
+	if result.DryRun {
+		slog.Info("dry run, gitlab action not applied", "event", event.Name, "action", result.Action,
+			"issue", result.IssueIID, "project", result.ProjectPath, "fields", result.Fields)
+		return result, nil
+	}
+
+	if result.Action == ActionComment {
+		err = e.gitlabRepo.CreateIssueNote(result.ProjectPath, result.IssueIID, result.Note)
+	} else {
+		err = e.gitlabRepo.UpdateIssue(result.ProjectPath, result.IssueIID, result.Fields)
+	}
+	if err != nil {
+		return result, e.tr.Errorf("err.webhook_gitlab", result.Action, result.IssueIID, err)
+	}
+
+	slog.Info("todoist event applied to gitlab", "event", event.Name, "action", result.Action,
+		"issue", result.IssueIID, "project", result.ProjectPath)
+	return result, nil
+}
+
+// applyTodoEvent markiert das GitLab-To-Do eines erledigten Tasks als erledigt; andere Events werden ignoriert
+func (e *Exporter) applyTodoEvent(event TodoistEvent, link stateRepo.TodoLink) (*ReverseAction, error) {
+	result := &ReverseAction{Event: event.Name, Action: ActionTodoDone, TodoID: link.TodoID, DryRun: e.config.WebhookDryRun}
+	if event.Name != "item:completed" {
+		result.Action = ActionIgnore
+		result.Reason = "task belongs to a GitLab todo"
+		return result, nil
+	}
+
+	if result.DryRun {
+		slog.Info("dry run, gitlab todo not marked done", "event", event.Name, "todo", link.TodoID, "task_id", link.TaskID)
+		return result, nil
+	}
+
+	if err := e.gitlabRepo.MarkTodoDone(link.TodoID); err != nil {
+		return result, e.tr.Errorf("err.webhook_todo_done", link.TodoID, err)
+	}
+	e.state.RemoveTodoLink(link.TaskID)
+	e.saveState()
+
+	slog.Info("gitlab todo marked done", "event", event.Name, "todo", link.TodoID, "task_id", link.TaskID)
+	return result, nil
+}
+
+// resolveLinkedIssue sucht das verknüpfte Issue im Sync-Zustand. Der Task-Inhalt ("#12 - ...")
+// reicht nicht: Todoist-Webhooks kommen für alle Projekte, jeder Task mit passendem Titel
+// könnte sonst ein Issue ändern.
+func (e *Exporter) resolveLinkedIssue(event TodoistEvent) (string, string) {
+	if e.state == nil || event.TaskID == "" {
+		return "", ""
+	}
+	link, ok := e.state.LinkByTaskID(event.TaskID)
+	if !ok {
+		return "", ""
+	}
+	return link.ProjectPath, link.IssueIID
+}
+
+// buildIssueUpdateFields übernimmt Titel und Fälligkeitsdatum aus dem Todoist Task
+func buildIssueUpdateFields(event TodoistEvent) map[string]interface{} {
+	fields := make(map[string]interface{})
+
+	// Titel ohne "#123 - "-Präfix
+	title := event.TaskContent
+	if prefix, rest, ok := strings.Cut(title, " - "); ok && extractIssueIIDFromContent(prefix) != "" {
+		title = rest
+	}
+	if title = strings.TrimSpace(title); title != "" {
+		fields["title"] = title
+	}
+
+	// Nur ein geändertes Datum wird übernommen, sonst würde jede Umbenennung eines Tasks
+	// ohne Datum die Fälligkeit in GitLab löschen. Todoist liefert Datum oder Datum mit
+	// Uhrzeit; GitLab kennt nur das Datum.
+	if event.DueChanged {
+		if len(event.DueDate) >= len("2006-01-02") {
+			fields["due_date"] = event.DueDate[:len("2006-01-02")]
+		} else {
+			fields["due_date"] = nil
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
+	stateRepo "hufschlaeger.net/gitlab-tasks-exporter/internal/repository/state"
+)
+
+type gitlabRequest struct {
+	method string
+	path   string
+	body   map[string]interface{}
+}
+
+// newReverseExporter erstellt einen Exporter, dessen GitLab-Anfragen an einen Testserver gehen
+func newReverseExporter(t *testing.T, cfg *config.Config) (*Exporter, *[]gitlabRequest) {
+	t.Helper()
+
+	requests := &[]gitlabRequest{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		*requests = append(*requests, gitlabRequest{r.Method, r.URL.EscapedPath(), body})
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg.GitLabURL = srv.URL
+	cfg.GitLabToken = "token"
+	if cfg.ProjectPath == "" {
+		cfg.ProjectPath = "group/project"
+	}
+	if cfg.TodoistWebhookActions == "" {
+		cfg.TodoistWebhookActions = config.DefaultTodoistWebhookActions
+	}
+
+	return NewExporter(cfg), requests
+}
+
+func TestParseTodoistActions(t *testing.T) {
+	actions, err := ParseTodoistActions(config.DefaultTodoistWebhookActions, i18n.New(i18n.DE))
+	if err != nil {
+		t.Fatalf("default actions invalid: %v", err)
+	}
+	if actions["item:completed"] != ActionClose || actions["note:added"] != ActionComment {
+		t.Fatalf("unexpected actions: %v", actions)
+	}
+
+	for _, spec := range []string{"item:completed", "item:completed=delete"} {
+		if _, err := ParseTodoistActions(spec, i18n.New(i18n.DE)); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+
+	_, err = ParseTodoistActions("item:completed=delete", i18n.New(i18n.EN))
+	if err == nil || err.Error() != `unknown action "delete" for item:completed` {
+		t.Fatalf("expected English error, got %v", err)
+	}
+}
+
+// linkedStateFile legt einen Sync-Zustand mit den übergebenen Task→Issue-Verknüpfungen an
+func linkedStateFile(t *testing.T, links map[string]string) string {
+	t.Helper()
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	store := stateRepo.NewStore(statePath)
+	for taskID, iid := range links {
+		store.SetLink(taskID, "group/project", iid)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	return statePath
+}
+
+func TestApplyTodoistEvent_Close(t *testing.T) {
+	e, requests := newReverseExporter(t, &config.Config{StateFile: linkedStateFile(t, map[string]string{"t1": "12"})})
+
+	result, err := e.ApplyTodoistEvent(TodoistEvent{Name: "item:completed", TaskID: "t1", TaskContent: "#12 - Fix login"})
+	if err != nil {
+		t.Fatalf("ApplyTodoistEvent() error = %v", err)
+	}
+	if result.Action != ActionClose || result.IssueIID != "12" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if len(*requests) != 1 {
+		t.Fatalf("expected one GitLab request, got %+v", *requests)
+	}
+	req := (*requests)[0]
+	if req.method != http.MethodPut || req.path != "/api/v4/projects/group%2Fproject/issues/12" || req.body["state_event"] != "close" {
+		t.Fatalf("unexpected GitLab request: %+v", req)
+	}
+}
+
+func TestApplyTodoistEvent_RequiresLinkAndSyncedProject(t *testing.T) {
+	e, requests := newReverseExporter(t, &config.Config{StateFile: linkedStateFile(t, map[string]string{"t1": "12"})})
+	e.todoistProjectID = "synced"
+
+	// Ein Task mit Issue-Präfix in einem fremden Projekt darf kein Issue schließen
+	result, err := e.ApplyTodoistEvent(TodoistEvent{Name: "item:completed", TaskID: "other", TaskContent: "#12 - Fix login", ProjectID: "private"})
+	if err != nil || result.Action != ActionIgnore {
+		t.Fatalf("unlinked task should be ignored, got %+v, err=%v", result, err)
+	}
+
+	// Auch ein verknüpfter Task, der in ein anderes Projekt verschoben wurde, wird ignoriert
+	result, err = e.ApplyTodoistEvent(TodoistEvent{Name: "item:completed", TaskID: "t1", ProjectID: "private"})
+	if err != nil || result.Action != ActionIgnore {
+		t.Fatalf("task outside the synced project should be ignored, got %+v, err=%v", result, err)
+	}
+
+	result, err = e.ApplyTodoistEvent(TodoistEvent{Name: "item:completed", TaskID: "t1", ProjectID: "synced"})
+	if err != nil || result.Action != ActionClose || result.IssueIID != "12" {
+		t.Fatalf("unexpected result: %+v, err=%v", result, err)
+	}
+	if len(*requests) != 1 {
+		t.Fatalf("expected one GitLab request, got %+v", *requests)
+	}
+}
+
+func TestApplyTodoistEvent_UsesSyncState(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	store := stateRepo.NewStore(statePath)
+	store.SetLink("t9", "other/project", "77")
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	e, requests := newReverseExporter(t, &config.Config{StateFile: statePath, Lang: i18n.EN})
+
+	// Inhalt ohne Präfix - die Verknüpfung kommt aus dem Sync-Zustand
+	result, err := e.ApplyTodoistEvent(TodoistEvent{Name: "note:added", TaskID: "t9", TaskContent: "Renamed", NoteContent: "Looks good"})
+	if err != nil {
+		t.Fatalf("ApplyTodoistEvent() error = %v", err)
+	}
+	if result.Action != ActionComment || result.ProjectPath != "other/project" || result.IssueIID != "77" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Note != "💬 Comment from Todoist:\n\nLooks good" {
+		t.Fatalf("note should use the configured language, got %q", result.Note)
+	}
+
+	req := (*requests)[0]
+	if req.method != http.MethodPost || req.path != "/api/v4/projects/other%2Fproject/issues/77/notes" {
+		t.Fatalf("unexpected GitLab request: %+v", req)
+	}
+}
+
+func TestApplyTodoistEvent_SeesLinksWrittenAfterStart(t *testing.T) {
+	statePath := linkedStateFile(t, map[string]string{"t1": "12"})
+	e, requests := newReverseExporter(t, &config.Config{StateFile: statePath})
+
+	// Ein export-Lauf in einem anderen Prozess legt nach dem Start von serve einen Task an
+	other := stateRepo.NewStore(statePath)
+	if err := other.Load(); err != nil {
+		t.Fatal(err)
+	}
+	other.SetLink("t2", "group/project", "34")
+	if err := other.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := e.ApplyTodoistEvent(TodoistEvent{Name: "item:completed", TaskID: "t2"})
+	if err != nil {
+		t.Fatalf("ApplyTodoistEvent() error = %v", err)
+	}
+	if result.Action != ActionClose || result.IssueIID != "34" {
+		t.Fatalf("link written after start should be used, got %+v", result)
+	}
+	if len(*requests) != 1 || (*requests)[0].path != "/api/v4/projects/group%2Fproject/issues/34" {
+		t.Fatalf("unexpected GitLab requests: %+v", *requests)
+	}
+}
+
+func TestApplyTodoistEvent_UpdateFields(t *testing.T) {
+	e, requests := newReverseExporter(t, &config.Config{StateFile: linkedStateFile(t, map[string]string{"t3": "3"})})
+
+	_, err := e.ApplyTodoistEvent(TodoistEvent{Name: "item:updated", TaskID: "t3", TaskContent: "#3 - New - title",
+		DueDate: "2025-06-01T10:00:00", DueChanged: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := (*requests)[0].body
+	if body["title"] != "New - title" || body["due_date"] != "2025-06-01" {
+		t.Fatalf("unexpected update fields: %v", body)
+	}
+
+	// Umbenennung eines Tasks ohne Datum lässt die Fälligkeit in GitLab stehen
+	fields := buildIssueUpdateFields(TodoistEvent{TaskContent: "#3 - Title"})
+	if _, ok := fields["due_date"]; ok {
+		t.Fatalf("unchanged due date must not be sent: %v", fields)
+	}
+
+	fields = buildIssueUpdateFields(TodoistEvent{TaskContent: "#3 - Title", DueChanged: true})
+	if v, ok := fields["due_date"]; !ok || v != nil {
+		t.Fatalf("removed due date should clear the GitLab due date: %v", fields)
+	}
+}
+
+func TestApplyTodoistEvent_IgnoredAndDryRun(t *testing.T) {
+	e, requests := newReverseExporter(t, &config.Config{TodoistWebhookActions: "item:completed=close", WebhookDryRun: true,
+		StateFile: linkedStateFile(t, map[string]string{"t1": "1"})})
+
+	// Nicht konfiguriertes Event
+	result, err := e.ApplyTodoistEvent(TodoistEvent{Name: "item:uncompleted", TaskID: "t1"})
+	if err != nil || result.Action != ActionIgnore {
+		t.Fatalf("expected ignored event, got %+v, err=%v", result, err)
+	}
+
+	// Task ohne Verknüpfung
+	result, err = e.ApplyTodoistEvent(TodoistEvent{Name: "item:completed", TaskID: "t2", TaskContent: "Buy milk"})
+	if err != nil || result.Action != ActionIgnore {
+		t.Fatalf("expected unlinked task to be ignored, got %+v, err=%v", result, err)
+	}
+
+	// Dry-Run: Aktion wird nur geplant
+	result, err = e.ApplyTodoistEvent(TodoistEvent{Name: "item:completed", TaskID: "t1", TaskContent: "#1 - A"})
+	if err != nil || result.Action != ActionClose || !result.DryRun {
+		t.Fatalf("expected dry-run close, got %+v, err=%v", result, err)
+	}
+
+	if len(*requests) != 0 {
+		t.Fatalf("no GitLab requests expected, got %+v", *requests)
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+// maxBackoffShift begrenzt die Verdopplung der Wartezeit, damit die Dauer nicht überläuft
+const maxBackoffShift = 16
+
+// Scheduler führt den Export in einem langlebigen Prozess regelmäßig aus
+type Scheduler struct {
+	config *config.Config
+	cron   *utils.CronSchedule
+	tr     *i18n.Translator
+
+	// run führt einen einzelnen Lauf aus (im Normalfall Exporter.Run)
+	run func() (SyncStats, error)
+}
+
+// NewScheduler erstellt einen Scheduler für Intervall- oder Cron-Betrieb
+func NewScheduler(cfg *config.Config, exporter *Exporter) (*Scheduler, error) {
+	// Eine ungültige Konfiguration würde sonst bei jedem Lauf mit Backoff erneut scheitern
+	if err := cfg.Validate(); err != nil {
+		return nil, exporter.tr.Errorf("err.invalid_config", err)
+	}
+
+	scheduler := &Scheduler{
+		config: cfg,
+		tr:     exporter.tr,
+		run:    exporter.Run,
+	}
+
+	if cfg.WatchCron != "" {
+		schedule, err := utils.ParseCron(cfg.WatchCron)
+		if err != nil {
+			return nil, exporter.tr.Errorf("err.watch_cron", err)
+		}
+		scheduler.cron = schedule
+	} else if cfg.WatchInterval <= 0 {
+		return nil, exporter.tr.Errorf("err.watch_interval")
+	}
+
+	return scheduler, nil
+}
+
+// Run startet die Synchronisationsschleife, bis der Context beendet wird
+func (s *Scheduler) Run(ctx context.Context) error {
+	slog.Info("watch mode started", "schedule", s.describe())
+
+	failures := 0
+	for cycle := 1; ; cycle++ {
+		started := time.Now()
+		stats, err := s.run()
+		duration := time.Since(started).Round(time.Millisecond)
+
+		if err != nil {
+			failures++
+			slog.Error("sync cycle failed", "cycle", cycle, "duration", duration,
+				"consecutive_failures", failures, "error", err)
+		} else {
+			failures = 0
+			slog.Info("sync cycle finished", "cycle", cycle, "duration", duration,
+				"issues", stats.Issues, "created", stats.Created, "updated", stats.Updated,
+				"skipped", stats.Skipped, "failed", stats.Failed)
+		}
+
+		next := s.nextRun(time.Now(), failures)
+		if next.IsZero() {
+			return s.tr.Errorf("err.watch_no_next_run", s.config.WatchCron)
+		}
+		slog.Info("next sync scheduled", "at", next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			slog.Info("watch mode stopped")
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// nextRun bestimmt den nächsten Lauf; ab dem zweiten Fehler in Folge wird die Wartezeit verdoppelt
+func (s *Scheduler) nextRun(now time.Time, failures int) time.Time {
+	next := s.next(now)
+	if next.IsZero() || failures < 2 {
+		return next
+	}
+
+	shift := failures - 1
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+
+	backoff := next.Sub(now) << uint(shift)
+	if backoff > s.config.WatchMaxBackoff {
+		backoff = s.config.WatchMaxBackoff
+	}
+
+	earliest := now.Add(backoff)
+	for next.Before(earliest) {
+		next = s.next(next)
+		if next.IsZero() {
+			break
+		}
+	}
+
+	return next
+}
+
+// next liefert den regulären nächsten Zeitpunkt ohne Backoff
+func (s *Scheduler) next(after time.Time) time.Time {
+	if s.cron != nil {
+		return s.cron.Next(after)
+	}
+	return after.Add(s.config.WatchInterval)
+}
+
+func (s *Scheduler) describe() string {
+	if s.cron != nil {
+		return fmt.Sprintf("cron: %s", s.config.WatchCron)
+	}
+	return fmt.Sprintf("interval: %s", s.config.WatchInterval)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
+)
+
+func TestNewScheduler_Validation(t *testing.T) {
+	if _, err := NewScheduler(&config.Config{WatchInterval: time.Minute}, NewExporter(&config.Config{})); err == nil {
+		t.Error("expected error for invalid configuration")
+	}
+	if _, err := NewScheduler(&config.Config{GitLabToken: "t", ProjectPath: "g/p", WatchInterval: 0}, NewExporter(&config.Config{})); err == nil {
+		t.Error("expected error for zero interval without cron")
+	}
+	if _, err := NewScheduler(&config.Config{GitLabToken: "t", ProjectPath: "g/p", WatchCron: "not a cron"}, NewExporter(&config.Config{})); err == nil {
+		t.Error("expected error for invalid cron expression")
+	}
+
+	s, err := NewScheduler(&config.Config{GitLabToken: "t", ProjectPath: "g/p", WatchCron: "*/5 * * * *"}, NewExporter(&config.Config{}))
+	if err != nil || s.cron == nil {
+		t.Fatalf("expected cron scheduler, got %v, err=%v", s, err)
+	}
+}
+
+func TestNewScheduler_ErrorsInEnglish(t *testing.T) {
+	english := NewExporter(&config.Config{Lang: i18n.EN})
+
+	_, err := NewScheduler(&config.Config{GitLabToken: "t", ProjectPath: "g/p", WatchInterval: 0}, english)
+	if err == nil || err.Error() != "watch interval must be greater than 0 (WATCH_INTERVAL)" {
+		t.Errorf("expected English interval error, got %v", err)
+	}
+	_, err = NewScheduler(&config.Config{GitLabToken: "t", ProjectPath: "g/p", WatchCron: "not a cron"}, english)
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid cron expression: ") {
+		t.Errorf("expected English cron error, got %v", err)
+	}
+}
+
+func TestScheduler_NextRun_Backoff(t *testing.T) {
+	s := &Scheduler{config: &config.Config{
+		WatchInterval:   10 * time.Minute,
+		WatchMaxBackoff: time.Hour,
+	}}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 10 * time.Minute},
+		{1, 10 * time.Minute}, // einzelner Fehler: regulärer Takt
+		{2, 20 * time.Minute},
+		{3, 40 * time.Minute},
+		{4, 60 * time.Minute}, // begrenzt durch WatchMaxBackoff
+		{50, 60 * time.Minute},
+	}
+
+	for _, c := range cases {
+		if got := s.nextRun(now, c.failures).Sub(now); got != c.want {
+			t.Errorf("failures=%d: next run in %s, want %s", c.failures, got, c.want)
+		}
+	}
+}
+
+func TestScheduler_NextRun_CronBackoffAlignsToSchedule(t *testing.T) {
+	s, err := NewScheduler(&config.Config{GitLabToken: "t", ProjectPath: "g/p", WatchCron: "*/15 * * * *", WatchMaxBackoff: time.Hour}, NewExporter(&config.Config{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 1, 1, 12, 5, 0, 0, time.UTC)
+
+	if got := s.nextRun(now, 0); !got.Equal(time.Date(2025, 1, 1, 12, 15, 0, 0, time.UTC)) {
+		t.Errorf("unexpected regular cron run: %v", got)
+	}
+	// 10 Minuten bis zum nächsten Slot, verdoppelt auf 20 → 12:25 → nächster Slot 12:30
+	if got := s.nextRun(now, 2); !got.Equal(time.Date(2025, 1, 1, 12, 30, 0, 0, time.UTC)) {
+		t.Errorf("unexpected cron backoff run: %v", got)
+	}
+}
+
+func TestScheduler_Run_StopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	s := &Scheduler{
+		config: &config.Config{WatchInterval: time.Millisecond, WatchMaxBackoff: time.Millisecond},
+		run: func() (SyncStats, error) {
+			calls++
+			if calls == 3 {
+				cancel()
+			}
+			if calls%2 == 0 {
+				return SyncStats{}, errors.New("boom")
+			}
+			return SyncStats{Issues: 1, Created: 1}, nil
+		},
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler did not stop after context cancel")
+	}
+
+	if calls < 3 {
+		t.Fatalf("expected at least 3 cycles, got %d", calls)
+	}
+}
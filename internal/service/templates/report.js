@@ -0,0 +1,85 @@
+(function () {
+  "use strict";
+
+  var search = document.getElementById("search");
+  var stateFilter = document.getElementById("state-filter");
+  var visibleCount = document.getElementById("visible-count");
+  var rows = Array.prototype.slice.call(document.querySelectorAll("table.issues tbody tr"));
+
+  // Filter: Suchtext und Status, leere Gruppen werden ausgeblendet
+  function applyFilter() {
+    var query = search.value.trim().toLowerCase();
+    var state = stateFilter.value;
+    var visible = 0;
+
+    rows.forEach(function (row) {
+      var match = (!state || row.dataset.state === state) &&
+        (!query || row.dataset.search.indexOf(query) !== -1);
+      row.hidden = !match;
+      if (match) {
+        visible++;
+      }
+    });
+
+    document.querySelectorAll("section.group").forEach(function (section) {
+      section.hidden = !section.querySelector("tbody tr:not([hidden])");
+    });
+
+    visibleCount.textContent = visibleCount.dataset.template
+      .replace("%d", visible)
+      .replace("%d", rows.length);
+  }
+
+  // Sortierung per Klick auf die Spaltenüberschrift
+  function sortTable(th) {
+    var table = th.closest("table");
+    var tbody = table.tBodies[0];
+    var index = Array.prototype.indexOf.call(th.parentNode.children, th);
+    var numeric = th.dataset.sort === "number";
+    var ascending = th.getAttribute("aria-sort") !== "ascending";
+
+    table.querySelectorAll("th").forEach(function (other) {
+      other.removeAttribute("aria-sort");
+    });
+    th.setAttribute("aria-sort", ascending ? "ascending" : "descending");
+
+    var value = function (row) {
+      var cell = row.children[index];
+      var text = cell.dataset.value !== undefined ? cell.dataset.value : cell.textContent.trim();
+      return numeric ? parseFloat(text) || 0 : text.toLowerCase();
+    };
+
+    Array.prototype.slice.call(tbody.rows)
+      .sort(function (a, b) {
+        var va = value(a);
+        var vb = value(b);
+        // Leere Werte (z.B. ohne Fälligkeit) immer ans Ende
+        if (va === "" || vb === "") {
+          return va === vb ? 0 : (va === "" ? 1 : -1);
+        }
+        var result = numeric ? va - vb : va.localeCompare(vb);
+        return ascending ? result : -result;
+      })
+      .forEach(function (row) {
+        tbody.appendChild(row);
+      });
+  }
+
+  document.querySelectorAll("table.issues th[data-sort]").forEach(function (th) {
+    th.addEventListener("click", function () {
+      sortTable(th);
+    });
+  });
+
+  // Klick auf ein Label filtert nach diesem Label
+  document.querySelectorAll(".chip[data-label]").forEach(function (chip) {
+    chip.addEventListener("click", function () {
+      search.value = chip.dataset.label;
+      applyFilter();
+    });
+  });
+
+  search.addEventListener("input", applyFilter);
+  stateFilter.addEventListener("change", applyFilter);
+  applyFilter();
+})();
@@ -0,0 +1,445 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+// timesheetCSVColumns sind die Spalten des CSV-Exports (eine Zeile pro Tag, Person und Issue)
+var timesheetCSVColumns = []string{"date", "user", "user_name", "reference", "title", "summary", "spent_hours", "hours"}
+
+// Rundungsarten für gebuchte Zeit
+const (
+	roundingUp      = "up"
+	roundingDown    = "down"
+	roundingNearest = "nearest"
+)
+
+// timesheetRounding rundet die Summe einer Timesheet-Zeile auf ein Vielfaches von unit (0 = keine Rundung)
+type timesheetRounding struct {
+	mode string
+	unit time.Duration
+}
+
+// Timesheet summiert die Zeitbuchungen eines Zeitraums je Tag, Person und Issue und schreibt sie nach stdout
+func (e *Exporter) Timesheet() error {
+	return e.writeTimesheet(os.Stdout)
+}
+
+func (e *Exporter) writeTimesheet(w io.Writer) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	format := e.formatName()
+	if format != FormatMarkdown && format != FormatJSON && format != FormatCSV {
+		return e.tr.Errorf("err.unknown_format", e.config.Format, FormatCSV+", "+FormatJSON+", "+FormatMarkdown)
+	}
+
+	// Mit --group wird kein Projekt gebraucht, daher nicht config.Validate
+	if e.config.GitLabToken == "" {
+		return e.tr.Errorf("err.invalid_config", e.tr.Errorf("config.missing_gitlab_token"))
+	}
+	namespace, group := e.timesheetNamespace()
+	if namespace == "" {
+		return e.tr.Errorf("err.invalid_config", e.tr.Errorf("config.missing_project_path"))
+	}
+
+	since, until, err := e.timesheetRange(time.Now())
+	if err != nil {
+		return err
+	}
+	rounding, err := e.timesheetRounding()
+	if err != nil {
+		return err
+	}
+
+	e.progress("progress.loading_timelogs", namespace, e.tr.FormatDate(since.Format(releaseDateLayout)), e.tr.FormatDate(until.Format(releaseDateLayout)))
+
+	timelogs, err := e.gitlabRepo.GetTimelogs(namespace, group, since, until)
+	if err != nil {
+		return e.tr.Errorf("err.loading_timelogs", err)
+	}
+
+	timesheet := e.timesheet(timelogs, since, until, rounding)
+	e.progress("progress.timesheet_found", len(timesheet.Entries), e.formatDecimal(timesheet.TotalHours, 2))
+
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(timesheet)
+	case FormatCSV:
+		return e.writeTimesheetCSV(w, timesheet)
+	default:
+		_, err = io.WriteString(w, e.renderTimesheetMarkdown(timesheet))
+		return err
+	}
+}
+
+// timesheetNamespace liefert die Gruppe (--group) oder, ohne Gruppe, das Projekt
+func (e *Exporter) timesheetNamespace() (string, bool) {
+	if e.config.TimesheetGroup != "" {
+		return e.config.TimesheetGroup, true
+	}
+	return e.config.ProjectPath, false
+}
+
+// timesheetRange liest --since und --until (jeweils inklusive); Standard ist der laufende Monat bis heute
+func (e *Exporter) timesheetRange(now time.Time) (time.Time, time.Time, error) {
+	since, err := e.parseReleaseDate("since", e.config.TimesheetSince)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	until, err := e.parseReleaseDate("until", e.config.TimesheetUntil)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if since == nil {
+		first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		since = &first
+	}
+	if until == nil {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		until = &today
+	}
+	if until.Before(*since) {
+		return time.Time{}, time.Time{}, e.tr.Errorf("err.timesheet_range", until.Format(releaseDateLayout), since.Format(releaseDateLayout))
+	}
+	return *since, *until, nil
+}
+
+// timesheetRounding liest --rounding, z.B. "15m" (aufrunden), "nearest:6m" oder "down:30m"
+func (e *Exporter) timesheetRounding() (timesheetRounding, error) {
+	spec := strings.ToLower(strings.TrimSpace(e.config.TimesheetRounding))
+	if spec == "" || spec == "none" {
+		return timesheetRounding{}, nil
+	}
+
+	rounding := timesheetRounding{mode: roundingUp}
+	if mode, unit, ok := strings.Cut(spec, ":"); ok {
+		rounding.mode, spec = mode, unit
+	}
+	unit, err := time.ParseDuration(spec)
+	if err != nil || unit < time.Second ||
+		(rounding.mode != roundingUp && rounding.mode != roundingDown && rounding.mode != roundingNearest) {
+		return timesheetRounding{}, e.tr.Errorf("err.timesheet_rounding", e.config.TimesheetRounding)
+	}
+	rounding.unit = unit
+	return rounding, nil
+}
+
+// apply rundet Sekunden auf ein Vielfaches der Einheit
+func (r timesheetRounding) apply(seconds int) int {
+	if r.unit == 0 {
+		return seconds
+	}
+	unit := r.unit.Seconds()
+	steps := float64(seconds) / unit
+	switch r.mode {
+	case roundingDown:
+		steps = math.Floor(steps)
+	case roundingNearest:
+		steps = math.Round(steps)
+	default:
+		steps = math.Ceil(steps)
+	}
+	return int(steps * unit)
+}
+
+// String liefert die Regel in der Schreibweise von --rounding, z.B. "up:15m" (leer ohne Rundung)
+func (r timesheetRounding) String() string {
+	if r.unit == 0 {
+		return ""
+	}
+	return r.mode + ":" + shortDuration(r.unit)
+}
+
+// shortDuration formatiert z.B. 15m oder 1h statt 15m0s bzw. 1h0m0s
+func shortDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return d.String()
+	}
+}
+
+// timesheet fasst die Buchungen je Tag, Person und Issue zusammen, rundet jede Zeile und bildet die Summen
+func (e *Exporter) timesheet(timelogs []todoistDomain.Timelog, since, until time.Time, rounding timesheetRounding) todoistDomain.Timesheet {
+	namespace, group := e.timesheetNamespace()
+	users := splitList(e.config.TimesheetUsers)
+
+	timesheet := todoistDomain.Timesheet{
+		SchemaVersion: todoistDomain.ExportSchemaVersion,
+		Metadata: todoistDomain.TimesheetMetadata{
+			ExportedAt: time.Now().UTC().Truncate(time.Second),
+			GitLabURL:  e.config.GetGitLabBaseURL(),
+			Namespace:  namespace,
+			Group:      group,
+			Since:      since.Format(releaseDateLayout),
+			Until:      until.Format(releaseDateLayout),
+			Users:      users,
+			Rounding:   rounding.String(),
+		},
+		Entries: []todoistDomain.TimesheetEntry{},
+		ByUser:  []todoistDomain.TimesheetTotal{},
+		ByIssue: []todoistDomain.TimesheetTotal{},
+		ByDay:   []todoistDomain.TimesheetTotal{},
+	}
+
+	entries := make(map[string]*todoistDomain.TimesheetEntry)
+	var order []string
+	for _, timelog := range timelogs {
+		if !timesheetUserMatches(timelog.User, users) {
+			continue
+		}
+
+		entry := todoistDomain.TimesheetEntry{
+			Date:     timelog.SpentAt.Local().Format(releaseDateLayout),
+			User:     timelog.User.Username,
+			UserName: timelog.User.Name,
+		}
+		switch {
+		case timelog.Issue != nil:
+			entry.Reference = timesheetReference(*timelog.Issue, "#", group)
+			entry.Title, entry.WebURL = timelog.Issue.Title, timelog.Issue.WebURL
+		case timelog.MergeRequest != nil:
+			entry.Reference = timesheetReference(*timelog.MergeRequest, "!", group)
+			entry.Title, entry.WebURL = timelog.MergeRequest.Title, timelog.MergeRequest.WebURL
+		}
+
+		key := entry.Date + "\x00" + entry.User + "\x00" + entry.Reference
+		existing, ok := entries[key]
+		if !ok {
+			existing = &entry
+			entries[key] = existing
+			order = append(order, key)
+		}
+		existing.SpentSeconds += timelog.TimeSpent
+		if summary := singleLine(timelog.Summary); summary != "" && !strings.Contains(existing.Summary, summary) {
+			existing.Summary = strings.TrimPrefix(existing.Summary+"; "+summary, "; ")
+		}
+	}
+
+	byUser, byIssue, byDay := make(map[string]int), make(map[string]int), make(map[string]int)
+	userNames, issueTitles := make(map[string]string), make(map[string]string)
+	total := 0
+	for _, key := range order {
+		entry := entries[key]
+		// Stornierte Buchungen (z.B. "/spend -1h") heben sich auf und erscheinen nicht
+		if entry.SpentSeconds == 0 {
+			continue
+		}
+		rounded := rounding.apply(entry.SpentSeconds)
+		entry.Hours = hours(rounded)
+		timesheet.Entries = append(timesheet.Entries, *entry)
+
+		byUser[entry.User] += rounded
+		userNames[entry.User] = entry.UserName
+		byIssue[entry.Reference] += rounded
+		issueTitles[entry.Reference] = entry.Title
+		byDay[entry.Date] += rounded
+		total += rounded
+	}
+
+	sort.SliceStable(timesheet.Entries, func(i, j int) bool {
+		a, b := timesheet.Entries[i], timesheet.Entries[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.User != b.User {
+			return a.User < b.User
+		}
+		return referenceLess(a.Reference, b.Reference)
+	})
+
+	for user, seconds := range byUser {
+		timesheet.ByUser = append(timesheet.ByUser, todoistDomain.TimesheetTotal{Key: user, Title: userNames[user], Hours: hours(seconds)})
+	}
+	sort.Slice(timesheet.ByUser, func(i, j int) bool { return timesheet.ByUser[i].Key < timesheet.ByUser[j].Key })
+
+	for reference, seconds := range byIssue {
+		timesheet.ByIssue = append(timesheet.ByIssue, todoistDomain.TimesheetTotal{Key: reference, Title: issueTitles[reference], Hours: hours(seconds)})
+	}
+	sort.Slice(timesheet.ByIssue, func(i, j int) bool { return referenceLess(timesheet.ByIssue[i].Key, timesheet.ByIssue[j].Key) })
+
+	for day, seconds := range byDay {
+		timesheet.ByDay = append(timesheet.ByDay, todoistDomain.TimesheetTotal{Key: day, Hours: hours(seconds)})
+	}
+	sort.Slice(timesheet.ByDay, func(i, j int) bool { return timesheet.ByDay[i].Key < timesheet.ByDay[j].Key })
+
+	timesheet.TotalHours = hours(total)
+	return timesheet
+}
+
+// timesheetUserMatches prüft den Personen-Filter (Username mit oder ohne @ oder Name)
+func timesheetUserMatches(user todoistDomain.TimelogUser, users []string) bool {
+	if len(users) == 0 {
+		return true
+	}
+	for _, want := range users {
+		want = strings.TrimPrefix(want, "@")
+		if strings.EqualFold(want, user.Username) || strings.EqualFold(want, user.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// timesheetReference liefert "#12" bzw. "!34"; bei Gruppen die volle Referenz mit Projektpfad
+func timesheetReference(target todoistDomain.TimelogTarget, prefix string, group bool) string {
+	if group && target.Reference != "" {
+		return target.Reference
+	}
+	return prefix + target.IID
+}
+
+// referenceLess sortiert Referenzen desselben Projekts numerisch (#2 vor #10)
+func referenceLess(a, b string) bool {
+	i, j := strings.LastIndexAny(a, "#!"), strings.LastIndexAny(b, "#!")
+	if i < 0 || j < 0 || a[:i+1] != b[:j+1] {
+		return a < b
+	}
+	return iidLess(a[i+1:], b[j+1:])
+}
+
+// hours rechnet Sekunden in Stunden mit zwei Nachkommastellen um
+func hours(seconds int) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
+
+// timesheetUser liefert den Anzeigenamen einer Person (Username, wenn kein Name bekannt ist)
+func timesheetUser(username, name string) string {
+	if name != "" {
+		return name
+	}
+	return username
+}
+
+// timesheetIssue verlinkt das Issue einer Zeile (Buchungen ohne Issue erhalten einen Platzhalter)
+func (e *Exporter) timesheetIssue(reference, title, webURL string) string {
+	if reference == "" {
+		return e.tr.T("timesheet.no_issue")
+	}
+	text := reference + " - " + utils.EscapeMarkdown(singleLine(title))
+	if webURL == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, webURL)
+}
+
+// renderTimesheetMarkdown erzeugt die Buchungen und Summen je Person, Issue und Tag als Tabellen
+func (e *Exporter) renderTimesheetMarkdown(timesheet todoistDomain.Timesheet) string {
+	var b strings.Builder
+
+	metadata := timesheet.Metadata
+	fmt.Fprintf(&b, "# %s\n\n", e.tr.T("timesheet.title", metadata.Namespace))
+	fmt.Fprintf(&b, "**%s:** %s – %s  \n", e.tr.T("timesheet.period"), e.tr.FormatDate(metadata.Since), e.tr.FormatDate(metadata.Until))
+	if len(metadata.Users) > 0 {
+		fmt.Fprintf(&b, "**%s:** %s  \n", e.tr.T("timesheet.users"), utils.EscapeMarkdown(strings.Join(metadata.Users, ", ")))
+	}
+	if rounding, err := e.timesheetRounding(); err == nil && rounding.unit > 0 {
+		fmt.Fprintf(&b, "**%s:** %s  \n", e.tr.T("timesheet.rounding"), e.tr.T("timesheet.rounding_"+rounding.mode, shortDuration(rounding.unit)))
+	}
+	fmt.Fprintf(&b, "**%s:** %s h  \n", e.tr.T("timesheet.total"), e.formatDecimal(timesheet.TotalHours, 2))
+
+	if len(timesheet.Entries) == 0 {
+		fmt.Fprintf(&b, "\n%s\n", e.tr.T("timesheet.none"))
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "\n## %s\n\n", e.tr.T("timesheet.entries"))
+	fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n|---|---|---|---|---:|\n",
+		e.tr.T("timesheet.date"), e.tr.T("timesheet.person"), e.tr.T("report.issue"), e.tr.T("timesheet.summary"), e.tr.T("timesheet.hours"))
+	for _, entry := range timesheet.Entries {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", e.tr.FormatDate(entry.Date),
+			utils.EscapeMarkdown(timesheetUser(entry.User, entry.UserName)), e.timesheetIssue(entry.Reference, entry.Title, entry.WebURL),
+			utils.EscapeMarkdown(entry.Summary), e.formatDecimal(entry.Hours, 2))
+	}
+	fmt.Fprintf(&b, "| **%s** | | | | **%s** |\n", e.tr.T("timesheet.total"), e.formatDecimal(timesheet.TotalHours, 2))
+
+	fmt.Fprintf(&b, "\n## %s\n\n", e.tr.T("timesheet.by_user"))
+	fmt.Fprintf(&b, "| %s | %s |\n|---|---:|\n", e.tr.T("timesheet.person"), e.tr.T("timesheet.hours"))
+	for _, total := range timesheet.ByUser {
+		fmt.Fprintf(&b, "| %s | %s |\n", utils.EscapeMarkdown(timesheetUser(total.Key, total.Title)), e.formatDecimal(total.Hours, 2))
+	}
+
+	urls := make(map[string]string)
+	for _, entry := range timesheet.Entries {
+		urls[entry.Reference] = entry.WebURL
+	}
+	fmt.Fprintf(&b, "\n## %s\n\n", e.tr.T("timesheet.by_issue"))
+	fmt.Fprintf(&b, "| %s | %s |\n|---|---:|\n", e.tr.T("report.issue"), e.tr.T("timesheet.hours"))
+	for _, total := range timesheet.ByIssue {
+		fmt.Fprintf(&b, "| %s | %s |\n", e.timesheetIssue(total.Key, total.Title, urls[total.Key]), e.formatDecimal(total.Hours, 2))
+	}
+
+	fmt.Fprintf(&b, "\n## %s\n\n", e.tr.T("timesheet.by_day"))
+	fmt.Fprintf(&b, "| %s | %s |\n|---|---:|\n", e.tr.T("timesheet.date"), e.tr.T("timesheet.hours"))
+	for _, total := range timesheet.ByDay {
+		fmt.Fprintf(&b, "| %s | %s |\n", e.tr.FormatDate(total.Key), e.formatDecimal(total.Hours, 2))
+	}
+
+	return b.String()
+}
+
+// writeTimesheetCSV schreibt eine Zeile pro Tag, Person und Issue und zum Schluss die Gesamtsumme
+func (e *Exporter) writeTimesheetCSV(w io.Writer, timesheet todoistDomain.Timesheet) error {
+	separator, err := e.csvSeparator()
+	if err != nil {
+		return err
+	}
+
+	if e.config.CSVBOM {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return err
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = separator
+	writer.UseCRLF = true
+
+	if err := writer.Write(timesheetCSVColumns); err != nil {
+		return err
+	}
+
+	spent := 0
+	for _, entry := range timesheet.Entries {
+		spent += entry.SpentSeconds
+		record := []string{
+			entry.Date,
+			entry.User,
+			csvCell(entry.UserName),
+			entry.Reference,
+			csvCell(entry.Title),
+			csvCell(entry.Summary),
+			e.formatDecimal(hours(entry.SpentSeconds), 2),
+			e.formatDecimal(entry.Hours, 2),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	total := []string{e.tr.T("timesheet.total"), "", "", "", "", "", e.formatDecimal(hours(spent), 2), e.formatDecimal(timesheet.TotalHours, 2)}
+	if err := writer.Write(total); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
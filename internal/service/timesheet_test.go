@@ -0,0 +1,199 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+)
+
+func timesheetTestLogs() []todoistDomain.Timelog {
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.Local) }
+	anna := todoistDomain.TimelogUser{Username: "anna", Name: "Anna"}
+	bob := todoistDomain.TimelogUser{Username: "bob", Name: "Bob"}
+	login := &todoistDomain.TimelogTarget{IID: "12", Title: "Login", WebURL: "u12", Reference: "g/p#12"}
+	docs := &todoistDomain.TimelogTarget{IID: "2", Title: "Docs", WebURL: "u2", Reference: "g/p#2"}
+	return []todoistDomain.Timelog{
+		{SpentAt: at(1, 9), TimeSpent: 20 * 60, Summary: "Review", User: anna, Issue: login},
+		{SpentAt: at(1, 15), TimeSpent: 10 * 60, Summary: "Fix", User: anna, Issue: login},
+		{SpentAt: at(1, 16), TimeSpent: 7 * 60, User: anna, Issue: docs},
+		{SpentAt: at(2, 9), TimeSpent: 3600, User: bob, MergeRequest: &todoistDomain.TimelogTarget{IID: "3", Title: "Fix login", WebURL: "m3"}},
+		{SpentAt: at(2, 10), TimeSpent: 3600, User: anna, Issue: docs},
+		{SpentAt: at(2, 11), TimeSpent: -3600, User: anna, Issue: docs},
+		{SpentAt: at(3, 9), TimeSpent: 3600, User: todoistDomain.TimelogUser{Username: "carl", Name: "Carl"}, Issue: login},
+	}
+}
+
+func TestTimesheet_AggregatesFiltersAndRounds(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", TimesheetUsers: "anna, @bob", TimesheetRounding: "15m"})
+	rounding, err := exporter.timesheetRounding()
+	if err != nil {
+		t.Fatal(err)
+	}
+	since, until := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	timesheet := exporter.timesheet(timesheetTestLogs(), since, until, rounding)
+
+	check := func(name string, got interface{}, want string) {
+		t.Helper()
+		encoded, _ := json.Marshal(got)
+		if string(encoded) != want {
+			t.Errorf("%s = %s, want %s", name, encoded, want)
+		}
+	}
+
+	if len(timesheet.Entries) != 3 {
+		t.Fatalf("expected three rows, got %+v", timesheet.Entries)
+	}
+	if entry := timesheet.Entries[0]; entry.Reference != "#2" || entry.SpentSeconds != 420 || entry.Hours != 0.25 {
+		t.Errorf("7 minutes should be rounded up to a quarter hour: %+v", entry)
+	}
+	if entry := timesheet.Entries[1]; entry.Reference != "#12" || entry.SpentSeconds != 1800 || entry.Hours != 0.5 || entry.Summary != "Review; Fix" {
+		t.Errorf("logs of a day should be summed: %+v", entry)
+	}
+	if entry := timesheet.Entries[2]; entry.Reference != "!3" || entry.User != "bob" || entry.Hours != 1 {
+		t.Errorf("merge request logs should be kept: %+v", entry)
+	}
+
+	check("by_user", timesheet.ByUser, `[{"key":"anna","title":"Anna","hours":0.75},{"key":"bob","title":"Bob","hours":1}]`)
+	check("by_issue", timesheet.ByIssue, `[{"key":"!3","title":"Fix login","hours":1},{"key":"#2","title":"Docs","hours":0.25},{"key":"#12","title":"Login","hours":0.5}]`)
+	check("by_day", timesheet.ByDay, `[{"key":"2024-03-01","hours":0.75},{"key":"2024-03-02","hours":1}]`)
+	if timesheet.TotalHours != 1.75 || timesheet.Metadata.Rounding != "up:15m" || timesheet.Metadata.Since != "2024-03-01" {
+		t.Errorf("unexpected totals or metadata: %v %+v", timesheet.TotalHours, timesheet.Metadata)
+	}
+
+	// In Gruppen bleibt der Projektpfad Teil der Referenz
+	exporter.config.TimesheetGroup = "g"
+	grouped := exporter.timesheet(timesheetTestLogs(), since, until, rounding)
+	if grouped.Entries[0].Reference != "g/p#2" || !grouped.Metadata.Group || grouped.Metadata.Namespace != "g" {
+		t.Errorf("group timesheet should use full references: %+v", grouped)
+	}
+}
+
+func TestTimesheetRounding(t *testing.T) {
+	tests := []struct {
+		spec    string
+		seconds int
+		want    int
+	}{
+		{"", 7 * 60, 7 * 60},
+		{"none", 7 * 60, 7 * 60},
+		{"15m", 16 * 60, 30 * 60},
+		{"UP:15m", 15 * 60, 15 * 60},
+		{"nearest:6m", 8 * 60, 6 * 60},
+		{"nearest:6m", 9 * 60, 12 * 60},
+		{"down:1h", 119 * 60, 3600},
+	}
+	for _, tt := range tests {
+		exporter := NewExporter(&config.Config{TimesheetRounding: tt.spec})
+		rounding, err := exporter.timesheetRounding()
+		if err != nil {
+			t.Fatalf("%q: %v", tt.spec, err)
+		}
+		if got := rounding.apply(tt.seconds); got != tt.want {
+			t.Errorf("%q: apply(%d) = %d, want %d", tt.spec, tt.seconds, got, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"sideways:15m", "15", "up:0s"} {
+		exporter := NewExporter(&config.Config{TimesheetRounding: spec, Lang: "en"})
+		if _, err := exporter.timesheetRounding(); err == nil || !strings.Contains(err.Error(), "invalid rounding") {
+			t.Errorf("%q: expected error, got %v", spec, err)
+		}
+	}
+}
+
+func TestTimesheetRange(t *testing.T) {
+	now := time.Date(2024, 3, 17, 15, 0, 0, 0, time.Local)
+	exporter := NewExporter(&config.Config{Lang: "en"})
+
+	since, until, err := exporter.timesheetRange(now)
+	if err != nil || since.Format(releaseDateLayout) != "2024-03-01" || until.Format(releaseDateLayout) != "2024-03-17" {
+		t.Errorf("default range should be the current month: %v %v %v", since, until, err)
+	}
+
+	exporter.config.TimesheetSince, exporter.config.TimesheetUntil = "2024-03-10", "2024-03-01"
+	if _, _, err := exporter.timesheetRange(now); err == nil || !strings.Contains(err.Error(), "is before") {
+		t.Errorf("expected error for reversed range, got %v", err)
+	}
+}
+
+func TestRenderTimesheetMarkdown(t *testing.T) {
+	exporter := NewExporter(&config.Config{ProjectPath: "g/p", Lang: "en", TimesheetUsers: "anna,bob", TimesheetRounding: "15m"})
+	rounding, _ := exporter.timesheetRounding()
+	since, until := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	got := exporter.renderTimesheetMarkdown(exporter.timesheet(timesheetTestLogs(), since, until, rounding))
+
+	for _, want := range []string{
+		"# Timesheet – g/p\n",
+		"**Rounding:** rounded up to 15m per row  \n**Total:** 1.75 h  \n",
+		"| Date | Person | Issue | Summary | Hours |\n|---|---|---|---|---:|\n",
+		"| [#12 - Login](u12) | Review; Fix | 0.50 |\n",
+		"| **Total** | | | | **1.75** |\n",
+		"## 👥 Per person\n\n| Person | Hours |\n|---|---:|\n| Anna | 0.75 |\n| Bob | 1.00 |\n",
+		"| [!3 - Fix login](m3) | 1.00 |\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown should contain %q:\n%s", want, got)
+		}
+	}
+
+	empty := exporter.renderTimesheetMarkdown(exporter.timesheet(nil, since, until, rounding))
+	if !strings.Contains(empty, "No time logged in this period.") {
+		t.Errorf("empty timesheet should say so:\n%s", empty)
+	}
+}
+
+func TestTimesheet_CSVForGroup(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if !strings.Contains(body["query"], `namespace: group(fullPath: "kunde")`) ||
+			!strings.Contains(body["query"], `startDate: "2024-03-01", endDate: "2024-03-31"`) {
+			t.Errorf("unexpected query: %s", body["query"])
+		}
+		_, _ = w.Write([]byte(`{"data":{"namespace":{"timelogs":{"nodes":[` +
+			`{"spent_at":"2024-03-05T10:00:00Z","time_spent":5400,"summary":"@kunde Workshop","user":{"username":"anna","name":"Anna"},` +
+			`"issue":{"iid":"7","title":"Onboarding","web_url":"u7","reference":"kunde/app#7"}}],` +
+			`"page_info":{"has_next_page":false}}}}}`))
+	}))
+	defer srv.Close()
+
+	exporter := NewExporter(&config.Config{
+		GitLabToken: "t", GitLabURL: srv.URL, Format: FormatCSV,
+		TimesheetGroup: "kunde", TimesheetSince: "2024-03-01", TimesheetUntil: "2024-03-31",
+	})
+
+	var buf bytes.Buffer
+	if err := exporter.writeTimesheet(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\r\n")
+	want := []string{
+		"date;user;user_name;reference;title;summary;spent_hours;hours",
+		// Zellen, die Tabellenkalkulationen als Formel lesen würden, werden entschärft
+		"2024-03-05;anna;Anna;kunde/app#7;Onboarding;'@kunde Workshop;1,50;1,50",
+		"Summe;;;;;;1,50;1,50",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+}
+
+func TestTimesheet_Validation(t *testing.T) {
+	exporter := NewExporter(&config.Config{GitLabToken: "t", Lang: "en"})
+	if err := exporter.writeTimesheet(&bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "PROJECT_PATH") {
+		t.Errorf("expected missing project, got %v", err)
+	}
+
+	exporter = NewExporter(&config.Config{GitLabToken: "t", ProjectPath: "g/p", Format: FormatHTML})
+	if err := exporter.writeTimesheet(&bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "html") {
+		t.Errorf("expected unknown format error, got %v", err)
+	}
+}
@@ -0,0 +1,363 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	todoistDomain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	stateRepo "hufschlaeger.net/gitlab-tasks-exporter/internal/repository/state"
+	"hufschlaeger.net/gitlab-tasks-exporter/pkg/utils"
+)
+
+// todoIcons ordnet den Anlässen von GitLab-To-Dos ein Symbol zu. Für jeden Anlass hier
+// gibt es einen Text "todo.<action>"; unbekannte Anlässe erscheinen unübersetzt.
+var todoIcons = map[string]string{
+	"assigned":            "🙋",
+	"mentioned":           "💬",
+	"directly_addressed":  "📣",
+	"review_requested":    "👀",
+	"review_submitted":    "📝",
+	"approval_required":   "👍",
+	"build_failed":        "🔴",
+	"unmergeable":         "⚠️",
+	"merge_train_removed": "🚂",
+	"marked":              "📌",
+}
+
+// todoPriorities hebt Anlässe hervor, bei denen andere warten (Todoist: 4 = p1, 1 = p4)
+var todoPriorities = map[string]int{
+	"review_requested":   3,
+	"approval_required":  3,
+	"build_failed":       3,
+	"unmergeable":        3,
+	"assigned":           2,
+	"directly_addressed": 2,
+}
+
+// todoTitleLength begrenzt den Task-Titel bei To-Dos ohne Issue oder Merge Request
+const todoTitleLength = 100
+
+// todoSyncStats ergänzt die Statistik um die in beide Richtungen erledigten To-Dos
+type todoSyncStats struct {
+	SyncStats
+	// Done zählt To-Dos, die nach Erledigen des Tasks in GitLab erledigt wurden
+	Done int
+	// Closed zählt Tasks, die geschlossen wurden, weil das To-Do in GitLab erledigt ist
+	Closed int
+}
+
+// todoUpdate ist ein offener Task, der zu einem To-Do gehört
+type todoUpdate struct {
+	todo todoistDomain.Todo
+	task todoistDomain.Task
+}
+
+// todoSyncPlan beschreibt, was ein todos-Lauf in GitLab und Todoist ändert
+type todoSyncPlan struct {
+	// markDone: Task in Todoist erledigt (oder gelöscht), To-Do in GitLab noch offen
+	markDone []stateRepo.TodoLink
+	// closeTasks: To-Do in GitLab erledigt, Task in Todoist noch offen
+	closeTasks []stateRepo.TodoLink
+	// forget: auf beiden Seiten erledigt, nur die Verknüpfung entfällt
+	forget []stateRepo.TodoLink
+	update []todoUpdate
+	create []todoistDomain.Todo
+}
+
+// Todos spiegelt die offenen To-Dos des Benutzers (Erwähnungen, Review-Anfragen, Zuweisungen usw.)
+// in ein eigenes Todoist-Projekt. Erledigte Tasks markieren das To-Do in GitLab als erledigt,
+// in GitLab erledigte To-Dos schließen den Task.
+func (e *Exporter) Todos() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Die To-Do-Liste gehört zum Token, nicht zu einem Projekt, daher nicht config.Validate
+	if e.config.GitLabToken == "" {
+		return e.tr.Errorf("err.invalid_config", e.tr.Errorf("config.missing_gitlab_token"))
+	}
+	if e.config.TodoistToken == "" {
+		return e.tr.Errorf("err.invalid_config", e.tr.Errorf("config.missing_todoist_token"))
+	}
+	if e.state == nil {
+		slog.Warn("sync state disabled, completed todoist tasks cannot mark gitlab todos done")
+	}
+
+	e.progress("progress.loading_todos")
+
+	todos, err := e.gitlabRepo.GetTodos()
+	if err != nil {
+		return e.tr.Errorf("err.loading_todos", err)
+	}
+	e.progress("progress.found_todos", len(todos))
+
+	e.progress("progress.exporting_todoist")
+	stats, err := e.syncTodos(todos)
+	e.saveState()
+	if err != nil {
+		return err
+	}
+
+	e.progress("progress.sync_done")
+	e.progress("progress.created", stats.Created)
+	e.progress("progress.updated", stats.Updated)
+	e.progress("progress.skipped", stats.Skipped)
+	e.progress("progress.todos_done", stats.Done)
+	e.progress("progress.todos_closed", stats.Closed)
+	slog.Info("todos sync finished", "todos", len(todos), "created", stats.Created, "updated", stats.Updated,
+		"skipped", stats.Skipped, "done", stats.Done, "closed", stats.Closed, "failed", stats.Failed)
+	return nil
+}
+
+// syncTodos gleicht die offenen To-Dos mit den Tasks im Inbox-Projekt ab
+func (e *Exporter) syncTodos(todos []todoistDomain.Todo) (todoSyncStats, error) {
+	var stats todoSyncStats
+
+	projectID, err := e.todosProjectID()
+	if err != nil {
+		return stats, e.tr.Errorf("err.project_setup", err)
+	}
+
+	tasks, err := e.todoistRepo.GetProjectTasks(projectID)
+	if err != nil {
+		return stats, e.tr.Errorf("err.loading_tasks", err)
+	}
+
+	var links []stateRepo.TodoLink
+	if e.state != nil {
+		links = e.state.TodoLinks()
+	}
+	plan := planTodoSync(todos, links, tasks, projectID)
+
+	// Verknüpfungen gibt es nur mit Sync-Zustand, daher ist e.state hier gesetzt
+	for _, link := range plan.markDone {
+		if err := e.gitlabRepo.MarkTodoDone(link.TodoID); err != nil {
+			// Die Verknüpfung bleibt, der nächste Lauf versucht es erneut
+			slog.Warn("marking gitlab todo done failed", "todo", link.TodoID, "task_id", link.TaskID, "error", err)
+			stats.Failed++
+			continue
+		}
+		slog.Info("gitlab todo marked done", "todo", link.TodoID, "task_id", link.TaskID)
+		e.state.RemoveTodoLink(link.TaskID)
+		stats.Done++
+	}
+
+	for _, link := range plan.closeTasks {
+		if err := e.todoistRepo.CloseTask(link.TaskID); err != nil {
+			slog.Warn("closing todo task failed", "todo", link.TodoID, "task_id", link.TaskID, "error", err)
+			stats.Failed++
+			continue
+		}
+		slog.Info("todo task closed", "todo", link.TodoID, "task_id", link.TaskID)
+		e.state.RemoveTodoLink(link.TaskID)
+		stats.Closed++
+	}
+
+	for _, link := range plan.forget {
+		e.state.RemoveTodoLink(link.TaskID)
+	}
+
+	for _, update := range plan.update {
+		e.rememberTodo(update.task.ID, update.todo.ID, projectID)
+
+		request := e.todoTask(update.todo, projectID)
+		if update.task.Content == request.Content && update.task.Description == request.Description && update.task.Priority == request.Priority {
+			stats.Skipped++
+			continue
+		}
+		updates := map[string]interface{}{"content": request.Content, "description": request.Description, "priority": request.Priority}
+		if _, err := e.todoistRepo.UpdateTask(update.task.ID, updates); err != nil {
+			slog.Warn("updating todo task failed", "todo", update.todo.ID, "task_id", update.task.ID, "error", err)
+			stats.Failed++
+			continue
+		}
+		stats.Updated++
+	}
+
+	for _, todo := range plan.create {
+		task, err := e.todoistRepo.CreateTask(e.todoTask(todo, projectID))
+		if err != nil {
+			slog.Warn("creating todo task failed", "todo", todo.ID, "error", err)
+			stats.Failed++
+			continue
+		}
+		slog.Info("todo task created", "todo", todo.ID, "action", todo.Action, "task_id", task.ID)
+		e.rememberTodo(task.ID, todo.ID, projectID)
+		stats.Created++
+	}
+
+	return stats, nil
+}
+
+// todosProjectID sucht oder erstellt das Todoist-Projekt für die To-Do-Liste
+func (e *Exporter) todosProjectID() (string, error) {
+	project, err := e.todoistRepo.FindProjectByName(e.config.TodosProject)
+	if err != nil {
+		return "", err
+	}
+	if project != nil {
+		return project.ID, nil
+	}
+
+	slog.Info("creating todoist project", "project", e.config.TodosProject)
+	project, err = e.todoistRepo.CreateProject(e.config.TodosProject)
+	if err != nil {
+		return "", err
+	}
+	return project.ID, nil
+}
+
+// rememberTodo speichert die Verknüpfung eines Tasks mit einem To-Do im Sync-Zustand
+func (e *Exporter) rememberTodo(taskID, todoID, projectID string) {
+	if e.state == nil || taskID == "" {
+		return
+	}
+	e.state.SetTodoLink(taskID, todoID, projectID)
+}
+
+// planTodoSync vergleicht offene To-Dos, Verknüpfungen und offene Tasks des Inbox-Projekts.
+// Ein verknüpfter Task, der nicht mehr offen ist, gilt als in Todoist erledigt.
+func planTodoSync(todos []todoistDomain.Todo, links []stateRepo.TodoLink, tasks []todoistDomain.Task, projectID string) todoSyncPlan {
+	var plan todoSyncPlan
+
+	open := make(map[string]todoistDomain.Task, len(tasks))
+	for _, task := range tasks {
+		open[task.ID] = task
+	}
+	pending := make(map[string]bool, len(todos))
+	for _, todo := range todos {
+		pending[todo.ID] = true
+	}
+
+	// linked ordnet To-Dos ihrem offenen Task zu ("" = wird gerade in GitLab erledigt)
+	linked := make(map[string]string)
+	linkedTasks := make(map[string]bool)
+	for _, link := range links {
+		// Verknüpfungen aus einem früheren Inbox-Projekt bleiben unangetastet
+		if link.ProjectID != projectID {
+			continue
+		}
+		linkedTasks[link.TaskID] = true
+
+		_, taskOpen := open[link.TaskID]
+		switch {
+		case pending[link.TodoID] && taskOpen:
+			linked[link.TodoID] = link.TaskID
+		case pending[link.TodoID]:
+			plan.markDone = append(plan.markDone, link)
+			linked[link.TodoID] = ""
+		case taskOpen:
+			plan.closeTasks = append(plan.closeTasks, link)
+		default:
+			plan.forget = append(plan.forget, link)
+		}
+	}
+
+	// Offene Tasks ohne Verknüpfung werden über den Titel zugeordnet, damit ohne
+	// (oder nach Verlust des) Sync-Zustands keine Duplikate entstehen
+	byContent := make(map[string]todoistDomain.Task)
+	for _, task := range tasks {
+		if _, ok := byContent[task.Content]; !ok && !linkedTasks[task.ID] {
+			byContent[task.Content] = task
+		}
+	}
+
+	for _, todo := range todos {
+		taskID, ok := linked[todo.ID]
+		switch {
+		case ok && taskID == "":
+		case ok:
+			plan.update = append(plan.update, todoUpdate{todo: todo, task: open[taskID]})
+		default:
+			content := todoTaskContent(todo)
+			if task, found := byContent[content]; found {
+				delete(byContent, content)
+				plan.update = append(plan.update, todoUpdate{todo: todo, task: task})
+				continue
+			}
+			plan.create = append(plan.create, todo)
+		}
+	}
+
+	return plan
+}
+
+// todoTask bildet ein To-Do auf einen Todoist Task ab; das Label ist der Anlass (z.B. "review_requested")
+func (e *Exporter) todoTask(todo todoistDomain.Todo, projectID string) todoistDomain.CreateTaskRequest {
+	priority := todoPriorities[todo.Action]
+	if priority == 0 {
+		priority = 1
+	}
+
+	return todoistDomain.CreateTaskRequest{
+		Content:     todoTaskContent(todo),
+		Description: e.todoTaskDescription(todo),
+		ProjectID:   projectID,
+		Labels:      []string{todo.Action},
+		Priority:    priority,
+	}
+}
+
+// todoTaskContent liefert den Task-Titel, z.B. "👀 group/project!3 - Fix login". To-Dos ohne
+// Issue oder Merge Request (z.B. Designs) erhalten den Projektpfad und die erste Zeile des Texts.
+func todoTaskContent(todo todoistDomain.Todo) string {
+	reference, title := "", ""
+	if todo.Target != nil {
+		reference, title = todo.Target.Reference, todo.Target.Title
+	}
+	if title == "" {
+		title, _, _ = strings.Cut(strings.TrimSpace(todo.Body), "\n")
+		title = utils.TruncateText(title, todoTitleLength)
+	}
+	if reference == "" && todo.Project != nil {
+		reference = todo.Project.FullPath
+	}
+
+	if reference == "" {
+		return fmt.Sprintf("%s %s", todoIcon(todo.Action), title)
+	}
+	return fmt.Sprintf("%s %s - %s", todoIcon(todo.Action), reference, title)
+}
+
+// todoTaskDescription beschreibt Anlass, auslösende Person, Text und Link des To-Dos
+func (e *Exporter) todoTaskDescription(todo todoistDomain.Todo) string {
+	header := fmt.Sprintf("%s **%s**", todoIcon(todo.Action), e.todoActionLabel(todo.Action))
+	if todo.Author != nil && todo.Author.Name != "" {
+		header += fmt.Sprintf(" · %s (@%s)", todo.Author.Name, todo.Author.Username)
+	}
+	parts := []string{header}
+
+	body := strings.TrimSpace(todo.Body)
+	if body != "" && (todo.Target == nil || body != todo.Target.Title) {
+		parts = append(parts, "> "+strings.ReplaceAll(body, "\n", "\n> "))
+	}
+
+	reference, url := "", ""
+	if todo.Target != nil {
+		reference, url = todo.Target.Reference, todo.Target.WebURL
+	}
+	if url == "" && todo.Project != nil {
+		reference, url = todo.Project.FullPath, todo.Project.WebURL
+	}
+	if url != "" {
+		parts = append(parts, fmt.Sprintf("🔗 [%s](%s)", reference, url))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// todoIcon liefert das Symbol eines Anlasses (📥 für unbekannte)
+func todoIcon(action string) string {
+	if icon, ok := todoIcons[action]; ok {
+		return icon
+	}
+	return "📥"
+}
+
+// todoActionLabel liefert den übersetzten Anlass, z.B. "Review angefragt"
+func (e *Exporter) todoActionLabel(action string) string {
+	if _, ok := todoIcons[action]; ok {
+		return e.tr.T("todo." + action)
+	}
+	return strings.ReplaceAll(action, "_", " ")
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	domain "hufschlaeger.net/gitlab-tasks-exporter/internal/domain/models"
+	stateRepo "hufschlaeger.net/gitlab-tasks-exporter/internal/repository/state"
+)
+
+func reviewTodo() domain.Todo {
+	return domain.Todo{
+		ID:      "gid://gitlab/Todo/1",
+		Action:  "review_requested",
+		Body:    "Fix login",
+		Author:  &domain.TimelogUser{Username: "anna", Name: "Anna"},
+		Project: &domain.TodoProject{FullPath: "group/project", WebURL: "p"},
+		Target:  &domain.TimelogTarget{IID: "3", Title: "Fix login", WebURL: "m3", Reference: "group/project!3"},
+	}
+}
+
+func TestTodoTask_ContentAndDescription(t *testing.T) {
+	exporter := NewExporter(&config.Config{Lang: "en"})
+
+	task := exporter.todoTask(reviewTodo(), "inbox")
+	if task.Content != "👀 group/project!3 - Fix login" || task.ProjectID != "inbox" || task.Priority != 3 ||
+		len(task.Labels) != 1 || task.Labels[0] != "review_requested" {
+		t.Errorf("unexpected task: %+v", task)
+	}
+	if task.Description != "👀 **Review requested** · Anna (@anna)\n\n🔗 [group/project!3](m3)" {
+		t.Errorf("unexpected description:\n%s", task.Description)
+	}
+
+	design := domain.Todo{
+		ID:      "gid://gitlab/Todo/2",
+		Action:  "new_action",
+		Body:    "@me please look\nat the mockup",
+		Project: &domain.TodoProject{FullPath: "group/project", WebURL: "p"},
+		Target:  &domain.TimelogTarget{},
+	}
+	task = exporter.todoTask(design, "inbox")
+	if task.Content != "📥 group/project - @me please look" || task.Priority != 1 {
+		t.Errorf("unexpected task without issue: %+v", task)
+	}
+	want := "📥 **new action**\n\n> @me please look\n> at the mockup\n\n🔗 [group/project](p)"
+	if task.Description != want {
+		t.Errorf("unexpected description:\n%s", task.Description)
+	}
+}
+
+func TestPlanTodoSync(t *testing.T) {
+	pending := reviewTodo()
+	doneInTodoist := domain.Todo{ID: "gid://gitlab/Todo/2", Action: "mentioned", Body: "Ping"}
+	unlinked := domain.Todo{ID: "gid://gitlab/Todo/5", Action: "assigned", Body: "Setup CI"}
+	fresh := domain.Todo{ID: "gid://gitlab/Todo/6", Action: "assigned", Body: "Docs"}
+
+	links := []stateRepo.TodoLink{
+		{TaskID: "t1", TodoID: pending.ID, ProjectID: "inbox"},
+		{TaskID: "t2", TodoID: doneInTodoist.ID, ProjectID: "inbox"},
+		{TaskID: "t3", TodoID: "gid://gitlab/Todo/3", ProjectID: "inbox"},
+		{TaskID: "t4", TodoID: "gid://gitlab/Todo/4", ProjectID: "inbox"},
+		{TaskID: "t9", TodoID: "gid://gitlab/Todo/9", ProjectID: "old-inbox"},
+	}
+	tasks := []domain.Task{
+		{ID: "t1", Content: "old title"},
+		{ID: "t3", Content: "💬 Done in GitLab"},
+		{ID: "t5", Content: "🙋 Setup CI"},
+	}
+
+	plan := planTodoSync([]domain.Todo{pending, doneInTodoist, unlinked, fresh}, links, tasks, "inbox")
+
+	if len(plan.markDone) != 1 || plan.markDone[0].TaskID != "t2" {
+		t.Errorf("completed task t2 should mark its todo done: %+v", plan.markDone)
+	}
+	if len(plan.closeTasks) != 1 || plan.closeTasks[0].TaskID != "t3" {
+		t.Errorf("open task t3 of a done todo should be closed: %+v", plan.closeTasks)
+	}
+	if len(plan.forget) != 1 || plan.forget[0].TaskID != "t4" {
+		t.Errorf("link t4 is done on both sides: %+v", plan.forget)
+	}
+	if len(plan.update) != 2 || plan.update[0].task.ID != "t1" || plan.update[1].task.ID != "t5" {
+		t.Errorf("linked t1 and unlinked t5 (same title) should be updated: %+v", plan.update)
+	}
+	if len(plan.create) != 1 || plan.create[0].ID != fresh.ID {
+		t.Errorf("only the new todo should be created: %+v", plan.create)
+	}
+}
+
+func TestApplyTodoistEvent_MarksTodoDone(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		queries = append(queries, body["query"])
+		_, _ = w.Write([]byte(`{"data":{"todo_mark_done":{"errors":[]}}}`))
+	}))
+	defer srv.Close()
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	store := stateRepo.NewStore(statePath)
+	store.SetLink("t1", "group/project", "12")
+	store.SetTodoLink("t2", "gid://gitlab/Todo/7", "inbox")
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	exporter := NewExporter(&config.Config{GitLabToken: "t", GitLabURL: srv.URL, StateFile: statePath,
+		TodoistWebhookActions: config.DefaultTodoistWebhookActions, WebhookDryRun: true})
+
+	// Andere Events und der Dry-Run ändern nichts
+	result, err := exporter.ApplyTodoistEvent(TodoistEvent{Name: "item:updated", TaskID: "t2", TaskContent: "💬 group/project - Ping"})
+	if err != nil || result.Action != ActionIgnore {
+		t.Fatalf("updates of todo tasks should be ignored: %+v, %v", result, err)
+	}
+	result, err = exporter.ApplyTodoistEvent(TodoistEvent{Name: "item:completed", TaskID: "t2"})
+	if err != nil || result.Action != ActionTodoDone || !result.DryRun || len(queries) != 0 {
+		t.Fatalf("dry run should not call GitLab: %+v, %v, %v", result, err, queries)
+	}
+
+	exporter.config.WebhookDryRun = false
+	result, err = exporter.ApplyTodoistEvent(TodoistEvent{Name: "item:completed", TaskID: "t2"})
+	if err != nil || result.Action != ActionTodoDone || result.TodoID != "gid://gitlab/Todo/7" {
+		t.Fatalf("unexpected result: %+v, %v", result, err)
+	}
+	if len(queries) != 1 || !strings.Contains(queries[0], `todoMarkDone(input: {id: "gid://gitlab/Todo/7"})`) {
+		t.Fatalf("unexpected GitLab requests: %v", queries)
+	}
+
+	reloaded := stateRepo.NewStore(statePath)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reloaded.TodoLinkByTaskID("t2"); ok {
+		t.Error("todo link should be removed once the todo is done")
+	}
+	if _, ok := reloaded.LinkByTaskID("t1"); !ok {
+		t.Error("issue links must be kept")
+	}
+}
@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GitLab Event-Typen (Header X-Gitlab-Event)
+const (
+	gitlabIssueHook             = "Issue Hook"
+	gitlabConfidentialIssueHook = "Confidential Issue Hook"
+	gitlabNoteHook              = "Note Hook"
+	gitlabConfidentialNoteHook  = "Confidential Note Hook"
+)
+
+// gitlabHookPayload enthält die Felder eines Issue- bzw. Note-Hooks, die für das Routing nötig sind.
+// Der Issue-Inhalt selbst wird danach frisch via GraphQL geladen, damit Webhook und Polling
+// exakt dieselben Daten synchronisieren.
+type gitlabHookPayload struct {
+	ObjectKind string `json:"object_kind"`
+	Project    struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID          int    `json:"iid"`
+		Action       string `json:"action"`
+		NoteableType string `json:"noteable_type"`
+	} `json:"object_attributes"`
+	Issue *struct {
+		IID int `json:"iid"`
+	} `json:"issue"`
+}
+
+// handleGitLab verarbeitet Issue- und Note-Hooks von GitLab
+func (s *Server) handleGitLab(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("X-Gitlab-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.config.GitLabWebhookSecret)) != 1 {
+		writeJSON(w, http.StatusUnauthorized, webhookResponse{Status: "error", Message: "invalid X-Gitlab-Token"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		writeJSON(w, http.StatusRequestEntityTooLarge, webhookResponse{Status: "error", Message: err.Error()})
+		return
+	}
+
+	var payload gitlabHookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writeJSON(w, http.StatusBadRequest, webhookResponse{Status: "error", Message: "invalid JSON payload"})
+		return
+	}
+
+	iid, reason := s.issueIIDFromGitLabEvent(r.Header.Get("X-Gitlab-Event"), payload)
+	if iid == "" {
+		writeJSON(w, http.StatusOK, webhookResponse{Status: "ignored", Reason: reason})
+		return
+	}
+
+	projectPath := payload.Project.PathWithNamespace
+	if !strings.EqualFold(projectPath, s.config.ProjectPath) {
+		writeJSON(w, http.StatusOK, webhookResponse{
+			Status: "ignored",
+			Reason: fmt.Sprintf("project %s is not configured", projectPath),
+		})
+		return
+	}
+
+	slog.Info("gitlab webhook received", "issue", iid, "kind", payload.ObjectKind)
+
+	stats, err := s.syncIssue(projectPath, iid)
+	if err != nil {
+		slog.Error("gitlab webhook sync failed", "issue", iid, "error", err)
+		writeJSON(w, http.StatusInternalServerError, webhookResponse{Status: "error", Issue: iid, Message: err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, webhookResponse{Status: "synced", Issue: iid, Stats: &stats})
+}
+
+// issueIIDFromGitLabEvent bestimmt das betroffene Issue oder den Grund, warum das Event ignoriert wird
+func (s *Server) issueIIDFromGitLabEvent(event string, payload gitlabHookPayload) (string, string) {
+	switch event {
+	case gitlabIssueHook, gitlabConfidentialIssueHook:
+		if payload.ObjectAttributes.IID == 0 {
+			return "", "issue hook without iid"
+		}
+		return strconv.Itoa(payload.ObjectAttributes.IID), ""
+
+	case gitlabNoteHook, gitlabConfidentialNoteHook:
+		if !s.config.GitLabWebhookNotes {
+			return "", "note events are disabled"
+		}
+		if payload.ObjectAttributes.NoteableType != "Issue" || payload.Issue == nil {
+			return "", "note is not attached to an issue"
+		}
+		return strconv.Itoa(payload.Issue.IID), ""
+
+	default:
+		return "", fmt.Sprintf("unsupported event %q", event)
+	}
+}
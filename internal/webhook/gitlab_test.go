@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/service"
+)
+
+type syncCall struct {
+	projectPath string
+	iid         string
+}
+
+// newTestServer erstellt einen Server, dessen Sync-Aufrufe aufgezeichnet werden
+func newTestServer(t *testing.T, cfg *config.Config, syncErr error) (*Server, *[]syncCall) {
+	t.Helper()
+
+	calls := &[]syncCall{}
+	s := &Server{
+		config: cfg,
+		syncIssue: func(projectPath string, iid string) (service.SyncStats, error) {
+			*calls = append(*calls, syncCall{projectPath, iid})
+			return service.SyncStats{Issues: 1, Updated: 1}, syncErr
+		},
+	}
+	return s, calls
+}
+
+func loadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("fixture %s: %v", name, err)
+	}
+	return data
+}
+
+func postGitLab(t *testing.T, s *Server, event, token string, body []byte) (*httptest.ResponseRecorder, webhookResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/gitlab", bytes.NewReader(body))
+	req.Header.Set("X-Gitlab-Event", event)
+	req.Header.Set("X-Gitlab-Token", token)
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	var resp webhookResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec, resp
+}
+
+func gitlabTestConfig() *config.Config {
+	return &config.Config{
+		ProjectPath:         "group/project",
+		GitLabWebhookSecret: "s3cret",
+	}
+}
+
+func TestGitLabHook_IssueEvent_SyncsIssue(t *testing.T) {
+	s, calls := newTestServer(t, gitlabTestConfig(), nil)
+
+	rec, resp := postGitLab(t, s, "Issue Hook", "s3cret", loadFixture(t, "gitlab_issue_hook.json"))
+
+	if rec.Code != http.StatusOK || resp.Status != "synced" || resp.Issue != "23" {
+		t.Fatalf("unexpected response %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(*calls) != 1 || (*calls)[0] != (syncCall{"group/project", "23"}) {
+		t.Fatalf("unexpected sync calls: %+v", *calls)
+	}
+	if resp.Stats == nil || resp.Stats.Updated != 1 {
+		t.Fatalf("expected stats in response: %s", rec.Body.String())
+	}
+}
+
+func TestGitLabHook_InvalidToken(t *testing.T) {
+	s, calls := newTestServer(t, gitlabTestConfig(), nil)
+
+	rec, _ := postGitLab(t, s, "Issue Hook", "wrong", loadFixture(t, "gitlab_issue_hook.json"))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+	if len(*calls) != 0 {
+		t.Fatalf("sync must not run for invalid token")
+	}
+}
+
+func TestGitLabHook_NoteEvent(t *testing.T) {
+	cfg := gitlabTestConfig()
+	s, calls := newTestServer(t, cfg, nil)
+
+	// Note-Events sind standardmäßig deaktiviert
+	rec, resp := postGitLab(t, s, "Note Hook", "s3cret", loadFixture(t, "gitlab_note_hook.json"))
+	if rec.Code != http.StatusOK || resp.Status != "ignored" || len(*calls) != 0 {
+		t.Fatalf("expected ignored note event, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	cfg.GitLabWebhookNotes = true
+	rec, resp = postGitLab(t, s, "Note Hook", "s3cret", loadFixture(t, "gitlab_note_hook.json"))
+	if rec.Code != http.StatusOK || resp.Status != "synced" || resp.Issue != "17" {
+		t.Fatalf("expected synced note event, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGitLabHook_IgnoredEvents(t *testing.T) {
+	cfg := gitlabTestConfig()
+	cfg.ProjectPath = "other/project"
+	s, calls := newTestServer(t, cfg, nil)
+
+	_, resp := postGitLab(t, s, "Issue Hook", "s3cret", loadFixture(t, "gitlab_issue_hook.json"))
+	if resp.Status != "ignored" {
+		t.Fatalf("expected foreign project to be ignored, got %+v", resp)
+	}
+
+	_, resp = postGitLab(t, s, "Push Hook", "s3cret", []byte(`{"object_kind":"push"}`))
+	if resp.Status != "ignored" {
+		t.Fatalf("expected push hook to be ignored, got %+v", resp)
+	}
+
+	if len(*calls) != 0 {
+		t.Fatalf("no sync expected, got %+v", *calls)
+	}
+}
+
+func TestGitLabHook_InvalidPayloadAndSyncError(t *testing.T) {
+	s, _ := newTestServer(t, gitlabTestConfig(), errors.New("todoist down"))
+
+	rec, _ := postGitLab(t, s, "Issue Hook", "s3cret", []byte(`not json`))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid JSON, got %d", rec.Code)
+	}
+
+	rec, resp := postGitLab(t, s, "Issue Hook", "s3cret", loadFixture(t, "gitlab_issue_hook.json"))
+	if rec.Code != http.StatusInternalServerError || resp.Message != "todoist down" {
+		t.Fatalf("expected 500 with error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestNewServer_ErrorsInEnglish(t *testing.T) {
+	exporter := service.NewExporter(&config.Config{Lang: "en"})
+
+	cases := map[string]*config.Config{
+		"webhook secret missing (GITLAB_WEBHOOK_SECRET and/or TODOIST_CLIENT_SECRET)": {Lang: "en"},
+		"GitLab webhooks need the Todoist export (--todoist or TODOIST_API=true)":     {Lang: "en", GitLabWebhookSecret: "x"},
+		"invalid configuration: GitLab token missing (GITLAB_TOKEN)":                  {Lang: "en", TodoistClientSecret: "x"},
+	}
+	for want, cfg := range cases {
+		if _, err := NewServer(cfg, exporter); err == nil || err.Error() != want {
+			t.Errorf("expected %q, got %v", want, err)
+		}
+	}
+}
+
+func TestNewServer_RequiresSecretAndTodoist(t *testing.T) {
+	exporter := service.NewExporter(&config.Config{})
+
+	if _, err := NewServer(&config.Config{TodoistAPI: true}, exporter); err == nil {
+		t.Error("expected error without webhook secret")
+	}
+	if _, err := NewServer(&config.Config{GitLabWebhookSecret: "x"}, exporter); err == nil {
+		t.Error("expected error without todoist export")
+	}
+
+	cfg := &config.Config{
+		GitLabWebhookSecret: "x", TodoistAPI: true, TodoistToken: "t",
+		GitLabToken: "g", ProjectPath: "group/project",
+	}
+	if _, err := NewServer(cfg, service.NewExporter(cfg)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Nur Todoist → GitLab: kein Todoist-Export nötig, aber gültige Aktionen
+	todoistOnly := &config.Config{
+		TodoistClientSecret: "x", GitLabToken: "g", ProjectPath: "group/project",
+		TodoistWebhookActions: config.DefaultTodoistWebhookActions,
+	}
+	if _, err := NewServer(todoistOnly, service.NewExporter(todoistOnly)); err != nil {
+		t.Errorf("unexpected error for todoist-only server: %v", err)
+	}
+	todoistOnly.TodoistWebhookActions = "item:completed=explode"
+	if _, err := NewServer(todoistOnly, service.NewExporter(todoistOnly)); err == nil {
+		t.Error("expected error for invalid action mapping")
+	}
+}
@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/i18n"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/service"
+)
+
+// maxPayloadSize begrenzt die Größe eingehender Webhook-Payloads
+const maxPayloadSize = 1 << 20
+
+// shutdownTimeout ist die Zeit, die laufende Requests beim Beenden noch bekommen
+const shutdownTimeout = 10 * time.Second
+
+// Server nimmt Webhooks entgegen und stößt die passende Synchronisation an
+type Server struct {
+	config *config.Config
+
+	// syncIssue synchronisiert ein einzelnes Issue (im Normalfall Exporter.SyncIssueByIID)
+	syncIssue func(projectPath string, iid string) (service.SyncStats, error)
+
+	// applyTodoistEvent überträgt ein Todoist-Event nach GitLab (im Normalfall Exporter.ApplyTodoistEvent)
+	applyTodoistEvent func(event service.TodoistEvent) (*service.ReverseAction, error)
+}
+
+// NewServer erstellt einen Webhook-Server für den konfigurierten Exporter.
+// Es werden nur die Endpunkte aktiviert, für die ein Secret konfiguriert ist.
+func NewServer(cfg *config.Config, exporter *service.Exporter) (*Server, error) {
+	tr := i18n.New(cfg.Lang)
+	if cfg.GitLabWebhookSecret == "" && cfg.TodoistClientSecret == "" {
+		return nil, tr.Errorf("err.webhook_secret")
+	}
+	if cfg.GitLabWebhookSecret != "" && !cfg.TodoistAPI {
+		return nil, tr.Errorf("err.webhook_needs_todoist")
+	}
+	if _, err := service.ParseTodoistActions(cfg.TodoistWebhookActions, tr); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, tr.Errorf("err.invalid_config", err)
+	}
+
+	return &Server{
+		config:            cfg,
+		syncIssue:         exporter.SyncIssueByIID,
+		applyTodoistEvent: exporter.ApplyTodoistEvent,
+	}, nil
+}
+
+// Handler liefert die Routen des Webhook-Servers
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	if s.config.GitLabWebhookSecret != "" {
+		mux.HandleFunc("POST /webhooks/gitlab", s.handleGitLab)
+	}
+	if s.config.TodoistClientSecret != "" {
+		mux.HandleFunc("POST /webhooks/todoist", s.handleTodoist)
+	}
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return mux
+}
+
+// ListenAndServe startet den HTTP-Server und beendet ihn sauber, wenn der Context endet
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              s.config.ListenAddr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("webhook server listening", "addr", s.config.ListenAddr)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		slog.Info("webhook server shutting down")
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// webhookResponse ist die JSON-Antwort auf einen Webhook
+type webhookResponse struct {
+	Status  string                 `json:"status"`
+	Reason  string                 `json:"reason,omitempty"`
+	Issue   string                 `json:"issue,omitempty"`
+	Stats   *service.SyncStats     `json:"stats,omitempty"`
+	Action  *service.ReverseAction `json:"action,omitempty"`
+	Message string                 `json:"error,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Warn("writing webhook response failed", "error", err)
+	}
+}
@@ -0,0 +1,145 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/service"
+)
+
+// todoistWebhookPayload ist der Umschlag eines Todoist-Webhooks
+type todoistWebhookPayload struct {
+	EventName string          `json:"event_name"`
+	EventData json.RawMessage `json:"event_data"`
+	// EventDataExtra enthält bei item:updated den Task vor der Änderung
+	EventDataExtra *struct {
+		OldItem *todoistItem `json:"old_item"`
+	} `json:"event_data_extra"`
+}
+
+type todoistItem struct {
+	ID        string `json:"id"`
+	Content   string `json:"content"`
+	ProjectID string `json:"project_id"`
+	Due       *struct {
+		Date string `json:"date"`
+	} `json:"due"`
+}
+
+type todoistNote struct {
+	ID      string       `json:"id"`
+	ItemID  string       `json:"item_id"`
+	Content string       `json:"content"`
+	Item    *todoistItem `json:"item"`
+}
+
+// handleTodoist verarbeitet Todoist-Webhooks und überträgt Änderungen nach GitLab
+func (s *Server) handleTodoist(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		writeJSON(w, http.StatusRequestEntityTooLarge, webhookResponse{Status: "error", Message: err.Error()})
+		return
+	}
+
+	if !validTodoistSignature(body, r.Header.Get("X-Todoist-Hmac-SHA256"), s.config.TodoistClientSecret) {
+		writeJSON(w, http.StatusUnauthorized, webhookResponse{Status: "error", Message: "invalid X-Todoist-Hmac-SHA256"})
+		return
+	}
+
+	event, err := parseTodoistEvent(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, webhookResponse{Status: "error", Message: err.Error()})
+		return
+	}
+
+	slog.Info("todoist webhook received", "event", event.Name, "task_id", event.TaskID)
+
+	action, err := s.applyTodoistEvent(event)
+	if err != nil {
+		slog.Error("todoist webhook failed", "event", event.Name, "task_id", event.TaskID, "error", err)
+		writeJSON(w, http.StatusInternalServerError, webhookResponse{Status: "error", Action: action, Message: err.Error()})
+		return
+	}
+
+	status := "applied"
+	switch {
+	case action.Action == service.ActionIgnore:
+		status = "ignored"
+	case action.DryRun:
+		status = "dry-run"
+	}
+
+	writeJSON(w, http.StatusOK, webhookResponse{Status: status, Issue: action.IssueIID, Action: action})
+}
+
+// validTodoistSignature prüft die Base64-kodierte HMAC-SHA256-Signatur des Request-Bodys
+func validTodoistSignature(body []byte, signature string, secret string) bool {
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || signature == "" {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// parseTodoistEvent wandelt Item- und Note-Events in ein service.TodoistEvent um
+func parseTodoistEvent(body []byte) (service.TodoistEvent, error) {
+	var payload todoistWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return service.TodoistEvent{}, fmt.Errorf("invalid JSON payload")
+	}
+
+	event := service.TodoistEvent{Name: payload.EventName}
+
+	switch {
+	case strings.HasPrefix(payload.EventName, "item:"):
+		var item todoistItem
+		if err := json.Unmarshal(payload.EventData, &item); err != nil {
+			return event, fmt.Errorf("invalid item event data")
+		}
+		applyTodoistItem(&event, item)
+
+		// Ohne alten Stand ist nicht erkennbar, ob sich die Fälligkeit geändert hat
+		if payload.EventDataExtra != nil && payload.EventDataExtra.OldItem != nil {
+			event.DueChanged = payload.EventDataExtra.OldItem.dueDate() != item.dueDate()
+		}
+
+	case strings.HasPrefix(payload.EventName, "note:"):
+		var note todoistNote
+		if err := json.Unmarshal(payload.EventData, &note); err != nil {
+			return event, fmt.Errorf("invalid note event data")
+		}
+		event.TaskID = note.ItemID
+		event.NoteContent = note.Content
+		if note.Item != nil {
+			applyTodoistItem(&event, *note.Item)
+		}
+	}
+
+	return event, nil
+}
+
+func applyTodoistItem(event *service.TodoistEvent, item todoistItem) {
+	event.TaskID = item.ID
+	event.TaskContent = item.Content
+	event.ProjectID = item.ProjectID
+	event.DueDate = item.dueDate()
+}
+
+// dueDate liefert das Fälligkeitsdatum des Tasks (leer ohne Datum)
+func (i todoistItem) dueDate() string {
+	if i.Due == nil {
+		return ""
+	}
+	return i.Due.Date
+}
@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/config"
+	"hufschlaeger.net/gitlab-tasks-exporter/internal/service"
+)
+
+const todoistTestSecret = "client-secret"
+
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// newTodoistTestServer erstellt einen Server, dessen Todoist-Events aufgezeichnet werden
+func newTodoistTestServer(result *service.ReverseAction, applyErr error) (*Server, *[]service.TodoistEvent) {
+	events := &[]service.TodoistEvent{}
+	s := &Server{
+		config: &config.Config{TodoistClientSecret: todoistTestSecret},
+		applyTodoistEvent: func(event service.TodoistEvent) (*service.ReverseAction, error) {
+			*events = append(*events, event)
+			return result, applyErr
+		},
+	}
+	return s, events
+}
+
+func postTodoist(t *testing.T, s *Server, signature string, body []byte) (*httptest.ResponseRecorder, webhookResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/todoist", bytes.NewReader(body))
+	req.Header.Set("X-Todoist-Hmac-SHA256", signature)
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	var resp webhookResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec, resp
+}
+
+func TestTodoistHook_ItemCompleted(t *testing.T) {
+	s, events := newTodoistTestServer(&service.ReverseAction{Event: "item:completed", Action: service.ActionClose, IssueIID: "23"}, nil)
+	body := loadFixture(t, "todoist_item_completed.json")
+
+	rec, resp := postTodoist(t, s, sign(body, todoistTestSecret), body)
+
+	if rec.Code != http.StatusOK || resp.Status != "applied" || resp.Issue != "23" {
+		t.Fatalf("unexpected response %d: %s", rec.Code, rec.Body.String())
+	}
+	want := service.TodoistEvent{
+		Name:        "item:completed",
+		TaskID:      "6X7rM8997g3RQmvh",
+		TaskContent: "#23 - New API: create/update/delete file",
+		ProjectID:   "6Jf8VQXxpwv56VQ7",
+		DueDate:     "2025-02-14",
+	}
+	if len(*events) != 1 || (*events)[0] != want {
+		t.Fatalf("unexpected events: %+v", *events)
+	}
+}
+
+func TestTodoistHook_NoteAdded(t *testing.T) {
+	s, events := newTodoistTestServer(&service.ReverseAction{Action: service.ActionComment, DryRun: true}, nil)
+	body := loadFixture(t, "todoist_note_added.json")
+
+	rec, resp := postTodoist(t, s, sign(body, todoistTestSecret), body)
+
+	if rec.Code != http.StatusOK || resp.Status != "dry-run" {
+		t.Fatalf("unexpected response %d: %s", rec.Code, rec.Body.String())
+	}
+	event := (*events)[0]
+	if event.TaskID != "6X7rM8997g3RQmvh" || event.NoteContent != "Deployed to staging, please verify." || event.TaskContent == "" {
+		t.Fatalf("unexpected note event: %+v", event)
+	}
+}
+
+func TestTodoistHook_ItemUpdatedDueChange(t *testing.T) {
+	s, events := newTodoistTestServer(&service.ReverseAction{Action: service.ActionUpdate}, nil)
+
+	// Umbenennung ohne Datum: die Fälligkeit bleibt unverändert
+	body := loadFixture(t, "todoist_item_updated.json")
+	postTodoist(t, s, sign(body, todoistTestSecret), body)
+
+	// Neues Datum gegenüber old_item
+	withDue := bytes.Replace(body, []byte(`"due": null,
+    "id"`), []byte(`"due": {"date": "2025-03-01"},
+    "id"`), 1)
+	postTodoist(t, s, sign(withDue, todoistTestSecret), withDue)
+
+	if len(*events) != 2 {
+		t.Fatalf("expected two events, got %+v", *events)
+	}
+	if renamed := (*events)[0]; renamed.DueChanged || renamed.ProjectID != "6Jf8VQXxpwv56VQ7" {
+		t.Errorf("rename must not change the due date: %+v", renamed)
+	}
+	if dated := (*events)[1]; !dated.DueChanged || dated.DueDate != "2025-03-01" {
+		t.Errorf("new due date should be reported as changed: %+v", dated)
+	}
+}
+
+func TestTodoistHook_InvalidSignature(t *testing.T) {
+	s, events := newTodoistTestServer(&service.ReverseAction{}, nil)
+	body := loadFixture(t, "todoist_item_completed.json")
+
+	for _, signature := range []string{"", "not-base64!", sign(body, "wrong-secret")} {
+		rec, _ := postTodoist(t, s, signature, body)
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("signature %q: expected 401, got %d", signature, rec.Code)
+		}
+	}
+	if len(*events) != 0 {
+		t.Fatalf("no events expected, got %+v", *events)
+	}
+}
+
+func TestTodoistHook_IgnoredAndError(t *testing.T) {
+	body := loadFixture(t, "todoist_item_completed.json")
+
+	s, _ := newTodoistTestServer(&service.ReverseAction{Action: service.ActionIgnore}, nil)
+	if _, resp := postTodoist(t, s, sign(body, todoistTestSecret), body); resp.Status != "ignored" {
+		t.Fatalf("expected ignored, got %+v", resp)
+	}
+
+	s, _ = newTodoistTestServer(&service.ReverseAction{Action: service.ActionClose}, errors.New("gitlab down"))
+	rec, resp := postTodoist(t, s, sign(body, todoistTestSecret), body)
+	if rec.Code != http.StatusInternalServerError || resp.Message != "gitlab down" {
+		t.Fatalf("expected 500, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandler_RoutesDependOnSecrets(t *testing.T) {
+	s := &Server{config: &config.Config{GitLabWebhookSecret: "x"}}
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/todoist", bytes.NewReader([]byte(`{}`)))
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("todoist endpoint should be disabled without client secret, got %d", rec.Code)
+	}
+}
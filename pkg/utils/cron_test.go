@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	base := time.Date(2025, 3, 14, 10, 7, 30, 0, time.UTC) // Freitag
+
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 3, 14, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 3, 14, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2025, 3, 15, 9, 0, 0, 0, time.UTC)},
+		{"30 8-18/2 * * 1-5", time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC)},
+		{"0 6 * * 1", time.Date(2025, 3, 17, 6, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2025, 3, 16, 12, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 6)}, // Freitag, 21.03.
+		// Schritte und volle Bereiche schränken nicht ein: Tag UND Wochentag müssen passen
+		{"0 0 */2 * 1", time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC)}, // ungerader Montag, nicht Samstag, 15.03.
+		{"0 0 1-31 * 1", time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 0-7", time.Date(2025, 4, 13, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, c := range cases {
+		schedule, err := ParseCron(c.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) error = %v", c.expr, err)
+		}
+		if got := schedule.Next(base); !got.Equal(c.want) {
+			t.Errorf("ParseCron(%q).Next() = %v, want %v", c.expr, got, c.want)
+		}
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) expected error", expr)
+		}
+	}
+}
+
+func TestParseCron_ImpossibleDate(t *testing.T) {
+	schedule, err := ParseCron("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("ParseCron error = %v", err)
+	}
+	if got := schedule.Next(time.Now()); !got.IsZero() {
+		t.Fatalf("expected zero time for impossible schedule, got %v", got)
+	}
+}
@@ -1,7 +1,7 @@
 package utils
 
 import (
-	"fmt"
+	"log/slog"
 	"time"
 )
 
@@ -26,18 +26,24 @@ func ConvertToTodoistDate(gitlabDate string) string {
 		}
 	}
 
-	fmt.Printf("⚠️  Unbekanntes Datumsformat: %s\n", gitlabDate)
+	slog.Warn("unknown date format", "date", gitlabDate)
 	return gitlabDate
 }
 
 // FormatDateForDisplay formatiert Datum für schöne Anzeige
 func FormatDateForDisplay(dateStr string) string {
+	return FormatDate(dateStr, "02.01.2006", "Kein Datum")
+}
+
+// FormatDate formatiert ein Datum (YYYY-MM-DD) im angegebenen Layout;
+// für ein leeres Datum wird empty geliefert
+func FormatDate(dateStr string, layout string, empty string) string {
 	if dateStr == "" {
-		return "Kein Datum"
+		return empty
 	}
 
 	if parsed, err := time.Parse("2006-01-02", dateStr); err == nil {
-		return parsed.Format("02.01.2006")
+		return parsed.Format(layout)
 	}
 
 	return dateStr
@@ -0,0 +1,173 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	mdHeading    = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdBullet     = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	mdOrdered    = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	mdTaskItem   = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	mdRule       = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	mdCodeSpan   = regexp.MustCompile("`([^`]+)`")
+	mdLink       = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBold       = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	mdItalic     = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	mdStrike     = regexp.MustCompile(`~~([^~]+)~~`)
+	mdSafeScheme = regexp.MustCompile(`(?i)^(https?://|mailto:|/|#)`)
+)
+
+// MarkdownToHTML wandelt GitLab Markdown in bereinigtes HTML um.
+//
+// Unterstützt wird eine Teilmenge: Überschriften, Absätze, Listen (inkl. Task-Listen),
+// Zitate, Code-Blöcke, Trennlinien sowie Inline-Code, Links, fett, kursiv und durchgestrichen.
+// Sämtlicher Text wird escaped, eingebettetes HTML erscheint also als Text; Links
+// sind nur mit http(s), mailto oder relativen Zielen erlaubt.
+func MarkdownToHTML(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	var b strings.Builder
+	var paragraph []string
+	list := ""
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + strings.Join(paragraph, "<br>\n") + "</p>\n")
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if list != "" {
+			b.WriteString("</" + list + ">\n")
+			list = ""
+		}
+	}
+	openList := func(tag string) {
+		if list != tag {
+			closeList()
+			b.WriteString("<" + tag + ">\n")
+			list = tag
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			flushParagraph()
+			closeList()
+			fence := trimmed[:3]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			b.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case trimmed == "":
+			flushParagraph()
+			closeList()
+
+		case mdHeading.MatchString(trimmed):
+			flushParagraph()
+			closeList()
+			match := mdHeading.FindStringSubmatch(trimmed)
+			// Überschriften der Beschreibung liegen unterhalb der Überschriften des Reports
+			level := len(match[1]) + 3
+			if level > 6 {
+				level = 6
+			}
+			tag := "h" + string(rune('0'+level))
+			b.WriteString("<" + tag + ">" + renderInlineMarkdown(match[2]) + "</" + tag + ">\n")
+
+		case mdRule.MatchString(trimmed):
+			flushParagraph()
+			closeList()
+			b.WriteString("<hr>\n")
+
+		case mdBullet.MatchString(line):
+			flushParagraph()
+			openList("ul")
+			b.WriteString("<li>" + renderListItem(mdBullet.FindStringSubmatch(line)[1]) + "</li>\n")
+
+		case mdOrdered.MatchString(line):
+			flushParagraph()
+			openList("ol")
+			b.WriteString("<li>" + renderListItem(mdOrdered.FindStringSubmatch(line)[1]) + "</li>\n")
+
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			closeList()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quote = append(quote, strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">"), " "))
+			}
+			i--
+			b.WriteString("<blockquote>\n" + MarkdownToHTML(strings.Join(quote, "\n")) + "</blockquote>\n")
+
+		default:
+			closeList()
+			paragraph = append(paragraph, renderInlineMarkdown(trimmed))
+		}
+	}
+
+	flushParagraph()
+	closeList()
+	return b.String()
+}
+
+// renderListItem stellt Task-Listen ("[ ]", "[x]") als Kästchen dar
+func renderListItem(item string) string {
+	if match := mdTaskItem.FindStringSubmatch(item); match != nil {
+		box := "☐ "
+		if match[1] != " " {
+			box = "☑ "
+		}
+		return box + renderInlineMarkdown(match[2])
+	}
+	return renderInlineMarkdown(item)
+}
+
+// renderInlineMarkdown escaped den Text und wandelt Inline-Formatierungen um.
+// Code-Spans werden nicht weiter formatiert.
+func renderInlineMarkdown(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range mdCodeSpan.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(renderLinks(text[last:loc[0]]))
+		b.WriteString("<code>" + html.EscapeString(text[loc[2]:loc[3]]) + "</code>")
+		last = loc[1]
+	}
+	b.WriteString(renderLinks(text[last:]))
+	return b.String()
+}
+
+func renderLinks(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range mdLink.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(renderEmphasis(text[last:loc[0]]))
+		label := renderEmphasis(text[loc[2]:loc[3]])
+		target := text[loc[4]:loc[5]]
+		if mdSafeScheme.MatchString(target) {
+			b.WriteString(`<a href="` + html.EscapeString(target) + `" rel="noopener noreferrer">` + label + "</a>")
+		} else {
+			// Unsichere Ziele (z.B. javascript:) werden nur als Text ausgegeben
+			b.WriteString(label)
+		}
+		last = loc[1]
+	}
+	b.WriteString(renderEmphasis(text[last:]))
+	return b.String()
+}
+
+func renderEmphasis(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = mdBold.ReplaceAllString(escaped, "<strong>$1</strong>")
+	escaped = mdItalic.ReplaceAllString(escaped, "<em>$1</em>")
+	return mdStrike.ReplaceAllString(escaped, "<del>$1</del>")
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	input := "## Schritte\n\n" +
+		"Erst **fett**, dann *kursiv* und `a < b`.\nZweite Zeile mit ~~alt~~.\n\n" +
+		"- [x] erledigt\n- [ ] offen\n\n" +
+		"1. eins\n2. [Doku](https://example.com/?a=1&b=2)\n\n" +
+		"> Zitat\n\n" +
+		"```go\nif a < b {}\n```\n\n" +
+		"---"
+
+	want := "<h5>Schritte</h5>\n" +
+		"<p>Erst <strong>fett</strong>, dann <em>kursiv</em> und <code>a &lt; b</code>.<br>\nZweite Zeile mit <del>alt</del>.</p>\n" +
+		"<ul>\n<li>☑ erledigt</li>\n<li>☐ offen</li>\n</ul>\n" +
+		"<ol>\n<li>eins</li>\n<li><a href=\"https://example.com/?a=1&amp;b=2\" rel=\"noopener noreferrer\">Doku</a></li>\n</ol>\n" +
+		"<blockquote>\n<p>Zitat</p>\n</blockquote>\n" +
+		"<pre><code>if a &lt; b {}</code></pre>\n" +
+		"<hr>\n"
+
+	if got := MarkdownToHTML(input); got != want {
+		t.Errorf("unexpected HTML:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMarkdownToHTML_Sanitizes(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		forbidden string
+	}{
+		{"script tag", "<script>alert(1)</script>", "<script>"},
+		{"event handler", `<img src=x onerror="alert(1)">`, "<img"},
+		{"javascript link", "[klick](javascript:alert(1))", "href"},
+		{"attribute breakout", `[x](https://a.example/"onmouseover="alert(1))`, `"onmouseover`},
+		{"html in code", "`<b>`", "<b>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToHTML(tt.input); strings.Contains(got, tt.forbidden) {
+				t.Errorf("MarkdownToHTML(%q) = %q contains %q", tt.input, got, tt.forbidden)
+			}
+		})
+	}
+}
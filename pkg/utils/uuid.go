@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"fmt"
+)
+
+// NamespaceURL ist der Namespace für URL-basierte UUIDs nach RFC 9562
+var NamespaceURL = [16]byte{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+// UUIDv5 erzeugt eine namensbasierte UUID (SHA-1). Gleicher Namespace und Name
+// ergeben immer dieselbe UUID.
+func UUIDv5(namespace [16]byte, name string) string {
+	h := sha1.New()
+	h.Write(namespace[:])
+	h.Write([]byte(name))
+	sum := h.Sum(nil)
+
+	var uuid [16]byte
+	copy(uuid[:], sum[:16])
+	uuid[6] = (uuid[6] & 0x0f) | 0x50 // Version 5
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variante RFC 9562
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+}
@@ -0,0 +1,18 @@
+package utils
+
+import "testing"
+
+func TestUUIDv5(t *testing.T) {
+	// Referenzwert aus Python: uuid.uuid5(uuid.NAMESPACE_URL, "https://gitlab.com/g/p/-/issues/1")
+	got := UUIDv5(NamespaceURL, "https://gitlab.com/g/p/-/issues/1")
+	if got != "5e47d267-5707-5c13-9fc9-5bff35703875" {
+		t.Errorf("UUIDv5() = %s", got)
+	}
+
+	if UUIDv5(NamespaceURL, "a") != UUIDv5(NamespaceURL, "a") {
+		t.Error("UUIDv5 must be deterministic")
+	}
+	if UUIDv5(NamespaceURL, "a") == UUIDv5(NamespaceURL, "b") {
+		t.Error("different names must give different UUIDs")
+	}
+}